@@ -0,0 +1,185 @@
+package azurerm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/mitchellh/go-homedir"
+)
+
+// azureCliProfile is the subset of the `azureProfile.json` file written by the
+// Azure CLI which lists the Subscriptions available to the logged in user.
+type azureCliProfile struct {
+	Subscriptions []azureCliSubscription `json:"subscriptions"`
+}
+
+type azureCliSubscription struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	IsDefault bool   `json:"isDefault"`
+	TenantID  string `json:"tenantId"`
+	User      struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"user"`
+}
+
+// azureCliToken is an entry in the `accessTokens.json` token cache written by the Azure CLI.
+type azureCliToken struct {
+	AccessToken  string `json:"accessToken"`
+	Authority    string `json:"_authority"`
+	ClientID     string `json:"_clientId"`
+	ExpiresOn    string `json:"expiresOn"`
+	RefreshToken string `json:"refreshToken"`
+	Resource     string `json:"resource"`
+	TokenType    string `json:"tokenType"`
+	UserID       string `json:"userId"`
+}
+
+// the Azure CLI writes out the expiry in local time, without a timezone
+const azureCliTokenExpiryFormat = "2006-01-02 15:04:05.999999"
+
+func (t azureCliToken) expiresOn() (time.Time, error) {
+	return time.ParseInLocation(azureCliTokenExpiryFormat, t.ExpiresOn, time.Local)
+}
+
+// adalToken converts the cached token into an adal.Token for the specified resource. The
+// Azure CLI issues multi-resource refresh tokens, so when the cached access token is for a
+// different resource only the refresh token is kept - which is exchanged on first use.
+func (t azureCliToken) adalToken(resource string) (*adal.Token, error) {
+	token := adal.Token{
+		RefreshToken: t.RefreshToken,
+		Resource:     resource,
+		Type:         t.TokenType,
+	}
+
+	if strings.TrimSuffix(t.Resource, "/") == strings.TrimSuffix(resource, "/") {
+		expiresOn, err := t.expiresOn()
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing the expiry date %q of the Azure CLI token: %s", t.ExpiresOn, err)
+		}
+
+		token.AccessToken = t.AccessToken
+		token.ExpiresOn = strconv.FormatInt(expiresOn.Unix(), 10)
+	}
+
+	return &token, nil
+}
+
+// loadAzureCliCredentials populates the Subscription ID, Tenant ID and token from the
+// credentials cached by the Azure CLI (e.g. after running `az login`). If a Subscription
+// ID is already configured it's used, otherwise the default Subscription is selected; a Tenant
+// ID which is configured must be the tenant of that Subscription.
+func (c *Config) loadAzureCliCredentials() error {
+	dir, err := homedir.Expand("~/.azure")
+	if err != nil {
+		return fmt.Errorf("Error finding the Azure CLI configuration directory: %s", err)
+	}
+
+	var profile azureCliProfile
+	if err := readAzureCliFile(filepath.Join(dir, "azureProfile.json"), &profile); err != nil {
+		return err
+	}
+
+	subscription, err := profile.findSubscription(c.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if c.TenantID != "" && !strings.EqualFold(c.TenantID, subscription.TenantID) {
+		return fmt.Errorf("The Tenant ID %q doesn't match the Tenant ID of the Azure CLI subscription %q (%q) - either remove it or log in to that tenant using `az login --tenant`", c.TenantID, subscription.ID, subscription.TenantID)
+	}
+
+	var tokens []azureCliToken
+	if err := readAzureCliFile(filepath.Join(dir, "accessTokens.json"), &tokens); err != nil {
+		return err
+	}
+
+	token, err := findAzureCliToken(tokens, subscription)
+	if err != nil {
+		return err
+	}
+
+	c.SubscriptionID = subscription.ID
+	c.TenantID = subscription.TenantID
+	c.azureCliToken = token
+	return nil
+}
+
+func readAzureCliFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading %q - have you logged in using `az login`? %s", path, err)
+	}
+
+	// the Azure CLI writes these files out with a UTF-8 Byte Order Mark
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("Error parsing %q: %s", path, err)
+	}
+
+	return nil
+}
+
+func (p azureCliProfile) findSubscription(subscriptionId string) (*azureCliSubscription, error) {
+	for _, subscription := range p.Subscriptions {
+		if subscriptionId == "" && subscription.IsDefault {
+			return &subscription, nil
+		}
+
+		if subscriptionId != "" && strings.EqualFold(subscription.ID, subscriptionId) {
+			return &subscription, nil
+		}
+	}
+
+	if subscriptionId == "" {
+		return nil, fmt.Errorf("No default Subscription was found in the Azure CLI profile - please run `az account set`")
+	}
+
+	return nil, fmt.Errorf("Subscription %q was not found in the Azure CLI profile - please run `az account list`", subscriptionId)
+}
+
+// findAzureCliToken returns the most recently issued token for the user and tenant of
+// the specified Subscription.
+func findAzureCliToken(tokens []azureCliToken, subscription *azureCliSubscription) (*azureCliToken, error) {
+	var found *azureCliToken
+	var foundExpiry time.Time
+
+	for i, token := range tokens {
+		if !strings.EqualFold(token.UserID, subscription.User.Name) {
+			continue
+		}
+
+		if !strings.HasSuffix(strings.ToLower(strings.TrimSuffix(token.Authority, "/")), "/"+strings.ToLower(subscription.TenantID)) {
+			continue
+		}
+
+		if token.RefreshToken == "" {
+			continue
+		}
+
+		expiresOn, err := token.expiresOn()
+		if err != nil {
+			continue
+		}
+
+		if found == nil || expiresOn.After(foundExpiry) {
+			found = &tokens[i]
+			foundExpiry = expiresOn
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("No Azure CLI token was found for %q in Tenant %q - please run `az login`", subscription.User.Name, subscription.TenantID)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,188 @@
+package azurerm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+func TestAzureCliProfileFindSubscription(t *testing.T) {
+	profile := azureCliProfile{
+		Subscriptions: []azureCliSubscription{
+			{
+				ID:       "00000000-0000-0000-0000-000000000001",
+				TenantID: "10000000-0000-0000-0000-000000000000",
+			},
+			{
+				ID:        "00000000-0000-0000-0000-000000000002",
+				TenantID:  "20000000-0000-0000-0000-000000000000",
+				IsDefault: true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		subscriptionId string
+		expectedTenant string
+		expectError    bool
+	}{
+		{
+			subscriptionId: "",
+			expectedTenant: "20000000-0000-0000-0000-000000000000",
+		},
+		{
+			subscriptionId: "00000000-0000-0000-0000-000000000001",
+			expectedTenant: "10000000-0000-0000-0000-000000000000",
+		},
+		{
+			subscriptionId: "00000000-0000-0000-0000-000000000099",
+			expectError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		subscription, err := profile.findSubscription(tc.subscriptionId)
+		if tc.expectError {
+			if err == nil {
+				t.Fatalf("Expected an error finding Subscription %q but didn't get one", tc.subscriptionId)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error finding Subscription %q: %s", tc.subscriptionId, err)
+		}
+		if subscription.TenantID != tc.expectedTenant {
+			t.Fatalf("Expected Tenant %q but got %q", tc.expectedTenant, subscription.TenantID)
+		}
+	}
+
+	if _, err := (azureCliProfile{}).findSubscription(""); err == nil {
+		t.Fatalf("Expected an error when there's no default Subscription")
+	}
+}
+
+func TestFindAzureCliToken(t *testing.T) {
+	subscription := &azureCliSubscription{
+		TenantID: "10000000-0000-0000-0000-000000000000",
+	}
+	subscription.User.Name = "user@example.com"
+
+	tokens := []azureCliToken{
+		{
+			AccessToken:  "other-user",
+			Authority:    "https://login.microsoftonline.com/10000000-0000-0000-0000-000000000000",
+			ExpiresOn:    "2017-08-25 15:00:00.000000",
+			RefreshToken: "refresh",
+			UserID:       "someone-else@example.com",
+		},
+		{
+			AccessToken:  "other-tenant",
+			Authority:    "https://login.microsoftonline.com/20000000-0000-0000-0000-000000000000",
+			ExpiresOn:    "2017-08-25 15:00:00.000000",
+			RefreshToken: "refresh",
+			UserID:       "user@example.com",
+		},
+		{
+			AccessToken:  "older",
+			Authority:    "https://login.microsoftonline.com/10000000-0000-0000-0000-000000000000",
+			ExpiresOn:    "2017-08-25 14:00:00.000000",
+			RefreshToken: "refresh",
+			UserID:       "user@example.com",
+		},
+		{
+			AccessToken:  "newest",
+			Authority:    "https://login.microsoftonline.com/10000000-0000-0000-0000-000000000000/",
+			ExpiresOn:    "2017-08-25 15:00:00.000000",
+			RefreshToken: "refresh",
+			UserID:       "User@Example.com",
+		},
+	}
+
+	token, err := findAzureCliToken(tokens, subscription)
+	if err != nil {
+		t.Fatalf("Unexpected error finding token: %s", err)
+	}
+	if token.AccessToken != "newest" {
+		t.Fatalf("Expected the newest token to be found but got %q", token.AccessToken)
+	}
+
+	if _, err := findAzureCliToken(tokens[:2], subscription); err == nil {
+		t.Fatalf("Expected an error when no token matches the user and tenant")
+	}
+}
+
+func TestAzureCliTokenAdalToken(t *testing.T) {
+	cliToken := azureCliToken{
+		AccessToken:  "access",
+		ExpiresOn:    "2017-08-25 15:00:00.123456",
+		RefreshToken: "refresh",
+		Resource:     "https://management.core.windows.net/",
+		TokenType:    "Bearer",
+	}
+
+	token, err := cliToken.adalToken("https://management.core.windows.net")
+	if err != nil {
+		t.Fatalf("Unexpected error converting token: %s", err)
+	}
+	if token.AccessToken != "access" || token.RefreshToken != "refresh" {
+		t.Fatalf("Expected the access and refresh tokens to be kept but got %+v", token)
+	}
+
+	expected := time.Date(2017, 8, 25, 15, 0, 0, 123456000, time.Local).Unix()
+	if token.ExpiresOn != strconv.FormatInt(expected, 10) {
+		t.Fatalf("Expected the token to expire on %d but got %s", expected, token.ExpiresOn)
+	}
+
+	token, err = cliToken.adalToken("https://graph.windows.net/")
+	if err != nil {
+		t.Fatalf("Unexpected error converting token: %s", err)
+	}
+	if token.AccessToken != "" || token.RefreshToken != "refresh" || token.Resource != "https://graph.windows.net/" {
+		t.Fatalf("Expected only the refresh token to be kept for another resource but got %+v", token)
+	}
+	if !token.IsExpired() {
+		t.Fatalf("Expected the token for another resource to need refreshing")
+	}
+
+	cliToken.ExpiresOn = "invalid"
+	if _, err := cliToken.adalToken("https://management.core.windows.net/"); err == nil {
+		t.Fatalf("Expected an error converting a token with an invalid expiry")
+	}
+}
+
+func TestConfigLoadAzureCliCredentials_tenantMismatch(t *testing.T) {
+	home, err := ioutil.TempDir("", "azurerm-cli")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(home)
+
+	if err := os.Mkdir(filepath.Join(home, ".azure"), 0700); err != nil {
+		t.Fatalf("Error creating the Azure CLI directory: %s", err)
+	}
+	profile := `{"subscriptions":[{"id":"00000000-0000-0000-0000-000000000001","tenantId":"10000000-0000-0000-0000-000000000000","isDefault":true}]}`
+	if err := ioutil.WriteFile(filepath.Join(home, ".azure", "azureProfile.json"), []byte(profile), 0600); err != nil {
+		t.Fatalf("Error writing the Azure CLI profile: %s", err)
+	}
+
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+
+	config := Config{TenantID: "20000000-0000-0000-0000-000000000000"}
+	err = config.loadAzureCliCredentials()
+	if err == nil || !strings.Contains(err.Error(), "doesn't match") {
+		t.Fatalf("Expected an error since the Tenant ID doesn't match the Azure CLI subscription but got %v", err)
+	}
+	if config.TenantID != "20000000-0000-0000-0000-000000000000" {
+		t.Fatalf("Expected the configured Tenant ID to be left alone but got %q", config.TenantID)
+	}
+}
@@ -0,0 +1,122 @@
+package azurerm
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+
+	"golang.org/x/crypto/pkcs12"
+)
+
+// loadClientCertificate reads the certificate and RSA private key used to authenticate
+// a Service Principal from either a PKCS#12 (PFX) or a PEM encoded file.
+func loadClientCertificate(path string, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error reading Client Certificate %q: %s", path, err)
+	}
+
+	var certificate *x509.Certificate
+	var privateKey *rsa.PrivateKey
+	if bytes.Contains(data, []byte("-----BEGIN")) {
+		certificate, privateKey, err = decodePemClientCertificate(data, password)
+	} else {
+		certificate, privateKey, err = decodePfxClientCertificate(data, password)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error decoding Client Certificate %q: %s", path, err)
+	}
+
+	return certificate, privateKey, nil
+}
+
+func decodePfxClientCertificate(data []byte, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	key, certificate, err := pkcs12.Decode(data, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, fmt.Errorf("the private key is a %T but only RSA keys are supported", key)
+	}
+
+	return certificate, privateKey, nil
+}
+
+func decodePemClientCertificate(data []byte, password string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	var certificate *x509.Certificate
+	var privateKey *rsa.PrivateKey
+
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			// the first certificate is the leaf, any others make up the chain
+			if certificate != nil {
+				continue
+			}
+
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing the certificate: %s", err)
+			}
+			certificate = cert
+
+		case "RSA PRIVATE KEY", "PRIVATE KEY":
+			keyBytes := block.Bytes
+			if x509.IsEncryptedPEMBlock(block) {
+				decrypted, err := x509.DecryptPEMBlock(block, []byte(password))
+				if err != nil {
+					return nil, nil, fmt.Errorf("decrypting the private key: %s", err)
+				}
+				keyBytes = decrypted
+			}
+
+			key, err := parsePemPrivateKey(block.Type, keyBytes)
+			if err != nil {
+				return nil, nil, err
+			}
+			privateKey = key
+		}
+	}
+
+	if certificate == nil {
+		return nil, nil, fmt.Errorf("no certificate was found")
+	}
+	if privateKey == nil {
+		return nil, nil, fmt.Errorf("no RSA private key was found")
+	}
+
+	return certificate, privateKey, nil
+}
+
+func parsePemPrivateKey(blockType string, keyBytes []byte) (*rsa.PrivateKey, error) {
+	if blockType == "RSA PRIVATE KEY" {
+		key, err := x509.ParsePKCS1PrivateKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the private key: %s", err)
+		}
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the private key: %s", err)
+	}
+
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("the private key is a %T but only RSA keys are supported", key)
+	}
+
+	return privateKey, nil
+}
@@ -0,0 +1,106 @@
+package azurerm
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadClientCertificate(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Error generating private key: %s", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-acctest"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %s", err)
+	}
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("Error marshalling private key: %s", err)
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	pkcs1Pem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	pkcs8Pem := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes})
+
+	dir, err := ioutil.TempDir("", "azurerm-client-certificate")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		name        string
+		contents    []byte
+		expectError bool
+	}{
+		{
+			name:     "pkcs1.pem",
+			contents: append(append([]byte{}, certPem...), pkcs1Pem...),
+		},
+		{
+			name:     "pkcs8.pem",
+			contents: append(append([]byte{}, pkcs8Pem...), certPem...),
+		},
+		{
+			name:        "no-key.pem",
+			contents:    certPem,
+			expectError: true,
+		},
+		{
+			name:        "no-certificate.pem",
+			contents:    pkcs1Pem,
+			expectError: true,
+		},
+		{
+			name:        "invalid.pfx",
+			contents:    []byte("not a pfx"),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		path := filepath.Join(dir, tc.name)
+		if err := ioutil.WriteFile(path, tc.contents, 0600); err != nil {
+			t.Fatalf("Error writing %q: %s", path, err)
+		}
+
+		certificate, key, err := loadClientCertificate(path, "")
+		if tc.expectError {
+			if err == nil {
+				t.Fatalf("Expected an error loading %q but didn't get one", tc.name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error loading %q: %s", tc.name, err)
+		}
+		if certificate.Subject.CommonName != "terraform-acctest" {
+			t.Fatalf("Expected the certificate for %q to be loaded but got %q", tc.name, certificate.Subject.CommonName)
+		}
+		if key.N.Cmp(privateKey.N) != 0 {
+			t.Fatalf("Expected the private key for %q to match the generated key", tc.name)
+		}
+	}
+
+	if _, _, err := loadClientCertificate(filepath.Join(dir, "does-not-exist.pfx"), ""); err == nil {
+		t.Fatalf("Expected an error loading a certificate which doesn't exist")
+	}
+}
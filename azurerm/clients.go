@@ -0,0 +1,549 @@
+package azurerm
+
+import (
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/arm/appinsights"
+	"github.com/Azure/azure-sdk-for-go/arm/automation"
+	"github.com/Azure/azure-sdk-for-go/arm/cdn"
+	"github.com/Azure/azure-sdk-for-go/arm/compute"
+	"github.com/Azure/azure-sdk-for-go/arm/containerregistry"
+	"github.com/Azure/azure-sdk-for-go/arm/containerservice"
+	"github.com/Azure/azure-sdk-for-go/arm/cosmos-db"
+	"github.com/Azure/azure-sdk-for-go/arm/disk"
+	"github.com/Azure/azure-sdk-for-go/arm/dns"
+	"github.com/Azure/azure-sdk-for-go/arm/eventhub"
+	"github.com/Azure/azure-sdk-for-go/arm/graphrbac"
+	"github.com/Azure/azure-sdk-for-go/arm/keyvault"
+	"github.com/Azure/azure-sdk-for-go/arm/network"
+	"github.com/Azure/azure-sdk-for-go/arm/redis"
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
+	"github.com/Azure/azure-sdk-for-go/arm/scheduler"
+	"github.com/Azure/azure-sdk-for-go/arm/servicebus"
+	"github.com/Azure/azure-sdk-for-go/arm/sql"
+	"github.com/Azure/azure-sdk-for-go/arm/storage"
+	"github.com/Azure/azure-sdk-for-go/arm/trafficmanager"
+)
+
+// clientRegistry builds each of the SDK clients the first time it's used (rather than every
+// client being built when the provider is configured), keyed by the service it manages - such
+// as `network.VirtualNetworks`.
+type clientRegistry struct {
+	lock    sync.Mutex
+	clients map[string]interface{}
+}
+
+func newClientRegistry() *clientRegistry {
+	return &clientRegistry{
+		clients: make(map[string]interface{}),
+	}
+}
+
+// get returns the client for the service, building it (using build) if it hasn't been used.
+func (r *clientRegistry) get(service string, build func(service string) interface{}) interface{} {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	client, ok := r.clients[service]
+	if !ok {
+		client = build(service)
+		r.clients[service] = client
+	}
+	return client
+}
+
+func (armClient *ArmClient) availSetClient() compute.AvailabilitySetsClient {
+	return armClient.clients.get("compute.AvailabilitySets", func(service string) interface{} {
+		client := compute.NewAvailabilitySetsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(compute.AvailabilitySetsClient)
+}
+
+func (armClient *ArmClient) usageOpsClient() compute.UsageClient {
+	return armClient.clients.get("compute.Usage", func(service string) interface{} {
+		client := compute.NewUsageClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(compute.UsageClient)
+}
+
+func (armClient *ArmClient) vmExtensionImageClient() compute.VirtualMachineExtensionImagesClient {
+	return armClient.clients.get("compute.VirtualMachineExtensionImages", func(service string) interface{} {
+		client := compute.NewVirtualMachineExtensionImagesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(compute.VirtualMachineExtensionImagesClient)
+}
+
+func (armClient *ArmClient) vmExtensionClient() compute.VirtualMachineExtensionsClient {
+	return armClient.clients.get("compute.VirtualMachineExtensions", func(service string) interface{} {
+		client := compute.NewVirtualMachineExtensionsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(compute.VirtualMachineExtensionsClient)
+}
+
+func (armClient *ArmClient) vmScaleSetClient() compute.VirtualMachineScaleSetsClient {
+	return armClient.clients.get("compute.VirtualMachineScaleSets", func(service string) interface{} {
+		client := compute.NewVirtualMachineScaleSetsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(compute.VirtualMachineScaleSetsClient)
+}
+
+func (armClient *ArmClient) vmImageClient() compute.VirtualMachineImagesClient {
+	return armClient.clients.get("compute.VirtualMachineImages", func(service string) interface{} {
+		client := compute.NewVirtualMachineImagesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(compute.VirtualMachineImagesClient)
+}
+
+func (armClient *ArmClient) vmClient() compute.VirtualMachinesClient {
+	return armClient.clients.get("compute.VirtualMachines", func(service string) interface{} {
+		client := compute.NewVirtualMachinesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(compute.VirtualMachinesClient)
+}
+
+func (armClient *ArmClient) imageClient() compute.ImagesClient {
+	return armClient.clients.get("compute.Images", func(service string) interface{} {
+		client := compute.NewImagesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(compute.ImagesClient)
+}
+
+func (armClient *ArmClient) diskClient() disk.DisksClient {
+	return armClient.clients.get("disk.Disks", func(service string) interface{} {
+		client := disk.NewDisksClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(disk.DisksClient)
+}
+
+func (armClient *ArmClient) cosmosDBClient() cosmosdb.DatabaseAccountsClient {
+	return armClient.clients.get("cosmosdb.DatabaseAccounts", func(service string) interface{} {
+		client := cosmosdb.NewDatabaseAccountsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(cosmosdb.DatabaseAccountsClient)
+}
+
+func (armClient *ArmClient) automationAccountClient() automation.AccountClient {
+	return armClient.clients.get("automation.Account", func(service string) interface{} {
+		client := automation.NewAccountClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(automation.AccountClient)
+}
+
+func (armClient *ArmClient) automationRunbookClient() automation.RunbookClient {
+	return armClient.clients.get("automation.Runbook", func(service string) interface{} {
+		client := automation.NewRunbookClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(automation.RunbookClient)
+}
+
+func (armClient *ArmClient) automationCredentialClient() automation.CredentialClient {
+	return armClient.clients.get("automation.Credential", func(service string) interface{} {
+		client := automation.NewCredentialClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(automation.CredentialClient)
+}
+
+func (armClient *ArmClient) automationScheduleClient() automation.ScheduleClient {
+	return armClient.clients.get("automation.Schedule", func(service string) interface{} {
+		client := automation.NewScheduleClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(automation.ScheduleClient)
+}
+
+func (armClient *ArmClient) appGatewayClient() network.ApplicationGatewaysClient {
+	return armClient.clients.get("network.ApplicationGateways", func(service string) interface{} {
+		client := network.NewApplicationGatewaysClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.ApplicationGatewaysClient)
+}
+
+func (armClient *ArmClient) ifaceClient() network.InterfacesClient {
+	return armClient.clients.get("network.Interfaces", func(service string) interface{} {
+		client := network.NewInterfacesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.InterfacesClient)
+}
+
+func (armClient *ArmClient) expressRouteCircuitClient() network.ExpressRouteCircuitsClient {
+	return armClient.clients.get("network.ExpressRouteCircuits", func(service string) interface{} {
+		client := network.NewExpressRouteCircuitsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.ExpressRouteCircuitsClient)
+}
+
+func (armClient *ArmClient) loadBalancerClient() network.LoadBalancersClient {
+	return armClient.clients.get("network.LoadBalancers", func(service string) interface{} {
+		client := network.NewLoadBalancersClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.LoadBalancersClient)
+}
+
+func (armClient *ArmClient) localNetConnClient() network.LocalNetworkGatewaysClient {
+	return armClient.clients.get("network.LocalNetworkGateways", func(service string) interface{} {
+		client := network.NewLocalNetworkGatewaysClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.LocalNetworkGatewaysClient)
+}
+
+func (armClient *ArmClient) publicIPClient() network.PublicIPAddressesClient {
+	return armClient.clients.get("network.PublicIPAddresses", func(service string) interface{} {
+		client := network.NewPublicIPAddressesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.PublicIPAddressesClient)
+}
+
+func (armClient *ArmClient) secGroupClient() network.SecurityGroupsClient {
+	return armClient.clients.get("network.SecurityGroups", func(service string) interface{} {
+		client := network.NewSecurityGroupsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.SecurityGroupsClient)
+}
+
+func (armClient *ArmClient) secRuleClient() network.SecurityRulesClient {
+	return armClient.clients.get("network.SecurityRules", func(service string) interface{} {
+		client := network.NewSecurityRulesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.SecurityRulesClient)
+}
+
+func (armClient *ArmClient) subnetClient() network.SubnetsClient {
+	return armClient.clients.get("network.Subnets", func(service string) interface{} {
+		client := network.NewSubnetsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.SubnetsClient)
+}
+
+func (armClient *ArmClient) vnetGatewayConnectionsClient() network.VirtualNetworkGatewayConnectionsClient {
+	return armClient.clients.get("network.VirtualNetworkGatewayConnections", func(service string) interface{} {
+		client := network.NewVirtualNetworkGatewayConnectionsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.VirtualNetworkGatewayConnectionsClient)
+}
+
+func (armClient *ArmClient) vnetGatewayClient() network.VirtualNetworkGatewaysClient {
+	return armClient.clients.get("network.VirtualNetworkGateways", func(service string) interface{} {
+		client := network.NewVirtualNetworkGatewaysClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.VirtualNetworkGatewaysClient)
+}
+
+func (armClient *ArmClient) vnetClient() network.VirtualNetworksClient {
+	return armClient.clients.get("network.VirtualNetworks", func(service string) interface{} {
+		client := network.NewVirtualNetworksClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.VirtualNetworksClient)
+}
+
+func (armClient *ArmClient) vnetPeeringsClient() network.VirtualNetworkPeeringsClient {
+	return armClient.clients.get("network.VirtualNetworkPeerings", func(service string) interface{} {
+		client := network.NewVirtualNetworkPeeringsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.VirtualNetworkPeeringsClient)
+}
+
+func (armClient *ArmClient) routeTablesClient() network.RouteTablesClient {
+	return armClient.clients.get("network.RouteTables", func(service string) interface{} {
+		client := network.NewRouteTablesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.RouteTablesClient)
+}
+
+func (armClient *ArmClient) routesClient() network.RoutesClient {
+	return armClient.clients.get("network.Routes", func(service string) interface{} {
+		client := network.NewRoutesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(network.RoutesClient)
+}
+
+func (armClient *ArmClient) dnsClient() dns.RecordSetsClient {
+	return armClient.clients.get("dns.RecordSets", func(service string) interface{} {
+		client := dns.NewRecordSetsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(dns.RecordSetsClient)
+}
+
+func (armClient *ArmClient) zonesClient() dns.ZonesClient {
+	return armClient.clients.get("dns.Zones", func(service string) interface{} {
+		client := dns.NewZonesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(dns.ZonesClient)
+}
+
+func (armClient *ArmClient) cdnProfilesClient() cdn.ProfilesClient {
+	return armClient.clients.get("cdn.Profiles", func(service string) interface{} {
+		client := cdn.NewProfilesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(cdn.ProfilesClient)
+}
+
+func (armClient *ArmClient) cdnEndpointsClient() cdn.EndpointsClient {
+	return armClient.clients.get("cdn.Endpoints", func(service string) interface{} {
+		client := cdn.NewEndpointsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(cdn.EndpointsClient)
+}
+
+func (armClient *ArmClient) containerRegistryClient() containerregistry.RegistriesClient {
+	return armClient.clients.get("containerregistry.Registries", func(service string) interface{} {
+		client := containerregistry.NewRegistriesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(containerregistry.RegistriesClient)
+}
+
+func (armClient *ArmClient) containerServicesClient() containerservice.ContainerServicesClient {
+	return armClient.clients.get("containerservice.ContainerServices", func(service string) interface{} {
+		client := containerservice.NewContainerServicesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(containerservice.ContainerServicesClient)
+}
+
+func (armClient *ArmClient) eventHubClient() eventhub.EventHubsClient {
+	return armClient.clients.get("eventhub.EventHubs", func(service string) interface{} {
+		client := eventhub.NewEventHubsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(eventhub.EventHubsClient)
+}
+
+func (armClient *ArmClient) eventHubConsumerGroupClient() eventhub.ConsumerGroupsClient {
+	return armClient.clients.get("eventhub.ConsumerGroups", func(service string) interface{} {
+		client := eventhub.NewConsumerGroupsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(eventhub.ConsumerGroupsClient)
+}
+
+func (armClient *ArmClient) eventHubNamespacesClient() eventhub.NamespacesClient {
+	return armClient.clients.get("eventhub.Namespaces", func(service string) interface{} {
+		client := eventhub.NewNamespacesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(eventhub.NamespacesClient)
+}
+
+func (armClient *ArmClient) providers() resources.ProvidersClient {
+	return armClient.clients.get("resources.Providers", func(service string) interface{} {
+		client := resources.NewProvidersClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(resources.ProvidersClient)
+}
+
+func (armClient *ArmClient) resourceGroupClient() resources.GroupsClient {
+	return armClient.clients.get("resources.Groups", func(service string) interface{} {
+		client := resources.NewGroupsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(resources.GroupsClient)
+}
+
+func (armClient *ArmClient) tagsClient() resources.TagsClient {
+	return armClient.clients.get("resources.Tags", func(service string) interface{} {
+		client := resources.NewTagsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(resources.TagsClient)
+}
+
+func (armClient *ArmClient) resourceFindClient() resources.GroupClient {
+	return armClient.clients.get("resources.Group", func(service string) interface{} {
+		client := resources.NewGroupClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(resources.GroupClient)
+}
+
+func (armClient *ArmClient) jobsClient() scheduler.JobsClient {
+	return armClient.clients.get("scheduler.Jobs", func(service string) interface{} {
+		client := scheduler.NewJobsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(scheduler.JobsClient)
+}
+
+func (armClient *ArmClient) jobsCollectionsClient() scheduler.JobCollectionsClient {
+	return armClient.clients.get("scheduler.JobCollections", func(service string) interface{} {
+		client := scheduler.NewJobCollectionsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(scheduler.JobCollectionsClient)
+}
+
+func (armClient *ArmClient) storageServiceClient() storage.AccountsClient {
+	return armClient.clients.get("storage.Accounts", func(service string) interface{} {
+		client := storage.NewAccountsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(storage.AccountsClient)
+}
+
+func (armClient *ArmClient) storageUsageClient() storage.UsageClient {
+	return armClient.clients.get("storage.Usage", func(service string) interface{} {
+		client := storage.NewUsageClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(storage.UsageClient)
+}
+
+func (armClient *ArmClient) deploymentsClient() resources.DeploymentsClient {
+	return armClient.clients.get("resources.Deployments", func(service string) interface{} {
+		client := resources.NewDeploymentsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(resources.DeploymentsClient)
+}
+
+func (armClient *ArmClient) redisClient() redis.GroupClient {
+	return armClient.clients.get("redis.Group", func(service string) interface{} {
+		client := redis.NewGroupClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(redis.GroupClient)
+}
+
+func (armClient *ArmClient) trafficManagerProfilesClient() trafficmanager.ProfilesClient {
+	return armClient.clients.get("trafficmanager.Profiles", func(service string) interface{} {
+		client := trafficmanager.NewProfilesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(trafficmanager.ProfilesClient)
+}
+
+func (armClient *ArmClient) trafficManagerEndpointsClient() trafficmanager.EndpointsClient {
+	return armClient.clients.get("trafficmanager.Endpoints", func(service string) interface{} {
+		client := trafficmanager.NewEndpointsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(trafficmanager.EndpointsClient)
+}
+
+func (armClient *ArmClient) serviceBusNamespacesClient() servicebus.NamespacesClient {
+	return armClient.clients.get("servicebus.Namespaces", func(service string) interface{} {
+		client := servicebus.NewNamespacesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(servicebus.NamespacesClient)
+}
+
+func (armClient *ArmClient) serviceBusQueuesClient() servicebus.QueuesClient {
+	return armClient.clients.get("servicebus.Queues", func(service string) interface{} {
+		client := servicebus.NewQueuesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(servicebus.QueuesClient)
+}
+
+func (armClient *ArmClient) serviceBusTopicsClient() servicebus.TopicsClient {
+	return armClient.clients.get("servicebus.Topics", func(service string) interface{} {
+		client := servicebus.NewTopicsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(servicebus.TopicsClient)
+}
+
+func (armClient *ArmClient) serviceBusSubscriptionsClient() servicebus.SubscriptionsClient {
+	return armClient.clients.get("servicebus.Subscriptions", func(service string) interface{} {
+		client := servicebus.NewSubscriptionsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(servicebus.SubscriptionsClient)
+}
+
+func (armClient *ArmClient) keyVaultClient() keyvault.VaultsClient {
+	return armClient.clients.get("keyvault.Vaults", func(service string) interface{} {
+		client := keyvault.NewVaultsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(keyvault.VaultsClient)
+}
+
+func (armClient *ArmClient) searchServicesClient() searchServicesClient {
+	return armClient.clients.get("search.Services", func(service string) interface{} {
+		client := newSearchServicesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(searchServicesClient)
+}
+
+func (armClient *ArmClient) sqlDatabasesClient() sql.DatabasesClient {
+	return armClient.clients.get("sql.Databases", func(service string) interface{} {
+		client := sql.NewDatabasesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(sql.DatabasesClient)
+}
+
+func (armClient *ArmClient) sqlElasticPoolsClient() sql.ElasticPoolsClient {
+	return armClient.clients.get("sql.ElasticPools", func(service string) interface{} {
+		client := sql.NewElasticPoolsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(sql.ElasticPoolsClient)
+}
+
+func (armClient *ArmClient) sqlFirewallRulesClient() sql.FirewallRulesClient {
+	return armClient.clients.get("sql.FirewallRules", func(service string) interface{} {
+		client := sql.NewFirewallRulesClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(sql.FirewallRulesClient)
+}
+
+func (armClient *ArmClient) sqlServersClient() sql.ServersClient {
+	return armClient.clients.get("sql.Servers", func(service string) interface{} {
+		client := sql.NewServersClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(sql.ServersClient)
+}
+
+func (armClient *ArmClient) appInsightsClient() appinsights.ComponentsClient {
+	return armClient.clients.get("appinsights.Components", func(service string) interface{} {
+		client := appinsights.NewComponentsClientWithBaseURI(armClient.environment.ResourceManagerEndpoint, armClient.subscriptionId)
+		armClient.configureClient(&client.Client, service, armClient.authorizer)
+		return client
+	}).(appinsights.ComponentsClient)
+}
+
+func (armClient *ArmClient) servicePrincipalsClient() graphrbac.ServicePrincipalsClient {
+	return armClient.clients.get("graphrbac.ServicePrincipals", func(service string) interface{} {
+		client := graphrbac.NewServicePrincipalsClientWithBaseURI(armClient.environment.GraphEndpoint, armClient.tenantId)
+		armClient.configureClient(&client.Client, service, armClient.graphAuthorizer)
+		return client
+	}).(graphrbac.ServicePrincipalsClient)
+}
@@ -0,0 +1,60 @@
+package azurerm
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestClientRegistry_buildsEachClientOnce(t *testing.T) {
+	registry := newClientRegistry()
+
+	var lock sync.Mutex
+	built := make(map[string]int)
+	build := func(service string) interface{} {
+		lock.Lock()
+		defer lock.Unlock()
+		built[service]++
+		return service
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, service := range []string{"network.VirtualNetworks", "network.Subnets"} {
+			wg.Add(1)
+			go func(service string) {
+				defer wg.Done()
+				if client := registry.get(service, build); client != service {
+					t.Errorf("Expected the client for %q but got %v", service, client)
+				}
+			}(service)
+		}
+	}
+	wg.Wait()
+
+	for _, service := range []string{"network.VirtualNetworks", "network.Subnets"} {
+		if built[service] != 1 {
+			t.Fatalf("Expected the client for %q to be built once but it was built %d times", service, built[service])
+		}
+	}
+}
+
+func TestArmClient_clientsAreBuiltWhenUsed(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	client := server.armClient(t)
+	if _, ok := client.clients.clients["network.VirtualNetworks"]; ok {
+		t.Fatalf("Expected the Virtual Networks client not to be built until it's used")
+	}
+
+	vnetClient := client.vnetClient()
+	if _, ok := client.clients.clients["network.VirtualNetworks"]; !ok {
+		t.Fatalf("Expected the Virtual Networks client to be built once it's used")
+	}
+	if vnetClient.Sender == nil || vnetClient.Authorizer == nil {
+		t.Fatalf("Expected the Virtual Networks client to be configured")
+	}
+	if _, ok := client.clients.clients["network.Subnets"]; ok {
+		t.Fatalf("Expected only the clients which are used to be built")
+	}
+}
@@ -3,36 +3,16 @@ package azurerm
 import (
 	"context"
 	"fmt"
-	"log"
+	"net"
 	"net/http"
-	"net/http/httputil"
-
-	"github.com/Azure/azure-sdk-for-go/arm/appinsights"
-	"github.com/Azure/azure-sdk-for-go/arm/automation"
-	"github.com/Azure/azure-sdk-for-go/arm/cdn"
-	"github.com/Azure/azure-sdk-for-go/arm/compute"
-	"github.com/Azure/azure-sdk-for-go/arm/containerregistry"
-	"github.com/Azure/azure-sdk-for-go/arm/containerservice"
-	"github.com/Azure/azure-sdk-for-go/arm/cosmos-db"
-	"github.com/Azure/azure-sdk-for-go/arm/disk"
-	"github.com/Azure/azure-sdk-for-go/arm/dns"
-	"github.com/Azure/azure-sdk-for-go/arm/eventhub"
-	"github.com/Azure/azure-sdk-for-go/arm/graphrbac"
-	"github.com/Azure/azure-sdk-for-go/arm/keyvault"
-	"github.com/Azure/azure-sdk-for-go/arm/network"
-	"github.com/Azure/azure-sdk-for-go/arm/redis"
-	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
-	"github.com/Azure/azure-sdk-for-go/arm/scheduler"
-	"github.com/Azure/azure-sdk-for-go/arm/servicebus"
-	"github.com/Azure/azure-sdk-for-go/arm/sql"
-	"github.com/Azure/azure-sdk-for-go/arm/storage"
-	"github.com/Azure/azure-sdk-for-go/arm/trafficmanager"
+	"net/url"
+	"time"
+
 	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/terraform/terraform"
-	riviera "github.com/jen20/riviera/azure"
 )
 
 // ArmClient contains the handles to all the specific Azure Resource Manager
@@ -42,133 +22,183 @@ type ArmClient struct {
 	tenantId       string
 	subscriptionId string
 	environment    azure.Environment
+	usingMsi       bool
+	usingAzureCli  bool
+
+	// retryPolicy is shared between all of the clients, so that they all back off when
+	// Resource Manager reports the rate limits are close to being reached
+	retryPolicy *retryPolicy
+
+	// logRedactor removes secrets from the requests and responses written to the debug log
+	logRedactor *logRedactor
+
+	// httpClient sends the requests made by each of the SDK clients
+	httpClient *http.Client
+
+	// defaultTags are applied to every resource which supports tags, beneath the tags
+	// configured on the resource itself
+	defaultTags map[string]string
+
+	// tags matching ignoreTagKeys or ignoreTagPrefixes are managed outside of Terraform (for
+	// example by Azure Policy) and so changes to them are ignored
+	ignoreTagKeys     []string
+	ignoreTagPrefixes []string
+
+	// locations caches the locations available to the subscription, used to validate the
+	// location of each resource
+	locations *locationCache
+
+	// resourceProviders registers the Resource Providers required by the resources with the
+	// subscription before each resource is created, unless provider registration is skipped
+	resourceProviders *resourceProviderRegistrar
+
+	// operations polls the long running operations started by the SDK clients
+	operations *operationPoller
+
+	// armToken is the token used to authorize requests to the Resource Manager API,
+	// the claims within it describe the identity Terraform is running as.
+	armToken *synchronizedToken
+
+	// clients holds the SDK clients, each of which is built the first time it's used
+	clients *clientRegistry
+
+	// subscriptions holds the ArmClients for the other subscriptions resources are managed in
+	subscriptions *subscriptionClients
+
+	// metrics records the requests sent by each of the SDK clients
+	metrics *apiMetrics
+
+	// readCache caches the resources (and Storage Account keys) read by many other resources,
+	// until they're modified
+	readCache *readCache
+
+	// authorizer and graphAuthorizer authorize requests to Resource Manager (including the
+	// tokens for any auxiliary tenants) and the Graph API
+	authorizer      autorest.Authorizer
+	graphAuthorizer autorest.Authorizer
 
 	StopContext context.Context
+}
+
+// getAuthorizationToken returns a token for the specified resource (e.g. the Resource
+// Manager or Graph endpoint) using the authentication method configured for the provider.
+func (c *Config) getAuthorizationToken(oauthConfig adal.OAuthConfig, resource string) (*adal.ServicePrincipalToken, error) {
+	if c.UseMsi {
+		return c.getMsiToken(oauthConfig, resource)
+	}
+
+	if c.azureCliToken != nil {
+		token, err := c.azureCliToken.adalToken(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		return adal.NewServicePrincipalTokenFromManualToken(oauthConfig, c.azureCliToken.ClientID, resource, *token)
+	}
+
+	if c.ClientCertPath != "" {
+		certificate, privateKey, err := loadClientCertificate(c.ClientCertPath, c.ClientCertPassword)
+		if err != nil {
+			return nil, err
+		}
 
-	rivieraClient *riviera.Client
-
-	availSetClient         compute.AvailabilitySetsClient
-	usageOpsClient         compute.UsageClient
-	vmExtensionImageClient compute.VirtualMachineExtensionImagesClient
-	vmExtensionClient      compute.VirtualMachineExtensionsClient
-	vmScaleSetClient       compute.VirtualMachineScaleSetsClient
-	vmImageClient          compute.VirtualMachineImagesClient
-	vmClient               compute.VirtualMachinesClient
-	imageClient            compute.ImagesClient
-
-	diskClient                 disk.DisksClient
-	cosmosDBClient             cosmosdb.DatabaseAccountsClient
-	automationAccountClient    automation.AccountClient
-	automationRunbookClient    automation.RunbookClient
-	automationCredentialClient automation.CredentialClient
-	automationScheduleClient   automation.ScheduleClient
-
-	appGatewayClient             network.ApplicationGatewaysClient
-	ifaceClient                  network.InterfacesClient
-	expressRouteCircuitClient    network.ExpressRouteCircuitsClient
-	loadBalancerClient           network.LoadBalancersClient
-	localNetConnClient           network.LocalNetworkGatewaysClient
-	publicIPClient               network.PublicIPAddressesClient
-	secGroupClient               network.SecurityGroupsClient
-	secRuleClient                network.SecurityRulesClient
-	subnetClient                 network.SubnetsClient
-	netUsageClient               network.UsagesClient
-	vnetGatewayConnectionsClient network.VirtualNetworkGatewayConnectionsClient
-	vnetGatewayClient            network.VirtualNetworkGatewaysClient
-	vnetClient                   network.VirtualNetworksClient
-	vnetPeeringsClient           network.VirtualNetworkPeeringsClient
-	routeTablesClient            network.RouteTablesClient
-	routesClient                 network.RoutesClient
-	dnsClient                    dns.RecordSetsClient
-	zonesClient                  dns.ZonesClient
-
-	cdnProfilesClient  cdn.ProfilesClient
-	cdnEndpointsClient cdn.EndpointsClient
-
-	containerRegistryClient containerregistry.RegistriesClient
-	containerServicesClient containerservice.ContainerServicesClient
-
-	eventHubClient              eventhub.EventHubsClient
-	eventHubConsumerGroupClient eventhub.ConsumerGroupsClient
-	eventHubNamespacesClient    eventhub.NamespacesClient
-
-	providers           resources.ProvidersClient
-	resourceGroupClient resources.GroupsClient
-	tagsClient          resources.TagsClient
-	resourceFindClient  resources.GroupClient
-
-	jobsClient            scheduler.JobsClient
-	jobsCollectionsClient scheduler.JobCollectionsClient
-
-	storageServiceClient storage.AccountsClient
-	storageUsageClient   storage.UsageClient
-
-	deploymentsClient resources.DeploymentsClient
-
-	redisClient redis.GroupClient
-
-	trafficManagerProfilesClient  trafficmanager.ProfilesClient
-	trafficManagerEndpointsClient trafficmanager.EndpointsClient
-
-	serviceBusNamespacesClient    servicebus.NamespacesClient
-	serviceBusQueuesClient        servicebus.QueuesClient
-	serviceBusTopicsClient        servicebus.TopicsClient
-	serviceBusSubscriptionsClient servicebus.SubscriptionsClient
-
-	keyVaultClient keyvault.VaultsClient
-
-	sqlElasticPoolsClient sql.ElasticPoolsClient
-
-	appInsightsClient appinsights.ComponentsClient
-
-	servicePrincipalsClient graphrbac.ServicePrincipalsClient
+		return adal.NewServicePrincipalTokenFromCertificate(oauthConfig, c.ClientID, certificate, privateKey, resource)
+	}
+
+	return adal.NewServicePrincipalToken(oauthConfig, c.ClientID, c.ClientSecret, resource)
 }
 
-func withRequestLogging() autorest.SendDecorator {
-	return func(s autorest.Sender) autorest.Sender {
-		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
-			// dump request to wire format
-			if dump, err := httputil.DumpRequestOut(r, true); err == nil {
-				log.Printf("[DEBUG] AzureRM Request: \n%s\n", dump)
-			} else {
-				// fallback to basic message
-				log.Printf("[DEBUG] AzureRM Request: %s to %s\n", r.Method, r.URL)
-			}
-
-			resp, err := s.Do(r)
-			if resp != nil {
-				// dump response to wire format
-				if dump, err := httputil.DumpResponse(resp, true); err == nil {
-					log.Printf("[DEBUG] AzureRM Response for %s: \n%s\n", r.URL, dump)
-				} else {
-					// fallback to basic message
-					log.Printf("[DEBUG] AzureRM Response: %s for %s\n", resp.Status, r.URL)
-				}
-			} else {
-				log.Printf("[DEBUG] Request to %s completed with no response", r.URL)
-			}
-			return resp, err
-		})
+func (c *Config) getMsiToken(oauthConfig adal.OAuthConfig, resource string) (*adal.ServicePrincipalToken, error) {
+	if c.MsiEndpoint == "" {
+		// the endpoint is discovered from the settings written by the MSI VM Extension
+		spt, err := adal.NewServicePrincipalTokenFromMSI(oauthConfig, resource)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving the MSI endpoint from the VM Extension settings - is the MSI extension installed? %s", err)
+		}
+		return spt, nil
 	}
+
+	msiEndpoint, err := url.Parse(c.MsiEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the MSI endpoint %q: %s", c.MsiEndpoint, err)
+	}
+
+	tokenEndpoint, err := msiEndpoint.Parse("/oauth2/token")
+	if err != nil {
+		return nil, fmt.Errorf("Error building the MSI token endpoint from %q: %s", c.MsiEndpoint, err)
+	}
+
+	// the authority is left as-is, since the MSI endpoint expects to be given it in the token request
+	oauthConfig.TokenEndpoint = *tokenEndpoint
+	return adal.NewServicePrincipalTokenWithSecret(oauthConfig, c.ClientID, resource, &adal.ServicePrincipalMSISecret{})
 }
 
 func setUserAgent(client *autorest.Client) {
+	client.UserAgent = armUserAgent()
+}
+
+func armUserAgent() string {
 	version := terraform.VersionString()
-	client.UserAgent = fmt.Sprintf("HashiCorp-Terraform-v%s", version)
+	return fmt.Sprintf("HashiCorp-Terraform-v%s", version)
+}
+
+// armTransport is shared by every client (and every instance of the provider within the
+// process), so that connections to Azure are pooled and re-used between requests.
+var armTransport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	// almost every request is sent to the same host, so as many idle connections are kept
+	// to it as are used by Terraform's default parallelism (and then some)
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   20,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: 1 * time.Second,
+}
+
+// newArmHTTPClient returns the http.Client used to send requests to Azure, which uses the
+// test transport when one's been configured.
+func newArmHTTPClient() *http.Client {
+	client := &http.Client{
+		Transport: armTransport,
+	}
+	if armTestTransport != nil {
+		client.Transport = armTestTransport()
+	}
+	return client
+}
+
+// configureClient sets the User Agent, Authorizer and Sender used by the SDK client for the
+// service. Retries are handled by the Sender (see withRetries), so autorest's own retries are
+// disabled, and the Sender also waits for long running operations to complete (see
+// operationPoller) before removing the cached reads of the resource it modified (see readCache).
+func (armClient *ArmClient) configureClient(client *autorest.Client, service string, auth autorest.Authorizer) {
+	setUserAgent(client)
+	client.Authorizer = auth
+	client.Sender = autorest.DecorateSender(armClient.sender(service),
+		armClient.operations.withPolling(auth),
+		withReadCacheInvalidation(armClient.readCache))
+	client.RetryAttempts = 0
+}
+
+// sender returns the Sender used to send each request (and each of its retries) made by the
+// client for the service to Azure.
+func (armClient *ArmClient) sender(service string) autorest.Sender {
+	return autorest.DecorateSender(armClient.httpClient,
+		withRequestLogging(armClient.logRedactor),
+		withMetrics(armClient.metrics, service),
+		withRetries(armClient.retryPolicy))
 }
 
 // getArmClient is a helper method which returns a fully instantiated
 // *ArmClient based on the Config's current settings.
 func (c *Config) getArmClient() (*ArmClient, error) {
-	// detect cloud from environment
-	env, envErr := azure.EnvironmentFromName(c.Environment)
-	if envErr != nil {
-		// try again with wrapped value to support readable values like german instead of AZUREGERMANCLOUD
-		wrapped := fmt.Sprintf("AZURE%sCLOUD", c.Environment)
-		var innerErr error
-		if env, innerErr = azure.EnvironmentFromName(wrapped); innerErr != nil {
-			return nil, envErr
-		}
+	env, err := c.getEnvironment()
+	if err != nil {
+		return nil, err
 	}
 
 	// client declarations:
@@ -176,22 +206,22 @@ func (c *Config) getArmClient() (*ArmClient, error) {
 		clientId:       c.ClientID,
 		tenantId:       c.TenantID,
 		subscriptionId: c.SubscriptionID,
-		environment:    env,
+		environment:    env.Environment,
+		usingMsi:       c.UseMsi,
+		usingAzureCli:  c.azureCliToken != nil,
+		retryPolicy:    newRetryPolicy(c.MaxRetries, c.MaxRetryDuration),
+		logRedactor:    newLogRedactor(c.LogRedactedFields),
+		httpClient:     newArmHTTPClient(),
+		clients:        newClientRegistry(),
+		subscriptions:  newSubscriptionClients(),
+		metrics:        runMetrics,
+		readCache:      newReadCache(),
+		defaultTags:    c.DefaultTags,
+
+		ignoreTagKeys:     c.IgnoreTagKeys,
+		ignoreTagPrefixes: c.IgnoreTagPrefixes,
 	}
 
-	rivieraClient, err := riviera.NewClient(&riviera.AzureResourceManagerCredentials{
-		ClientID:                c.ClientID,
-		ClientSecret:            c.ClientSecret,
-		TenantID:                c.TenantID,
-		SubscriptionID:          c.SubscriptionID,
-		ResourceManagerEndpoint: env.ResourceManagerEndpoint,
-		ActiveDirectoryEndpoint: env.ActiveDirectoryEndpoint,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("Error creating Riviera client: %s", err)
-	}
-	client.rivieraClient = rivieraClient
-
 	oauthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, c.TenantID)
 	if err != nil {
 		return nil, err
@@ -202,376 +232,86 @@ func (c *Config) getArmClient() (*ArmClient, error) {
 		return nil, fmt.Errorf("Unable to configure OAuthConfig for tenant %s", c.TenantID)
 	}
 
-	spt, err := adal.NewServicePrincipalToken(*oauthConfig, c.ClientID, c.ClientSecret, env.ResourceManagerEndpoint)
+	spt, err := c.getAuthorizationToken(*oauthConfig, env.TokenAudience)
 	if err != nil {
 		return nil, err
 	}
 
-	graphSpt, err := adal.NewServicePrincipalToken(*oauthConfig, c.ClientID, c.ClientSecret, env.GraphEndpoint)
+	graphSpt, err := c.getAuthorizationToken(*oauthConfig, env.GraphEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	endpoint := env.ResourceManagerEndpoint
-	auth := autorest.NewBearerAuthorizer(spt)
-	graphEndpoint := env.GraphEndpoint
-	graphAuth := autorest.NewBearerAuthorizer(graphSpt)
-
-	// NOTE: these declarations should be left separate for clarity should the
-	// clients be wished to be configured with custom Responders/PollingModess etc...
-	asc := compute.NewAvailabilitySetsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&asc.Client)
-	asc.Authorizer = auth
-	asc.Sender = autorest.CreateSender(withRequestLogging())
-	client.availSetClient = asc
-
-	uoc := compute.NewUsageClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&uoc.Client)
-	uoc.Authorizer = auth
-	uoc.Sender = autorest.CreateSender(withRequestLogging())
-	client.usageOpsClient = uoc
-
-	vmeic := compute.NewVirtualMachineExtensionImagesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&vmeic.Client)
-	vmeic.Authorizer = auth
-	vmeic.Sender = autorest.CreateSender(withRequestLogging())
-	client.vmExtensionImageClient = vmeic
-
-	vmec := compute.NewVirtualMachineExtensionsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&vmec.Client)
-	vmec.Authorizer = auth
-	vmec.Sender = autorest.CreateSender(withRequestLogging())
-	client.vmExtensionClient = vmec
-
-	vmic := compute.NewVirtualMachineImagesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&vmic.Client)
-	vmic.Authorizer = auth
-	vmic.Sender = autorest.CreateSender(withRequestLogging())
-	client.vmImageClient = vmic
-
-	vmssc := compute.NewVirtualMachineScaleSetsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&vmssc.Client)
-	vmssc.Authorizer = auth
-	vmssc.Sender = autorest.CreateSender(withRequestLogging())
-	client.vmScaleSetClient = vmssc
-
-	vmc := compute.NewVirtualMachinesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&vmc.Client)
-	vmc.Authorizer = auth
-	vmc.Sender = autorest.CreateSender(withRequestLogging())
-	client.vmClient = vmc
-
-	agc := network.NewApplicationGatewaysClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&agc.Client)
-	agc.Authorizer = auth
-	agc.Sender = autorest.CreateSender(withRequestLogging())
-	client.appGatewayClient = agc
-
-	crc := containerregistry.NewRegistriesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&crc.Client)
-	crc.Authorizer = auth
-	crc.Sender = autorest.CreateSender(withRequestLogging())
-	client.containerRegistryClient = crc
-
-	csc := containerservice.NewContainerServicesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&csc.Client)
-	csc.Authorizer = auth
-	csc.Sender = autorest.CreateSender(withRequestLogging())
-	client.containerServicesClient = csc
-
-	cdb := cosmosdb.NewDatabaseAccountsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&cdb.Client)
-	cdb.Authorizer = auth
-	cdb.Sender = autorest.CreateSender(withRequestLogging())
-	client.cosmosDBClient = cdb
-
-	dkc := disk.NewDisksClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&dkc.Client)
-	dkc.Authorizer = auth
-	dkc.Sender = autorest.CreateSender(withRequestLogging())
-	client.diskClient = dkc
-
-	img := compute.NewImagesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&img.Client)
-	img.Authorizer = auth
-	img.Sender = autorest.CreateSender(withRequestLogging())
-	client.imageClient = img
-
-	ehc := eventhub.NewEventHubsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&ehc.Client)
-	ehc.Authorizer = auth
-	ehc.Sender = autorest.CreateSender(withRequestLogging())
-	client.eventHubClient = ehc
-
-	chcgc := eventhub.NewConsumerGroupsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&chcgc.Client)
-	chcgc.Authorizer = auth
-	chcgc.Sender = autorest.CreateSender(withRequestLogging())
-	client.eventHubConsumerGroupClient = chcgc
-
-	ehnc := eventhub.NewNamespacesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&ehnc.Client)
-	ehnc.Authorizer = auth
-	ehnc.Sender = autorest.CreateSender(withRequestLogging())
-	client.eventHubNamespacesClient = ehnc
-
-	ifc := network.NewInterfacesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&ifc.Client)
-	ifc.Authorizer = auth
-	ifc.Sender = autorest.CreateSender(withRequestLogging())
-	client.ifaceClient = ifc
-
-	erc := network.NewExpressRouteCircuitsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&erc.Client)
-	erc.Authorizer = auth
-	erc.Sender = autorest.CreateSender(withRequestLogging())
-	client.expressRouteCircuitClient = erc
-
-	lbc := network.NewLoadBalancersClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&lbc.Client)
-	lbc.Authorizer = auth
-	lbc.Sender = autorest.CreateSender(withRequestLogging())
-	client.loadBalancerClient = lbc
-
-	lgc := network.NewLocalNetworkGatewaysClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&lgc.Client)
-	lgc.Authorizer = auth
-	lgc.Sender = autorest.CreateSender(withRequestLogging())
-	client.localNetConnClient = lgc
-
-	pipc := network.NewPublicIPAddressesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&pipc.Client)
-	pipc.Authorizer = auth
-	pipc.Sender = autorest.CreateSender(withRequestLogging())
-	client.publicIPClient = pipc
-
-	sgc := network.NewSecurityGroupsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&sgc.Client)
-	sgc.Authorizer = auth
-	sgc.Sender = autorest.CreateSender(withRequestLogging())
-	client.secGroupClient = sgc
-
-	src := network.NewSecurityRulesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&src.Client)
-	src.Authorizer = auth
-	src.Sender = autorest.CreateSender(withRequestLogging())
-	client.secRuleClient = src
-
-	snc := network.NewSubnetsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&snc.Client)
-	snc.Authorizer = auth
-	snc.Sender = autorest.CreateSender(withRequestLogging())
-	client.subnetClient = snc
-
-	vgcc := network.NewVirtualNetworkGatewayConnectionsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&vgcc.Client)
-	vgcc.Authorizer = auth
-	vgcc.Sender = autorest.CreateSender(withRequestLogging())
-	client.vnetGatewayConnectionsClient = vgcc
-
-	vgc := network.NewVirtualNetworkGatewaysClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&vgc.Client)
-	vgc.Authorizer = auth
-	vgc.Sender = autorest.CreateSender(withRequestLogging())
-	client.vnetGatewayClient = vgc
-
-	vnc := network.NewVirtualNetworksClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&vnc.Client)
-	vnc.Authorizer = auth
-	vnc.Sender = autorest.CreateSender(withRequestLogging())
-	client.vnetClient = vnc
-
-	vnpc := network.NewVirtualNetworkPeeringsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&vnpc.Client)
-	vnpc.Authorizer = auth
-	vnpc.Sender = autorest.CreateSender(withRequestLogging())
-	client.vnetPeeringsClient = vnpc
-
-	rtc := network.NewRouteTablesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&rtc.Client)
-	rtc.Authorizer = auth
-	rtc.Sender = autorest.CreateSender(withRequestLogging())
-	client.routeTablesClient = rtc
-
-	rc := network.NewRoutesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&rc.Client)
-	rc.Authorizer = auth
-	rc.Sender = autorest.CreateSender(withRequestLogging())
-	client.routesClient = rc
-
-	dn := dns.NewRecordSetsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&dn.Client)
-	dn.Authorizer = auth
-	dn.Sender = autorest.CreateSender(withRequestLogging())
-	client.dnsClient = dn
-
-	zo := dns.NewZonesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&zo.Client)
-	zo.Authorizer = auth
-	zo.Sender = autorest.CreateSender(withRequestLogging())
-	client.zonesClient = zo
-
-	rgc := resources.NewGroupsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&rgc.Client)
-	rgc.Authorizer = auth
-	rgc.Sender = autorest.CreateSender(withRequestLogging())
-	client.resourceGroupClient = rgc
-
-	pc := resources.NewProvidersClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&pc.Client)
-	pc.Authorizer = auth
-	pc.Sender = autorest.CreateSender(withRequestLogging())
-	client.providers = pc
-
-	tc := resources.NewTagsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&tc.Client)
-	tc.Authorizer = auth
-	tc.Sender = autorest.CreateSender(withRequestLogging())
-	client.tagsClient = tc
-
-	rf := resources.NewGroupClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&rf.Client)
-	rf.Authorizer = auth
-	rf.Sender = autorest.CreateSender(withRequestLogging())
-	client.resourceFindClient = rf
-
-	jc := scheduler.NewJobsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&jc.Client)
-	jc.Authorizer = auth
-	jc.Sender = autorest.CreateSender(withRequestLogging())
-	client.jobsClient = jc
-
-	jcc := scheduler.NewJobCollectionsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&jcc.Client)
-	jcc.Authorizer = auth
-	jcc.Sender = autorest.CreateSender(withRequestLogging())
-	client.jobsCollectionsClient = jcc
-
-	ssc := storage.NewAccountsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&ssc.Client)
-	ssc.Authorizer = auth
-	ssc.Sender = autorest.CreateSender(withRequestLogging())
-	client.storageServiceClient = ssc
-
-	suc := storage.NewUsageClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&suc.Client)
-	suc.Authorizer = auth
-	suc.Sender = autorest.CreateSender(withRequestLogging())
-	client.storageUsageClient = suc
-
-	cpc := cdn.NewProfilesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&cpc.Client)
-	cpc.Authorizer = auth
-	cpc.Sender = autorest.CreateSender(withRequestLogging())
-	client.cdnProfilesClient = cpc
-
-	cec := cdn.NewEndpointsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&cec.Client)
-	cec.Authorizer = auth
-	cec.Sender = autorest.CreateSender(withRequestLogging())
-	client.cdnEndpointsClient = cec
-
-	dc := resources.NewDeploymentsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&dc.Client)
-	dc.Authorizer = auth
-	dc.Sender = autorest.CreateSender(withRequestLogging())
-	client.deploymentsClient = dc
-
-	tmpc := trafficmanager.NewProfilesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&tmpc.Client)
-	tmpc.Authorizer = auth
-	tmpc.Sender = autorest.CreateSender(withRequestLogging())
-	client.trafficManagerProfilesClient = tmpc
-
-	tmec := trafficmanager.NewEndpointsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&tmec.Client)
-	tmec.Authorizer = auth
-	tmec.Sender = autorest.CreateSender(withRequestLogging())
-	client.trafficManagerEndpointsClient = tmec
-
-	rdc := redis.NewGroupClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&rdc.Client)
-	rdc.Authorizer = auth
-	rdc.Sender = autorest.CreateSender(withRequestLogging())
-	client.redisClient = rdc
-
-	sbnc := servicebus.NewNamespacesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&sbnc.Client)
-	sbnc.Authorizer = auth
-	sbnc.Sender = autorest.CreateSender(withRequestLogging())
-	client.serviceBusNamespacesClient = sbnc
-
-	sbqc := servicebus.NewQueuesClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&sbqc.Client)
-	sbqc.Authorizer = auth
-	sbqc.Sender = autorest.CreateSender(withRequestLogging())
-	client.serviceBusQueuesClient = sbqc
-
-	sbtc := servicebus.NewTopicsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&sbtc.Client)
-	sbtc.Authorizer = auth
-	sbtc.Sender = autorest.CreateSender(withRequestLogging())
-	client.serviceBusTopicsClient = sbtc
-
-	sbsc := servicebus.NewSubscriptionsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&sbsc.Client)
-	sbsc.Authorizer = auth
-	sbsc.Sender = autorest.CreateSender(withRequestLogging())
-	client.serviceBusSubscriptionsClient = sbsc
-
-	kvc := keyvault.NewVaultsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&kvc.Client)
-	kvc.Authorizer = auth
-	kvc.Sender = autorest.CreateSender(withRequestLogging())
-	client.keyVaultClient = kvc
-
-	sqlepc := sql.NewElasticPoolsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&sqlepc.Client)
-	sqlepc.Authorizer = auth
-	sqlepc.Sender = autorest.CreateSender(withRequestLogging())
-	client.sqlElasticPoolsClient = sqlepc
-
-	ai := appinsights.NewComponentsClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&ai.Client)
-	ai.Authorizer = auth
-	ai.Sender = autorest.CreateSender(withRequestLogging())
-	client.appInsightsClient = ai
-
-	spc := graphrbac.NewServicePrincipalsClientWithBaseURI(graphEndpoint, c.TenantID)
-	setUserAgent(&spc.Client)
-	spc.Authorizer = graphAuth
-	spc.Sender = autorest.CreateSender(withRequestLogging())
-	client.servicePrincipalsClient = spc
-
-	aadb := automation.NewAccountClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&aadb.Client)
-	aadb.Authorizer = auth
-	aadb.Sender = autorest.CreateSender(withRequestLogging())
-	client.automationAccountClient = aadb
-
-	arc := automation.NewRunbookClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&arc.Client)
-	arc.Authorizer = auth
-	arc.Sender = autorest.CreateSender(withRequestLogging())
-	client.automationRunbookClient = arc
-
-	acc := automation.NewCredentialClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&acc.Client)
-	acc.Authorizer = auth
-	acc.Sender = autorest.CreateSender(withRequestLogging())
-	client.automationCredentialClient = acc
-
-	aschc := automation.NewScheduleClientWithBaseURI(endpoint, c.SubscriptionID)
-	setUserAgent(&aschc.Client)
-	aschc.Authorizer = auth
-	aschc.Sender = autorest.CreateSender(withRequestLogging())
-	client.automationScheduleClient = aschc
+	auxiliaryTokens := make([]*adal.ServicePrincipalToken, 0, len(c.AuxiliaryTenantIDs))
+	for _, tenantID := range c.AuxiliaryTenantIDs {
+		auxiliaryOAuthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, tenantID)
+		if err != nil {
+			return nil, fmt.Errorf("Error configuring OAuth for the auxiliary tenant %q: %s", tenantID, err)
+		}
+
+		auxiliarySpt, err := c.getAuthorizationToken(*auxiliaryOAuthConfig, env.TokenAudience)
+		if err != nil {
+			return nil, fmt.Errorf("Error obtaining a token for the auxiliary tenant %q: %s", tenantID, err)
+		}
+		auxiliaryTokens = append(auxiliaryTokens, auxiliarySpt)
+	}
+
+	if armTestTransport != nil {
+		spt.SetSender(client.httpClient)
+		graphSpt.SetSender(client.httpClient)
+		for _, auxiliarySpt := range auxiliaryTokens {
+			auxiliarySpt.SetSender(client.httpClient)
+		}
+	}
+
+	// the tokens are shared by every client, so they're refreshed by one request at a time
+	client.armToken = newSynchronizedToken(spt)
+	auxiliary := make([]*synchronizedToken, 0, len(auxiliaryTokens))
+	for _, auxiliarySpt := range auxiliaryTokens {
+		auxiliary = append(auxiliary, newSynchronizedToken(auxiliarySpt))
+	}
+
+	// requests to Resource Manager carry the tokens for any auxiliary tenants, so that they can
+	// reference resources in those tenants
+	client.authorizer = newAuxiliaryTenantAuthorizer(autorest.NewBearerAuthorizer(client.armToken), auxiliary)
+	client.graphAuthorizer = autorest.NewBearerAuthorizer(newSynchronizedToken(graphSpt))
+	client.operations = newOperationPoller(client.sender("operations"), client.authorizer)
+	client.operations.stopCh = client.stopCh
+
+	client.locations = newLocationCache(client.listLocations)
+	if !c.SkipProviderRegistration {
+		client.resourceProviders = newResourceProviderRegistrar(client.providers(), c.ResourceProviderRegistrationTimeout)
+		client.resourceProviders.stopCh = client.stopCh
+		client.resourceProviders.configured = c.AdditionalResourceProvidersToRegister
+		if len(c.ResourceProvidersToRegister) > 0 {
+			client.resourceProviders.configured = c.ResourceProvidersToRegister
+			client.resourceProviders.exclusive = true
+		}
+	}
 
 	return &client, nil
 }
 
+type storageAccountKey struct {
+	key    string
+	exists bool
+}
+
+// getKeyForStorageAccount returns the first Access Key of the Storage Account, which is cached
+// (since it's used by each of the Containers, Blobs, Queues etc. within it) until the Storage
+// Account is modified.
 func (armClient *ArmClient) getKeyForStorageAccount(resourceGroupName, storageAccountName string) (string, bool, error) {
-	accountKeys, err := armClient.storageServiceClient.ListKeys(resourceGroupName, storageAccountName)
+	id := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Storage/storageAccounts/%s/listKeys", armClient.subscriptionId, resourceGroupName, storageAccountName)
+	value, err := armClient.readCache.get(id, func() (interface{}, error) {
+		key, exists, err := armClient.listKeyForStorageAccount(resourceGroupName, storageAccountName)
+		return storageAccountKey{key, exists}, err
+	})
+	account := value.(storageAccountKey)
+	return account.key, account.exists, err
+}
+
+func (armClient *ArmClient) listKeyForStorageAccount(resourceGroupName, storageAccountName string) (string, bool, error) {
+	accountKeys, err := armClient.storageServiceClient().ListKeys(resourceGroupName, storageAccountName)
 	if accountKeys.StatusCode == http.StatusNotFound {
 		return "", false, nil
 	}
@@ -589,7 +329,9 @@ func (armClient *ArmClient) getKeyForStorageAccount(resourceGroupName, storageAc
 	return *keys[0].Value, true, nil
 }
 
-func (armClient *ArmClient) getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.BlobStorageClient, bool, error) {
+// getStorageClientForStorageAccount returns a client for the data plane of the Storage
+// Account, authenticated using the Account's Access Key.
+func (armClient *ArmClient) getStorageClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.Client, bool, error) {
 	key, accountExists, err := armClient.getKeyForStorageAccount(resourceGroupName, storageAccountName)
 	if err != nil {
 		return nil, accountExists, err
@@ -604,62 +346,45 @@ func (armClient *ArmClient) getBlobStorageClientForStorageAccount(resourceGroupN
 		return nil, true, fmt.Errorf("Error creating storage client for storage account %q: %s", storageAccountName, err)
 	}
 
+	storageClient.HTTPClient = armClient.httpClient
+	return &storageClient, true, nil
+}
+
+func (armClient *ArmClient) getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.BlobStorageClient, bool, error) {
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	if err != nil || !accountExists {
+		return nil, accountExists, err
+	}
+
 	blobClient := storageClient.GetBlobService()
 	return &blobClient, true, nil
 }
 
 func (armClient *ArmClient) getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.FileServiceClient, bool, error) {
-	key, accountExists, err := armClient.getKeyForStorageAccount(resourceGroupName, storageAccountName)
-	if err != nil {
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	if err != nil || !accountExists {
 		return nil, accountExists, err
 	}
-	if accountExists == false {
-		return nil, false, nil
-	}
-
-	storageClient, err := mainStorage.NewClient(storageAccountName, key, armClient.environment.StorageEndpointSuffix,
-		mainStorage.DefaultAPIVersion, true)
-	if err != nil {
-		return nil, true, fmt.Errorf("Error creating storage client for storage account %q: %s", storageAccountName, err)
-	}
 
 	fileClient := storageClient.GetFileService()
 	return &fileClient, true, nil
 }
 
 func (armClient *ArmClient) getTableServiceClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.TableServiceClient, bool, error) {
-	key, accountExists, err := armClient.getKeyForStorageAccount(resourceGroupName, storageAccountName)
-	if err != nil {
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	if err != nil || !accountExists {
 		return nil, accountExists, err
 	}
-	if accountExists == false {
-		return nil, false, nil
-	}
-
-	storageClient, err := mainStorage.NewClient(storageAccountName, key, armClient.environment.StorageEndpointSuffix,
-		mainStorage.DefaultAPIVersion, true)
-	if err != nil {
-		return nil, true, fmt.Errorf("Error creating storage client for storage account %q: %s", storageAccountName, err)
-	}
 
 	tableClient := storageClient.GetTableService()
 	return &tableClient, true, nil
 }
 
 func (armClient *ArmClient) getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.QueueServiceClient, bool, error) {
-	key, accountExists, err := armClient.getKeyForStorageAccount(resourceGroupName, storageAccountName)
-	if err != nil {
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	if err != nil || !accountExists {
 		return nil, accountExists, err
 	}
-	if accountExists == false {
-		return nil, false, nil
-	}
-
-	storageClient, err := mainStorage.NewClient(storageAccountName, key, armClient.environment.StorageEndpointSuffix,
-		mainStorage.DefaultAPIVersion, true)
-	if err != nil {
-		return nil, true, fmt.Errorf("Error creating storage client for storage account %q: %s", storageAccountName, err)
-	}
 
 	queueClient := storageClient.GetQueueService()
 	return &queueClient, true, nil
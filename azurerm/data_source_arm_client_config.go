@@ -35,10 +35,39 @@ func dataSourceArmClientConfig() *schema.Resource {
 
 func dataSourceArmClientConfigRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	spClient := client.servicePrincipalsClient
+	spClient := client.servicePrincipalsClient()
+
+	if client.usingAzureCli {
+		// when authenticated via the Azure CLI Terraform is running as a user rather than
+		// a Service Principal, so the Object ID of the user is returned instead
+		claims, err := client.getArmTokenClaims()
+		if err != nil {
+			return err
+		}
+
+		d.SetId(time.Now().UTC().String())
+		d.Set("client_id", claims.AppID)
+		d.Set("tenant_id", client.tenantId)
+		d.Set("subscription_id", client.subscriptionId)
+		d.Set("service_principal_object_id", claims.ObjectID)
+
+		return nil
+	}
+
+	clientId := client.clientId
+	if client.usingMsi && clientId == "" {
+		// the Client ID of the Managed Service Identity isn't known up-front, but
+		// is available in the claims of the token issued to it
+		claims, err := client.getArmTokenClaims()
+		if err != nil {
+			return err
+		}
+		clientId = claims.AppID
+	}
+
 	// Application & Service Principal is 1:1 per tenant. Since we know the appId (client_id)
 	// here, we can query for the Service Principal whose appId matches.
-	filter := fmt.Sprintf("appId eq '%s'", client.clientId)
+	filter := fmt.Sprintf("appId eq '%s'", clientId)
 	listResult, listErr := spClient.List(filter)
 
 	if listErr != nil {
@@ -52,7 +81,7 @@ func dataSourceArmClientConfigRead(d *schema.ResourceData, meta interface{}) err
 	servicePrincipal := (*listResult.Value)[0]
 
 	d.SetId(time.Now().UTC().String())
-	d.Set("client_id", client.clientId)
+	d.Set("client_id", clientId)
 	d.Set("tenant_id", client.tenantId)
 	d.Set("subscription_id", client.subscriptionId)
 	d.Set("service_principal_object_id", *servicePrincipal.ObjectID)
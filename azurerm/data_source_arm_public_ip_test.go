@@ -4,13 +4,12 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccDataSourceAzureRMPublicIP_basic(t *testing.T) {
 	dataSourceName := "data.azurerm_public_ip.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 
 	name := fmt.Sprintf("acctestpublicip-%d", ri)
 	resourceGroupName := fmt.Sprintf("acctestRG-%d", ri)
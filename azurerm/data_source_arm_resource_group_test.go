@@ -5,12 +5,11 @@ import (
 
 	"fmt"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccDataSourceAzureRMResourceGroup_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	name := fmt.Sprintf("acctestRg_%d", ri)
 	location := testLocation()
 
@@ -53,7 +53,7 @@ func dataSourceArmManagedDisk() *schema.Resource {
 }
 
 func dataSourceArmManagedDiskRead(d *schema.ResourceData, meta interface{}) error {
-	diskClient := meta.(*ArmClient).diskClient
+	diskClient := meta.(*ArmClient).diskClient()
 
 	resGroup := d.Get("resource_group_name").(string)
 	name := d.Get("name").(string)
@@ -76,7 +76,7 @@ func dataSourceArmManagedDiskRead(d *schema.ResourceData, meta interface{}) erro
 		flattenAzureRmManagedDiskCreationData(d, resp.CreationData)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, nil)
 
 	return nil
 }
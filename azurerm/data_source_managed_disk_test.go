@@ -4,13 +4,12 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccDataSourceAzureRMManagedDisk_basic(t *testing.T) {
 	dataSourceName := "data.azurerm_managed_disk.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 
 	name := fmt.Sprintf("acctestmanageddisk-%d", ri)
 	resourceGroupName := fmt.Sprintf("acctestRG-%d", ri)
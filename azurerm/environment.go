@@ -0,0 +1,161 @@
+package azurerm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+// cloudEnvironment is the set of endpoints used to communicate with an Azure Cloud,
+// along with the audience of the tokens used to authenticate with Resource Manager -
+// which in an Azure Stack differs from the Resource Manager endpoint.
+type cloudEnvironment struct {
+	azure.Environment
+
+	TokenAudience string `json:"tokenAudience"`
+}
+
+// armMetadataEndpoints is the response from the `/metadata/endpoints` API exposed by
+// Resource Manager in Azure Stack, describing the endpoints used by that cloud.
+type armMetadataEndpoints struct {
+	GalleryEndpoint string `json:"galleryEndpoint"`
+	GraphEndpoint   string `json:"graphEndpoint"`
+	PortalEndpoint  string `json:"portalEndpoint"`
+	Authentication  struct {
+		LoginEndpoint string   `json:"loginEndpoint"`
+		Audiences     []string `json:"audiences"`
+	} `json:"authentication"`
+}
+
+const armMetadataAPIVersion = "2015-01-01"
+
+// getEnvironment returns the Cloud Environment the provider should connect to - which is
+// either discovered from a Resource Manager metadata endpoint, loaded from a file or one
+// of the built-in Azure Clouds.
+func (c *Config) getEnvironment() (*cloudEnvironment, error) {
+	if c.MetadataURL != "" {
+		return environmentFromMetadataURL(c.MetadataURL)
+	}
+
+	if c.EnvironmentFilePath != "" {
+		return environmentFromFile(c.EnvironmentFilePath)
+	}
+
+	return environmentFromName(c.Environment)
+}
+
+func environmentFromName(name string) (*cloudEnvironment, error) {
+	env, envErr := azure.EnvironmentFromName(name)
+	if envErr != nil {
+		// try again with wrapped value to support readable values like german instead of AZUREGERMANCLOUD
+		wrapped := fmt.Sprintf("AZURE%sCLOUD", name)
+		var innerErr error
+		if env, innerErr = azure.EnvironmentFromName(wrapped); innerErr != nil {
+			return nil, envErr
+		}
+	}
+
+	return &cloudEnvironment{
+		Environment:   env,
+		TokenAudience: env.ResourceManagerEndpoint,
+	}, nil
+}
+
+// environmentFromFile loads a Cloud Environment from a JSON file in the same format
+// as the go-autorest `azure.Environment` struct, optionally including a `tokenAudience`.
+func environmentFromFile(path string) (*cloudEnvironment, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the Environment file %q: %s", path, err)
+	}
+
+	var env cloudEnvironment
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("Error parsing the Environment file %q: %s", path, err)
+	}
+
+	if env.ResourceManagerEndpoint == "" || env.ActiveDirectoryEndpoint == "" {
+		return nil, fmt.Errorf("The Environment file %q must specify both the `resourceManagerEndpoint` and `activeDirectoryEndpoint`", path)
+	}
+
+	if env.TokenAudience == "" {
+		env.TokenAudience = env.ResourceManagerEndpoint
+	}
+
+	return &env, nil
+}
+
+// environmentFromMetadataURL builds a Cloud Environment from the metadata exposed by the
+// specified Resource Manager endpoint. The metadata doesn't include the DNS suffixes, which
+// in an Azure Stack are derived from the domain of the Resource Manager endpoint.
+func environmentFromMetadataURL(resourceManagerEndpoint string) (*cloudEnvironment, error) {
+	endpoint, err := url.Parse(resourceManagerEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the Metadata URL %q: %s", resourceManagerEndpoint, err)
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("The Metadata URL %q must be an absolute URL, such as `https://management.local.azurestack.external`", resourceManagerEndpoint)
+	}
+	endpoint.Path = ""
+
+	metadataURL := fmt.Sprintf("%s/metadata/endpoints?api-version=%s", endpoint.String(), armMetadataAPIVersion)
+	httpClient := http.Client{
+		Timeout: 30 * time.Second,
+	}
+	resp, err := httpClient.Get(metadataURL)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the Resource Manager metadata from %q: %s", metadataURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error retrieving the Resource Manager metadata from %q: unexpected status %s", metadataURL, resp.Status)
+	}
+
+	var metadata armMetadataEndpoints
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("Error parsing the Resource Manager metadata from %q: %s", metadataURL, err)
+	}
+
+	if metadata.Authentication.LoginEndpoint == "" || len(metadata.Authentication.Audiences) == 0 {
+		return nil, fmt.Errorf("The Resource Manager metadata from %q didn't include the authentication endpoints", metadataURL)
+	}
+
+	// e.g. `management.local.azurestack.external` -> `local.azurestack.external`
+	domain := endpoint.Hostname()
+	if i := strings.Index(domain, "."); i >= 0 {
+		domain = domain[i+1:]
+	}
+
+	env := cloudEnvironment{
+		Environment: azure.Environment{
+			Name:                       "AzureStackCloud",
+			ManagementPortalURL:        ensureTrailingSlash(metadata.PortalEndpoint),
+			ResourceManagerEndpoint:    ensureTrailingSlash(endpoint.String()),
+			ActiveDirectoryEndpoint:    ensureTrailingSlash(metadata.Authentication.LoginEndpoint),
+			GalleryEndpoint:            ensureTrailingSlash(metadata.GalleryEndpoint),
+			GraphEndpoint:              ensureTrailingSlash(metadata.GraphEndpoint),
+			KeyVaultEndpoint:           fmt.Sprintf("https://vault.%s/", domain),
+			StorageEndpointSuffix:      domain,
+			KeyVaultDNSSuffix:          fmt.Sprintf("vault.%s", domain),
+			ResourceManagerVMDNSSuffix: fmt.Sprintf("cloudapp.%s", domain),
+		},
+		TokenAudience: metadata.Authentication.Audiences[0],
+	}
+
+	return &env, nil
+}
+
+func ensureTrailingSlash(input string) string {
+	if input == "" || strings.HasSuffix(input, "/") {
+		return input
+	}
+
+	return input + "/"
+}
@@ -0,0 +1,171 @@
+package azurerm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvironmentFromName(t *testing.T) {
+	testCases := []struct {
+		name         string
+		expectedName string
+		expectError  bool
+	}{
+		{
+			name:         "public",
+			expectedName: "AzurePublicCloud",
+		},
+		{
+			name:         "AZUREGERMANCLOUD",
+			expectedName: "AzureGermanCloud",
+		},
+		{
+			name:        "azurestack",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		env, err := environmentFromName(tc.name)
+		if tc.expectError {
+			if err == nil {
+				t.Fatalf("Expected an error for Environment %q but didn't get one", tc.name)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error for Environment %q: %s", tc.name, err)
+		}
+		if env.Name != tc.expectedName {
+			t.Fatalf("Expected Environment %q but got %q", tc.expectedName, env.Name)
+		}
+		if env.TokenAudience != env.ResourceManagerEndpoint {
+			t.Fatalf("Expected the Token Audience to be %q but got %q", env.ResourceManagerEndpoint, env.TokenAudience)
+		}
+	}
+}
+
+func TestEnvironmentFromFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "azurerm-environment")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		contents         string
+		expectedAudience string
+		expectError      bool
+	}{
+		{
+			contents: `{
+				"name": "AzureStackCloud",
+				"resourceManagerEndpoint": "https://management.local.azurestack.external/",
+				"activeDirectoryEndpoint": "https://login.windows.net/",
+				"storageEndpointSuffix": "local.azurestack.external",
+				"tokenAudience": "https://management.example.onmicrosoft.com/00000000-0000-0000-0000-000000000000"
+			}`,
+			expectedAudience: "https://management.example.onmicrosoft.com/00000000-0000-0000-0000-000000000000",
+		},
+		{
+			contents: `{
+				"resourceManagerEndpoint": "https://management.local.azurestack.external/",
+				"activeDirectoryEndpoint": "https://login.windows.net/",
+				"storageEndpointSuffix": "local.azurestack.external"
+			}`,
+			expectedAudience: "https://management.local.azurestack.external/",
+		},
+		{
+			contents:    `{"storageEndpointSuffix": "local.azurestack.external"}`,
+			expectError: true,
+		},
+		{
+			contents:    `not-json`,
+			expectError: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		path := filepath.Join(dir, fmt.Sprintf("environment-%d.json", i))
+		if err := ioutil.WriteFile(path, []byte(tc.contents), 0600); err != nil {
+			t.Fatalf("Error writing %q: %s", path, err)
+		}
+
+		env, err := environmentFromFile(path)
+		if tc.expectError {
+			if err == nil {
+				t.Fatalf("Expected an error loading %q but didn't get one", tc.contents)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error loading %q: %s", tc.contents, err)
+		}
+		if env.StorageEndpointSuffix != "local.azurestack.external" {
+			t.Fatalf("Expected the Storage Endpoint Suffix to be loaded but got %q", env.StorageEndpointSuffix)
+		}
+		if env.TokenAudience != tc.expectedAudience {
+			t.Fatalf("Expected the Token Audience %q but got %q", tc.expectedAudience, env.TokenAudience)
+		}
+	}
+}
+
+func TestEnvironmentFromMetadataURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/endpoints" || r.URL.Query().Get("api-version") != armMetadataAPIVersion {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(w, `{
+			"galleryEndpoint": "https://adminportal.local.azurestack.external:30015/",
+			"graphEndpoint": "https://graph.windows.net/",
+			"portalEndpoint": "https://portal.local.azurestack.external/",
+			"authentication": {
+				"loginEndpoint": "https://login.windows.net",
+				"audiences": [
+					"https://management.example.onmicrosoft.com/00000000-0000-0000-0000-000000000000"
+				]
+			}
+		}`)
+	}))
+	defer server.Close()
+
+	env, err := environmentFromMetadataURL(server.URL)
+	if err != nil {
+		t.Fatalf("Unexpected error discovering the Environment: %s", err)
+	}
+
+	if env.ResourceManagerEndpoint != server.URL+"/" {
+		t.Fatalf("Expected the Resource Manager Endpoint %q but got %q", server.URL+"/", env.ResourceManagerEndpoint)
+	}
+	if env.ActiveDirectoryEndpoint != "https://login.windows.net/" {
+		t.Fatalf("Expected the Active Directory Endpoint to be normalized but got %q", env.ActiveDirectoryEndpoint)
+	}
+	if env.GraphEndpoint != "https://graph.windows.net/" {
+		t.Fatalf("Expected the Graph Endpoint to be discovered but got %q", env.GraphEndpoint)
+	}
+	if env.TokenAudience != "https://management.example.onmicrosoft.com/00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("Expected the Token Audience to be discovered but got %q", env.TokenAudience)
+	}
+
+	// the test server listens on an IP so there's no domain to derive the suffixes from
+	if env.StorageEndpointSuffix == "" || env.KeyVaultDNSSuffix != "vault."+env.StorageEndpointSuffix {
+		t.Fatalf("Expected the DNS Suffixes to be derived but got %q and %q", env.StorageEndpointSuffix, env.KeyVaultDNSSuffix)
+	}
+
+	if _, err := environmentFromMetadataURL(server.URL + "/not-found/"); err != nil {
+		t.Fatalf("Expected any path in the Metadata URL to be ignored but got: %s", err)
+	}
+
+	if _, err := environmentFromMetadataURL("management.local.azurestack.external"); err == nil {
+		t.Fatalf("Expected an error for a relative Metadata URL")
+	}
+}
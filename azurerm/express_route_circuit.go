@@ -8,19 +8,19 @@ import (
 )
 
 func extractResourceGroupAndErcName(resourceId string) (resourceGroup string, name string, err error) {
-	id, err := parseAzureResourceID(resourceId)
+	id, names, err := parseAzureResourceIDOfType(resourceId, "Microsoft.Network/expressRouteCircuits")
 
 	if err != nil {
 		return "", "", err
 	}
 	resourceGroup = id.ResourceGroup
-	name = id.Path["expressRouteCircuits"]
+	name = names[0]
 
 	return
 }
 
 func retrieveErcByResourceId(resourceId string, meta interface{}) (erc *network.ExpressRouteCircuit, resourceGroup string, e error) {
-	ercClient := meta.(*ArmClient).expressRouteCircuitClient
+	ercClient := meta.(*ArmClient).expressRouteCircuitClient()
 
 	resGroup, name, err := extractResourceGroupAndErcName(resourceId)
 	if err != nil {
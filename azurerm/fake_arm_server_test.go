@@ -0,0 +1,528 @@
+package azurerm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const (
+	fakeArmSubscriptionID = "00000000-0000-0000-0000-000000000000"
+	fakeArmTenantID       = "00000000-0000-0000-0000-000000000001"
+	fakeArmClientID       = "00000000-0000-0000-0000-000000000002"
+)
+
+// fakeArmServer is an in-process fake of Azure Resource Manager, which allows the Create,
+// Read, Update and Delete functions of resources to be tested without any credentials.
+//
+// Resources are stored by ID (which, as in Resource Manager, is case-insensitive) and support
+// PUT, GET, HEAD, PATCH and DELETE - as well as listing the resources within a collection. When
+// operationPolls is set, PUT and DELETE become long running operations which complete after
+// being polled that many times, using the `Azure-AsyncOperation` or `Location` headers. The
+// server also exposes the metadata and token endpoints, so that an ArmClient can be pointed
+// at it using the `metadata_url`.
+type fakeArmServer struct {
+	*httptest.Server
+
+	lock           sync.Mutex
+	resources      map[string]map[string]interface{}
+	operations     map[int]int
+	operationPolls int
+	handlers       map[string]http.HandlerFunc
+	requests       []string
+}
+
+func newFakeArmServer() *fakeArmServer {
+	s := &fakeArmServer{
+		resources:  make(map[string]map[string]interface{}),
+		operations: make(map[int]int),
+		handlers:   make(map[string]http.HandlerFunc),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// armClient returns an ArmClient which sends all of its requests to the fake server. Resource
+// Providers aren't registered before resources are created, unless the test is of registration
+// (see armClientRegisteringProviders).
+func (s *fakeArmServer) armClient(t *testing.T) *ArmClient {
+	return s.newArmClient(t, true)
+}
+
+// armClientRegisteringProviders returns an ArmClient, as armClient does, which registers the
+// Resource Providers required by each resource before it's created.
+func (s *fakeArmServer) armClientRegisteringProviders(t *testing.T) *ArmClient {
+	return s.newArmClient(t, false)
+}
+
+func (s *fakeArmServer) newArmClient(t *testing.T, skipProviderRegistration bool) *ArmClient {
+	config := Config{
+		SubscriptionID:           fakeArmSubscriptionID,
+		ClientID:                 fakeArmClientID,
+		ClientSecret:             "fake-secret",
+		TenantID:                 fakeArmTenantID,
+		MetadataURL:              s.URL,
+		MaxRetries:               0,
+		MaxRetryDuration:         time.Minute,
+		SkipProviderRegistration: skipProviderRegistration,
+	}
+
+	client, err := config.getArmClient()
+	if err != nil {
+		t.Fatalf("Error building the ArmClient for the fake Resource Manager: %s", err)
+	}
+	client.StopContext = context.Background()
+	return client
+}
+
+// fakeArmVirtualNetworkConfig returns the raw configuration of the `azurerm_virtual_network`
+// used by tests of the behaviour shared by every resource, which tests can add to.
+func fakeArmVirtualNetworkConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"name":                "example",
+		"resource_group_name": "example",
+		"location":            "westeurope",
+		"address_space":       []interface{}{"10.0.0.0/16"},
+	}
+}
+
+// createResource creates the resource from the raw configuration in the same way Terraform
+// does (using the Diff and Apply of the resource), so that the Create function has access to
+// the timeouts of the resource.
+func createResource(t *testing.T, r *schema.Resource, raw map[string]interface{}, meta interface{}) (*terraform.InstanceState, error) {
+	c, err := config.NewRawConfig(raw)
+	if err != nil {
+		t.Fatalf("Error building the config: %s", err)
+	}
+
+	diff, err := r.Diff(nil, terraform.NewResourceConfig(c))
+	if err != nil {
+		t.Fatalf("Error building the diff: %s", err)
+	}
+
+	return r.Apply(nil, diff, meta)
+}
+
+// updateResource updates the resource to the configuration in the same way Terraform does.
+func updateResource(t *testing.T, r *schema.Resource, state *terraform.InstanceState, raw map[string]interface{}, meta interface{}) (*terraform.InstanceState, error) {
+	c, err := config.NewRawConfig(raw)
+	if err != nil {
+		t.Fatalf("Error building the config: %s", err)
+	}
+
+	diff, err := r.Diff(state, terraform.NewResourceConfig(c))
+	if err != nil {
+		t.Fatalf("Error building the diff: %s", err)
+	}
+
+	return r.Apply(state, diff, meta)
+}
+
+// destroyResource deletes the resource in the same way Terraform does.
+func destroyResource(r *schema.Resource, state *terraform.InstanceState, meta interface{}) error {
+	_, err := r.Apply(state, &terraform.InstanceDiff{Destroy: true}, meta)
+	return err
+}
+
+// handle overrides the response to requests with the method whose path has the suffix, such
+// as a `POST` to `/listKeys`.
+func (s *fakeArmServer) handle(method, pathSuffix string, handler http.HandlerFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.handlers[method+" "+strings.ToLower(pathSuffix)] = handler
+}
+
+// put stores the resource, as if it'd been created by a PUT request.
+func (s *fakeArmServer) put(id string, resource map[string]interface{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.store(id, resource)
+}
+
+// get returns a copy of the resource with the specified ID, if it exists.
+func (s *fakeArmServer) get(id string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	resource, ok := s.resources[strings.ToLower(id)]
+	if !ok {
+		return nil, false
+	}
+	return copyFakeResource(resource), true
+}
+
+// requestCount returns the number of requests made with the method whose path has the suffix.
+func (s *fakeArmServer) requestCount(method, pathSuffix string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	count := 0
+	for _, request := range s.requests {
+		if strings.HasPrefix(request, method+" ") && strings.HasSuffix(request, strings.ToLower(pathSuffix)) {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *fakeArmServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	s.lock.Lock()
+	s.requests = append(s.requests, r.Method+" "+strings.ToLower(path))
+	var handler http.HandlerFunc
+	for key, h := range s.handlers {
+		if strings.HasPrefix(key, r.Method+" ") && strings.HasSuffix(strings.ToLower(path), strings.TrimPrefix(key, r.Method+" ")) {
+			handler = h
+		}
+	}
+	s.lock.Unlock()
+
+	if handler != nil {
+		handler(w, r)
+		return
+	}
+
+	switch {
+	case path == "/metadata/endpoints":
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"graphEndpoint": s.URL,
+			"authentication": map[string]interface{}{
+				"loginEndpoint": s.URL,
+				"audiences":     []string{s.URL + "/"},
+			},
+		})
+	case strings.HasSuffix(path, "/oauth2/token"):
+		expiresOn := time.Now().Add(time.Hour).Unix()
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": "fake-token",
+			"token_type":   "Bearer",
+			"expires_in":   "3600",
+			"expires_on":   fmt.Sprintf("%d", expiresOn),
+			"not_before":   fmt.Sprintf("%d", time.Now().Unix()),
+			"resource":     r.FormValue("resource"),
+		})
+	case strings.HasPrefix(path, "/fakeOperations/"), strings.HasPrefix(path, "/fakeOperationResults/"):
+		s.serveOperation(w, path)
+	default:
+		s.serveResource(w, r, path)
+	}
+}
+
+func (s *fakeArmServer) serveResource(w http.ResponseWriter, r *http.Request, id string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := strings.ToLower(id)
+	existing, exists := s.resources[key]
+
+	switch r.Method {
+	case http.MethodGet:
+		// Resource IDs have an even number of segments, collections an odd number
+		if len(strings.Split(strings.Trim(id, "/"), "/"))%2 == 1 {
+			s.writeJSON(w, http.StatusOK, map[string]interface{}{"value": s.list(key)})
+			return
+		}
+		if !exists {
+			s.writeNotFound(w, id)
+			return
+		}
+		s.writeJSON(w, http.StatusOK, existing)
+
+	case http.MethodHead:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case http.MethodPut, http.MethodPatch:
+		if r.Method == http.MethodPatch && !exists {
+			s.writeNotFound(w, id)
+			return
+		}
+
+		resource := make(map[string]interface{})
+		if body, _ := ioutil.ReadAll(r.Body); len(body) > 0 {
+			if err := json.Unmarshal(body, &resource); err != nil {
+				s.writeJSON(w, http.StatusBadRequest, fakeArmError("InvalidRequestContent", err.Error()))
+				return
+			}
+		}
+		if r.Method == http.MethodPatch {
+			resource = mergeFakeResource(copyFakeResource(existing), resource)
+		}
+
+		stored := s.store(id, resource)
+
+		statusCode := http.StatusCreated
+		if exists {
+			statusCode = http.StatusOK
+		}
+
+		if s.operationPolls == 0 || r.Method == http.MethodPatch {
+			s.writeJSON(w, statusCode, stored)
+			return
+		}
+
+		// the resource is returned as in-progress, the client polls until the operation completes
+		response := copyFakeResource(stored)
+		response["properties"].(map[string]interface{})["provisioningState"] = "Updating"
+		s.startOperation(w, false)
+		s.writeJSON(w, statusCode, response)
+
+	case http.MethodDelete:
+		if !exists {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		for k := range s.resources {
+			if k == key || strings.HasPrefix(k, key+"/") {
+				delete(s.resources, k)
+			}
+		}
+
+		if s.operationPolls == 0 {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		s.startOperation(w, true)
+		w.WriteHeader(http.StatusAccepted)
+
+	case http.MethodPost:
+		s.writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+	default:
+		s.writeJSON(w, http.StatusMethodNotAllowed, fakeArmError("MethodNotAllowed", r.Method))
+	}
+}
+
+// store completes the resource as Resource Manager would - by setting the ID, name, type and
+// provisioning state, along with the IDs of any named sub-resources (such as the rules of a
+// Load Balancer) - before storing it. The caller must hold the lock.
+func (s *fakeArmServer) store(id string, resource map[string]interface{}) map[string]interface{} {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+
+	resource["id"] = id
+	resource["name"] = segments[len(segments)-1]
+	resource["type"] = fakeResourceType(segments)
+
+	properties, ok := resource["properties"].(map[string]interface{})
+	if !ok {
+		properties = make(map[string]interface{})
+		resource["properties"] = properties
+	}
+	properties["provisioningState"] = "Succeeded"
+
+	for key, value := range properties {
+		children, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, child := range children {
+			if child, ok := child.(map[string]interface{}); ok {
+				if name, ok := child["name"].(string); ok && child["id"] == nil {
+					child["id"] = fmt.Sprintf("%s/%s/%s", id, key, name)
+				}
+			}
+		}
+	}
+
+	s.resources[strings.ToLower(id)] = copyFakeResource(resource)
+	return resource
+}
+
+// list returns the resources directly within the collection. The caller must hold the lock.
+func (s *fakeArmServer) list(collection string) []interface{} {
+	values := make([]interface{}, 0)
+	for key, resource := range s.resources {
+		if key[:strings.LastIndex(key, "/")] == collection {
+			values = append(values, resource)
+		}
+	}
+	return values
+}
+
+// startOperation begins a long running operation, which is polled using either of the
+// headers. The caller must hold the lock.
+func (s *fakeArmServer) startOperation(w http.ResponseWriter, includeLocation bool) {
+	id := len(s.operations) + 1
+	s.operations[id] = s.operationPolls
+
+	w.Header().Set("Azure-AsyncOperation", fmt.Sprintf("%s/fakeOperations/%d", s.URL, id))
+	if includeLocation {
+		w.Header().Set("Location", fmt.Sprintf("%s/fakeOperationResults/%d", s.URL, id))
+	}
+	w.Header().Set("Retry-After", "0")
+}
+
+func (s *fakeArmServer) serveOperation(w http.ResponseWriter, path string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var id int
+	fmt.Sscanf(path[strings.LastIndex(path, "/")+1:], "%d", &id)
+
+	remaining, ok := s.operations[id]
+	if !ok {
+		s.writeNotFound(w, path)
+		return
+	}
+	if remaining > 0 {
+		s.operations[id] = remaining - 1
+	}
+
+	inProgress := remaining > 1
+	if strings.HasPrefix(path, "/fakeOperationResults/") {
+		if inProgress {
+			w.Header().Set("Location", s.URL+path)
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	status := "Succeeded"
+	if inProgress {
+		status = "InProgress"
+		w.Header().Set("Retry-After", "0")
+	}
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{"status": status})
+}
+
+func (s *fakeArmServer) writeNotFound(w http.ResponseWriter, id string) {
+	s.writeJSON(w, http.StatusNotFound, fakeArmError("ResourceNotFound", fmt.Sprintf("The Resource '%s' was not found.", id)))
+}
+
+func (s *fakeArmServer) writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func fakeArmError(code, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	}
+}
+
+// fakeResourceType returns the type of the resource, e.g. `Microsoft.Network/loadBalancers`.
+func fakeResourceType(segments []string) string {
+	providers := -1
+	for i, segment := range segments {
+		if strings.EqualFold(segment, "providers") {
+			providers = i
+		}
+	}
+	if providers == -1 || providers+2 >= len(segments) {
+		return "Microsoft.Resources/" + segments[len(segments)-2]
+	}
+
+	types := []string{segments[providers+1]}
+	for i := providers + 2; i < len(segments); i += 2 {
+		types = append(types, segments[i])
+	}
+	return strings.Join(types, "/")
+}
+
+func copyFakeResource(resource map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(resource)
+	var copied map[string]interface{}
+	json.Unmarshal(data, &copied)
+	return copied
+}
+
+// mergeFakeResource applies a PATCH to the resource.
+func mergeFakeResource(resource, patch map[string]interface{}) map[string]interface{} {
+	for key, value := range patch {
+		if patchMap, ok := value.(map[string]interface{}); ok {
+			if existingMap, ok := resource[key].(map[string]interface{}); ok {
+				resource[key] = mergeFakeResource(existingMap, patchMap)
+				continue
+			}
+		}
+		resource[key] = value
+	}
+	return resource
+}
+
+func TestFakeArmServer_resourceLifecycle(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+	server.operationPolls = 2
+
+	client := server.armClient(t).vnetClient()
+	vnet := map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"addressSpace": map[string]interface{}{"addressPrefixes": []string{"10.0.0.0/16"}},
+			"subnets": []interface{}{
+				map[string]interface{}{"name": "internal", "properties": map[string]interface{}{"addressPrefix": "10.0.1.0/24"}},
+			},
+		},
+	}
+	data, _ := json.Marshal(vnet)
+	id := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example", fakeArmSubscriptionID)
+	req, _ := http.NewRequest("PUT", server.URL+id, strings.NewReader(string(data)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Error creating the Virtual Network: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || resp.Header.Get("Azure-AsyncOperation") == "" {
+		t.Fatalf("Expected a long running operation to be started but got %d", resp.StatusCode)
+	}
+
+	read, err := client.Get("EXAMPLE", "Example", "")
+	if err != nil {
+		t.Fatalf("Error reading the Virtual Network: %s", err)
+	}
+	if read.ID == nil || *read.ID != id {
+		t.Fatalf("Expected the Virtual Network to be found case-insensitively but got %+v", read.ID)
+	}
+	subnets := *read.VirtualNetworkPropertiesFormat.Subnets
+	if len(subnets) != 1 || subnets[0].ID == nil || *subnets[0].ID != id+"/subnets/internal" {
+		t.Fatalf("Expected the ID of the Subnet to be set but got %+v", subnets)
+	}
+
+	list, err := client.List("example")
+	if err != nil {
+		t.Fatalf("Error listing the Virtual Networks: %s", err)
+	}
+	if list.Value == nil || len(*list.Value) != 1 {
+		t.Fatalf("Expected a single Virtual Network to be listed but got %+v", list.Value)
+	}
+
+	_, errChan := client.Delete("example", "example", make(chan struct{}))
+	if err := <-errChan; err != nil {
+		t.Fatalf("Error deleting the Virtual Network: %s", err)
+	}
+	if count := server.requestCount("GET", "/fakeOperations/2"); count != 2 {
+		t.Fatalf("Expected the delete operation to be polled twice but got %d", count)
+	}
+
+	read, err = client.Get("example", "example", "")
+	if err == nil || read.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected a 404 once the Virtual Network was deleted but got %d", read.StatusCode)
+	}
+}
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMAutomationAccount_importAccoutWithFreeSku(t *testing.T) {
 	resourceName := "azurerm_automation_account.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAutomationAccount_skuFree(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -34,7 +33,7 @@ func TestAccAzureRMAutomationAccount_importAccoutWithFreeSku(t *testing.T) {
 func TestAccAzureRMAutomationAccount_importAccoutWithBasicSku(t *testing.T) {
         resourceName := "azurerm_automation_account.test"
 
-        ri := acctest.RandInt()
+        ri := testRandInt(t)
         config := testAccAzureRMAutomationAccount_skuBasic(ri, testLocation())
 
         resource.Test(t, resource.TestCase{
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMAutomationCredential_importCredential(t *testing.T) {
 	resourceName := "azurerm_automation_credential.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAutomationCredential_testCredential(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
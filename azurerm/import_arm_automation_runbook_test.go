@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMAutomationRunbook_importRunbookPSWorkflow(t *testing.T) {
 	resourceName := "azurerm_automation_runbook.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAutomationRunbook_PSWorkflow(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
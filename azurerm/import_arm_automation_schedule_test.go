@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMAutomationSchedule_importScheduleOneTime(t *testing.T) {
 	resourceName := "azurerm_automation_schedule.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAutomationSchedule_oneTime(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
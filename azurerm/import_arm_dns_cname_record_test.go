@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMDnsCNameRecord_importBasic(t *testing.T) {
 	resourceName := "azurerm_dns_cname_record.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsCNameRecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMDnsCNameRecord_importBasic(t *testing.T) {
 func TestAccAzureRMDnsCNameRecord_importWithTags(t *testing.T) {
 	resourceName := "azurerm_dns_cname_record.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsCNameRecord_withTags(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMDnsNsRecord_importBasic(t *testing.T) {
 	resourceName := "azurerm_dns_ns_record.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsNsRecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMDnsNsRecord_importBasic(t *testing.T) {
 func TestAccAzureRMDnsNsRecord_importWithTags(t *testing.T) {
 	resourceName := "azurerm_dns_ns_record.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsNsRecord_withTags(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMDnsPtrRecord_importBasic(t *testing.T) {
 	resourceName := "azurerm_dns_ptr_record.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsPtrRecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMDnsPtrRecord_importBasic(t *testing.T) {
 func TestAccAzureRMDnsPtrRecord_importWithTags(t *testing.T) {
 	resourceName := "azurerm_dns_ptr_record.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsPtrRecord_withTags(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
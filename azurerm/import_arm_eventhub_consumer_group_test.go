@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMEventHubConsumerGroup_importBasic(t *testing.T) {
 	resourceName := "azurerm_eventhub_consumer_group.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHubConsumerGroup_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -34,7 +33,7 @@ func TestAccAzureRMEventHubConsumerGroup_importBasic(t *testing.T) {
 func TestAccAzureRMEventHubConsumerGroup_importComplete(t *testing.T) {
 	resourceName := "azurerm_eventhub_consumer_group.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHubConsumerGroup_complete(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMEventHub_importBasic(t *testing.T) {
 	resourceName := "azurerm_eventhub.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHub_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMExpressRouteCircuit_importBasic(t *testing.T) {
 	resourceName := "azurerm_express_route_circuit.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMExpressRouteCircuit_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
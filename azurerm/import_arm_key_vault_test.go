@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMKeyVault_importBasic(t *testing.T) {
 	resourceName := "azurerm_key_vault.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMKeyVault_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
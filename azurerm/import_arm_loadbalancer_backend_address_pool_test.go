@@ -4,14 +4,13 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMLoadBalancerBackEndAddressPool_importBasic(t *testing.T) {
 	resourceName := "azurerm_lb_backend_address_pool.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	addressPoolName := fmt.Sprintf("%d-address-pool", ri)
 
 	resource.Test(t, resource.TestCase{
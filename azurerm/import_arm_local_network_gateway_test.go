@@ -3,13 +3,12 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMLocalNetworkGateway_importBasic(t *testing.T) {
 	resourceName := "azurerm_local_network_gateway.test"
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -3,13 +3,12 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMNetworkSecurityGroup_importBasic(t *testing.T) {
 	resourceName := "azurerm_network_security_group.test"
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMPublicIpStatic_importBasic(t *testing.T) {
 	resourceName := "azurerm_public_ip.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMPublicIPStatic_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
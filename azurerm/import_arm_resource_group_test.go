@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMResourceGroup_importBasic(t *testing.T) {
 	resourceName := "azurerm_resource_group.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMResourceGroup_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
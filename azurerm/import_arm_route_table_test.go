@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMRouteTable_importBasic(t *testing.T) {
 	resourceName := "azurerm_route_table.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMRouteTable_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMSearchService_importBasic(t *testing.T) {
 	resourceName := "azurerm_search_service.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMSearchService_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
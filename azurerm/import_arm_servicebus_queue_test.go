@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMServiceBusQueue_importBasic(t *testing.T) {
 	resourceName := "azurerm_servicebus_queue.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMServiceBusQueue_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
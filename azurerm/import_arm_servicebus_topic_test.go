@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMServiceBusTopic_importBasic(t *testing.T) {
 	resourceName := "azurerm_servicebus_topic.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMServiceBusTopic_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMServiceBusTopic_importBasic(t *testing.T) {
 func TestAccAzureRMServiceBusTopic_importBasicDisabled(t *testing.T) {
 	resourceName := "azurerm_servicebus_topic.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMServiceBusTopic_basicDisabled(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
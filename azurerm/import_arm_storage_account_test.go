@@ -3,15 +3,14 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMStorageAccount_importBasic(t *testing.T) {
 	resourceName := "azurerm_storage_account.testsa"
 
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	config := testAccAzureRMStorageAccount_basic(ri, rs, testLocation())
 
 	resource.Test(t, resource.TestCase{
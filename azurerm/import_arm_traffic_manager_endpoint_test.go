@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMTrafficManagerEndpoint_importBasic(t *testing.T) {
 	resourceName := "azurerm_traffic_manager_endpoint.testExternal"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMTrafficManagerEndpoint_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
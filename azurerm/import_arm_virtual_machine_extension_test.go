@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMVirtualMachineExtension_importBasic(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_extension.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachineExtension_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
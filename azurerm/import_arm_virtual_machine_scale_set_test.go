@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMVirtualMachineScaleSet_importBasic(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_scale_set.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachineScaleSet_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMVirtualMachineScaleSet_importBasic(t *testing.T) {
 func TestAccAzureRMVirtualMachineScaleSet_importBasic_managedDisk(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_scale_set.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachineScaleSet_basicLinux_managedDisk(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -56,7 +55,7 @@ func TestAccAzureRMVirtualMachineScaleSet_importBasic_managedDisk(t *testing.T)
 func TestAccAzureRMVirtualMachineScaleSet_importLinux(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_scale_set.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachineScaleSet_linux(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -79,7 +78,7 @@ func TestAccAzureRMVirtualMachineScaleSet_importLinux(t *testing.T) {
 func TestAccAzureRMVirtualMachineScaleSet_importLoadBalancer(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_scale_set.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachineScaleSetLoadBalancerTemplate(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -102,7 +101,7 @@ func TestAccAzureRMVirtualMachineScaleSet_importLoadBalancer(t *testing.T) {
 func TestAccAzureRMVirtualMachineScaleSet_importOverProvision(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_scale_set.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachineScaleSetOverProvisionTemplate(ri, testLocation())
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -123,7 +122,7 @@ func TestAccAzureRMVirtualMachineScaleSet_importOverProvision(t *testing.T) {
 func TestAccAzureRMVirtualMachineScaleSet_importExtension(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_scale_set.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachineScaleSetExtensionTemplate(ri, testLocation())
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -144,7 +143,7 @@ func TestAccAzureRMVirtualMachineScaleSet_importExtension(t *testing.T) {
 func TestAccAzureRMVirtualMachineScaleSet_importMultipleExtensions(t *testing.T) {
 	resourceName := "azurerm_virtual_machine_scale_set.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachineScaleSetMultipleExtensionsTemplate(ri, testLocation())
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMVirtualMachine_importBasic(t *testing.T) {
 	resourceName := "azurerm_virtual_machine.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachine_basicLinuxMachine(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -38,7 +37,7 @@ func TestAccAzureRMVirtualMachine_importBasic(t *testing.T) {
 func TestAccAzureRMVirtualMachine_importBasic_managedDisk(t *testing.T) {
 	resourceName := "azurerm_virtual_machine.test"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualMachine_basicLinuxMachine_managedDisk_explicit(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -3,14 +3,13 @@ package azurerm
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 )
 
 func TestAccAzureRMVirtualNetworkPeering_importBasic(t *testing.T) {
 	resourceName := "azurerm_virtual_network_peering.test1"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualNetworkPeering_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
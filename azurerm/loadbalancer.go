@@ -1,10 +1,13 @@
 package azurerm
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"net/http"
-	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/hashicorp/errwrap"
@@ -13,18 +16,35 @@ import (
 )
 
 func resourceGroupAndLBNameFromId(loadBalancerId string) (string, string, error) {
-	id, err := parseAzureResourceID(loadBalancerId)
+	id, names, err := parseAzureResourceIDOfType(loadBalancerId, "Microsoft.Network/loadBalancers")
 	if err != nil {
 		return "", "", err
 	}
-	name := id.Path["loadBalancers"]
+	name := names[0]
 	resGroup := id.ResourceGroup
 
 	return resGroup, name, nil
 }
 
+type loadBalancerRead struct {
+	loadBalancer *network.LoadBalancer
+	exists       bool
+}
+
+// retrieveLoadBalancerById returns the Load Balancer, which is cached (since it's read by each
+// of its child resources) until it's modified - so it mustn't be modified by the caller.
 func retrieveLoadBalancerById(loadBalancerId string, meta interface{}) (*network.LoadBalancer, bool, error) {
-	loadBalancerClient := meta.(*ArmClient).loadBalancerClient
+	value, err := meta.(*ArmClient).readCache.get(loadBalancerId, func() (interface{}, error) {
+		loadBalancer, exists, err := readLoadBalancerById(loadBalancerId, meta)
+		return loadBalancerRead{loadBalancer, exists}, err
+	})
+	read := value.(loadBalancerRead)
+	return read.loadBalancer, read.exists, err
+}
+
+// readLoadBalancerById returns the Load Balancer without using the cache, for changes to it.
+func readLoadBalancerById(loadBalancerId string, meta interface{}) (*network.LoadBalancer, bool, error) {
+	loadBalancerClient := meta.(*ArmClient).loadBalancerClient()
 
 	resGroup, name, err := resourceGroupAndLBNameFromId(loadBalancerId)
 	if err != nil {
@@ -42,6 +62,228 @@ func retrieveLoadBalancerById(loadBalancerId string, meta interface{}) (*network
 	return &resp, true, nil
 }
 
+// loadBalancerWriteWindow is how long a change to a child resource of a Load Balancer (such as
+// a Rule or a Probe) waits for changes to the other child resources of the Load Balancer, so
+// that they're made by a single update rather than one update each.
+var loadBalancerWriteWindow = 2 * time.Second
+
+// loadBalancerWrites holds the changes to the child resources of each Load Balancer (keyed by
+// its ID) which are waiting to be written.
+var loadBalancerWrites = &loadBalancerWriteQueue{
+	pending: make(map[string]*loadBalancerWriteBatch),
+}
+
+// loadBalancerChange makes the change to a child resource to the Load Balancer, returning
+// whether it was modified. It mustn't modify the Load Balancer when it returns an error.
+type loadBalancerChange func(loadBalancer *network.LoadBalancer) (bool, error)
+
+type loadBalancerWrite struct {
+	ctx    context.Context
+	child  string
+	change loadBalancerChange
+	result chan loadBalancerWriteResult
+}
+
+type loadBalancerWriteResult struct {
+	loadBalancer *network.LoadBalancer
+	exists       bool
+	err          error
+}
+
+// loadBalancerWriteBatch is written using its own context rather than that of any one of its
+// changes, which is cancelled once none of the changes are waiting for it - so that a change
+// which times out (or is stopped) doesn't cancel the update of the others.
+type loadBalancerWriteBatch struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	writes  []*loadBalancerWrite
+	waiting int
+}
+
+type loadBalancerWriteQueue struct {
+	lock    sync.Mutex
+	pending map[string]*loadBalancerWriteBatch
+}
+
+// updateLoadBalancer makes the change to the child resource (described by child, such as
+// `Probe "http"`) of the Load Balancer, and returns the Load Balancer once it's been updated -
+// or whether it exists, since nothing is changed when it doesn't.
+//
+// Changes to the child resources of a Load Balancer made within loadBalancerWriteWindow of
+// each other, or while it's being updated, are merged into a single update. Each change
+// returns its own error: when the merged update fails, the changes are retried one at a time
+// so that the error is returned by the child resource which caused it.
+func updateLoadBalancer(ctx context.Context, meta interface{}, loadBalancerID string, child string, change loadBalancerChange) (*network.LoadBalancer, bool, error) {
+	write := &loadBalancerWrite{
+		ctx:    ctx,
+		child:  child,
+		change: change,
+		result: make(chan loadBalancerWriteResult, 1),
+	}
+
+	queue := loadBalancerWrites
+	key := strings.ToLower(loadBalancerID)
+	queue.lock.Lock()
+	batch, waiting := queue.pending[key]
+	if waiting && batch.ctx.Err() != nil {
+		// each of the changes in the batch stopped waiting for it, so it's abandoned
+		waiting = false
+	}
+	if !waiting {
+		batch = newLoadBalancerWriteBatch(meta.(*ArmClient))
+		queue.pending[key] = batch
+	}
+	batch.writes = append(batch.writes, write)
+	batch.waiting++
+	queue.lock.Unlock()
+
+	// the first change to the Load Balancer starts writing the batch, they all wait for it
+	if !waiting {
+		go queue.write(meta, loadBalancerID, batch)
+	}
+
+	defer queue.done(batch)
+	select {
+	case result := <-write.result:
+		return result.loadBalancer, result.exists, result.err
+	case <-ctx.Done():
+		return nil, false, fmt.Errorf("Error waiting for the update of LoadBalancer %q with the changes to %s: %s", loadBalancerID, child, ctx.Err())
+	}
+}
+
+func newLoadBalancerWriteBatch(client *ArmClient) *loadBalancerWriteBatch {
+	ctx, cancel := context.WithCancel(context.Background())
+	if client.operations == nil {
+		return &loadBalancerWriteBatch{ctx: ctx, cancel: cancel}
+	}
+
+	return &loadBalancerWriteBatch{
+		ctx: ctx,
+		cancel: func() {
+			client.operations.forget(ctx.Done())
+			cancel()
+		},
+	}
+}
+
+// done is called once a change stops waiting for the batch, cancelling the batch once none of
+// its changes are waiting for it.
+func (q *loadBalancerWriteQueue) done(batch *loadBalancerWriteBatch) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	batch.waiting--
+	if batch.waiting == 0 {
+		batch.cancel()
+	}
+}
+
+func (q *loadBalancerWriteQueue) write(meta interface{}, loadBalancerID string, batch *loadBalancerWriteBatch) {
+	select {
+	case <-time.After(loadBalancerWriteWindow):
+	case <-batch.ctx.Done():
+	}
+
+	unlock := lockResourceIDs(loadBalancerID)
+	defer unlock()
+
+	// changes made while waiting for the lock are written by this batch too
+	q.lock.Lock()
+	key := strings.ToLower(loadBalancerID)
+	if q.pending[key] == batch {
+		delete(q.pending, key)
+	}
+	writes := batch.writes
+	q.lock.Unlock()
+
+	writeLoadBalancerChanges(batch.ctx, meta, loadBalancerID, writes)
+}
+
+// writeLoadBalancerChanges retrieves the Load Balancer, makes the changes to it and updates it,
+// sending the result to each of the changes. The caller must hold the lock on the Load Balancer.
+func writeLoadBalancerChanges(ctx context.Context, meta interface{}, loadBalancerID string, writes []*loadBalancerWrite) {
+	loadBalancer, exists, err := readLoadBalancerById(loadBalancerID, meta)
+	if err != nil || !exists {
+		if err != nil {
+			err = errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
+		}
+		for _, write := range writes {
+			write.result <- loadBalancerWriteResult{exists: exists, err: err}
+		}
+		return
+	}
+
+	modified := false
+	applied := make([]*loadBalancerWrite, 0, len(writes))
+	children := make([]string, 0, len(writes))
+	for _, write := range writes {
+		if err := write.ctx.Err(); err != nil {
+			write.result <- loadBalancerWriteResult{err: err}
+			continue
+		}
+
+		changed, err := write.change(loadBalancer)
+		if err != nil {
+			write.result <- loadBalancerWriteResult{exists: true, err: err}
+			continue
+		}
+
+		modified = modified || changed
+		applied = append(applied, write)
+		children = append(children, write.child)
+	}
+
+	if !modified {
+		for _, write := range applied {
+			write.result <- loadBalancerWriteResult{loadBalancer: loadBalancer, exists: true}
+		}
+		return
+	}
+
+	log.Printf("[DEBUG] Updating LoadBalancer %q with the changes to %s", loadBalancerID, strings.Join(children, ", "))
+	read, err := putLoadBalancer(ctx, meta, loadBalancerID, loadBalancer)
+	if err != nil && len(applied) > 1 {
+		log.Printf("[DEBUG] Error updating LoadBalancer %q with %d changes, retrying them one at a time: %s", loadBalancerID, len(applied), err)
+		for _, write := range applied {
+			if err := write.ctx.Err(); err != nil {
+				write.result <- loadBalancerWriteResult{err: err}
+				continue
+			}
+			writeLoadBalancerChanges(ctx, meta, loadBalancerID, []*loadBalancerWrite{write})
+		}
+		return
+	}
+
+	for _, write := range applied {
+		write.result <- loadBalancerWriteResult{loadBalancer: read, exists: true, err: err}
+	}
+}
+
+func putLoadBalancer(ctx context.Context, meta interface{}, loadBalancerID string, loadBalancer *network.LoadBalancer) (*network.LoadBalancer, error) {
+	lbClient := meta.(*ArmClient).loadBalancerClient()
+
+	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
+	if err != nil {
+		return nil, errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
+	}
+
+	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, ctx.Done())
+	err = <-error
+	if err != nil {
+		return nil, errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
+	}
+
+	read, err := lbClient.Get(resGroup, loadBalancerName, "")
+	if err != nil {
+		return nil, errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
+	}
+	if read.ID == nil {
+		return nil, fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
+	}
+
+	return &read, nil
+}
+
 func findLoadBalancerBackEndAddressPoolByName(lb *network.LoadBalancer, name string) (*network.BackendAddressPool, int, bool) {
 	if lb == nil || lb.LoadBalancerPropertiesFormat == nil || lb.LoadBalancerPropertiesFormat.BackendAddressPools == nil {
 		return nil, -1, false
@@ -128,7 +370,7 @@ func findLoadBalancerProbeByName(lb *network.LoadBalancer, name string) (*networ
 
 func loadbalancerStateRefreshFunc(client *ArmClient, resourceGroupName string, loadbalancer string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		res, err := client.loadBalancerClient.Get(resourceGroupName, loadbalancer, "")
+		res, err := client.loadBalancerClient().Get(resourceGroupName, loadbalancer, "")
 		if err != nil {
 			return nil, "", fmt.Errorf("Error issuing read request in loadbalancerStateRefreshFunc to Azure ARM for LoadBalancer '%s' (RG: '%s'): %s", loadbalancer, resourceGroupName, err)
 		}
@@ -147,21 +389,21 @@ func validateLoadBalancerPrivateIpAddressAllocation(v interface{}, k string) (ws
 
 // sets the loadbalancer_id in the ResourceData from the sub resources full id
 func loadBalancerSubResourceStateImporter(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	r, err := regexp.Compile(`.+\/loadBalancers\/.+?\/`)
-	if err != nil {
-		return nil, err
-	}
-
-	lbID := strings.TrimSuffix(r.FindString(d.Id()), "/")
-	parsed, err := parseAzureResourceID(lbID)
+	id, err := parseAzureResourceID(d.Id())
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse loadbalancer id from %s", d.Id())
 	}
 
-	if parsed.Path["loadBalancers"] == "" {
+	if !strings.EqualFold(id.Provider, "Microsoft.Network") || len(id.Segments) != 2 || !strings.EqualFold(id.Segments[0].Key, "loadBalancers") {
 		return nil, fmt.Errorf("parsed ID is invalid")
 	}
 
+	id.Segments = id.Segments[:1]
+	lbID, err := composeAzureResourceID(id)
+	if err != nil {
+		return nil, err
+	}
+
 	d.Set("loadbalancer_id", lbID)
 	return []*schema.ResourceData{d}, nil
 }
@@ -0,0 +1,189 @@
+package azurerm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/arm/network"
+)
+
+func TestUpdateLoadBalancer_fakeArmCoalesced(t *testing.T) {
+	window := loadBalancerWriteWindow
+	loadBalancerWriteWindow = 500 * time.Millisecond
+	defer func() {
+		loadBalancerWriteWindow = window
+	}()
+
+	cases := []struct {
+		Name     string
+		Rules    []string
+		Failed   []string
+		Expected int
+	}{
+		{
+			Name:     "merged",
+			Rules:    []string{"http", "https", "ssh"},
+			Expected: 1,
+		},
+		{
+			// the change can't be made, so the other rules are created without it
+			Name:     "invalid",
+			Rules:    []string{"http", "invalid", "ssh"},
+			Failed:   []string{"invalid"},
+			Expected: 1,
+		},
+		{
+			// the merged update is rejected, so the rules are retried one at a time
+			Name:     "rejected",
+			Rules:    []string{"http", "rejected", "ssh"},
+			Failed:   []string{"rejected"},
+			Expected: 4,
+		},
+	}
+
+	for _, tc := range cases {
+		server := newFakeArmServer()
+		defer server.Close()
+
+		loadBalancerID := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/loadBalancers/example", fakeArmSubscriptionID)
+		server.put(loadBalancerID, map[string]interface{}{
+			"location": "westeurope",
+			"properties": map[string]interface{}{
+				"frontendIPConfigurations": []interface{}{
+					map[string]interface{}{"name": "public"},
+				},
+				"loadBalancingRules": []interface{}{},
+			},
+		})
+		server.handle("PUT", "/loadBalancers/example", func(w http.ResponseWriter, req *http.Request) {
+			body, _ := ioutil.ReadAll(req.Body)
+			if bytes.Contains(body, []byte(`"name":"rejected"`)) {
+				server.writeJSON(w, http.StatusBadRequest, fakeArmError("InvalidResourceReference", "The rule rejected is invalid"))
+				return
+			}
+
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			server.serveResource(w, req, strings.TrimSuffix(req.URL.Path, "/"))
+		})
+
+		meta := server.armClient(t)
+		r := resourceArmLoadBalancerRule()
+
+		var lock sync.Mutex
+		var wg sync.WaitGroup
+		errors := make(map[string]error)
+		for i, name := range tc.Rules {
+			wg.Add(1)
+			go func(i int, name string) {
+				defer wg.Done()
+
+				frontend := "public"
+				if name == "invalid" {
+					frontend = "private"
+				}
+				_, err := createResource(t, r, map[string]interface{}{
+					"name":                           name,
+					"resource_group_name":            "example",
+					"loadbalancer_id":                loadBalancerID,
+					"frontend_ip_configuration_name": frontend,
+					"protocol":                       "Tcp",
+					"frontend_port":                  8000 + i,
+					"backend_port":                   8000 + i,
+				}, meta)
+
+				lock.Lock()
+				errors[name] = err
+				lock.Unlock()
+			}(i, name)
+		}
+		wg.Wait()
+
+		failed := make(map[string]bool)
+		for _, name := range tc.Failed {
+			failed[name] = true
+		}
+		for name, err := range errors {
+			if failed[name] && err == nil {
+				t.Fatalf("%s: Expected an error creating the Load Balancer Rule %q", tc.Name, name)
+			}
+			if !failed[name] && err != nil {
+				t.Fatalf("%s: Error creating the Load Balancer Rule %q: %s", tc.Name, name, err)
+			}
+		}
+
+		if count := server.requestCount("PUT", "/loadBalancers/example"); count != tc.Expected {
+			t.Fatalf("%s: Expected the Load Balancer to be updated %d times but got %d", tc.Name, tc.Expected, count)
+		}
+
+		loadBalancer, _ := server.get(loadBalancerID)
+		rules := loadBalancer["properties"].(map[string]interface{})["loadBalancingRules"].([]interface{})
+		if expected := len(tc.Rules) - len(tc.Failed); len(rules) != expected {
+			t.Fatalf("%s: Expected the Load Balancer to have %d rules but got %+v", tc.Name, expected, rules)
+		}
+	}
+}
+
+func TestUpdateLoadBalancer_fakeArmCancelled(t *testing.T) {
+	window := loadBalancerWriteWindow
+	loadBalancerWriteWindow = 500 * time.Millisecond
+	defer func() {
+		loadBalancerWriteWindow = window
+	}()
+
+	server := newFakeArmServer()
+	defer server.Close()
+
+	loadBalancerID := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/loadBalancers/example", fakeArmSubscriptionID)
+	server.put(loadBalancerID, map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"probes": []interface{}{},
+		},
+	})
+
+	meta := server.armClient(t)
+	addProbe := func(name string) loadBalancerChange {
+		return func(loadBalancer *network.LoadBalancer) (bool, error) {
+			probes := append(*loadBalancer.LoadBalancerPropertiesFormat.Probes, network.Probe{Name: &name})
+			loadBalancer.LoadBalancerPropertiesFormat.Probes = &probes
+			return true, nil
+		}
+	}
+
+	// the first change starts the batch, but is cancelled before it's written - which
+	// mustn't cancel the update of the change which is still waiting for it
+	cancelled, cancel := context.WithCancel(context.Background())
+	errors := make(chan error, 1)
+	go func() {
+		_, _, err := updateLoadBalancer(cancelled, meta, loadBalancerID, `Probe "cancelled"`, addProbe("cancelled"))
+		errors <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	if _, exists, err := updateLoadBalancer(context.Background(), meta, loadBalancerID, `Probe "waiting"`, addProbe("waiting")); err != nil || !exists {
+		t.Fatalf("Error updating the Load Balancer: %v", err)
+	}
+	if err := <-errors; err == nil {
+		t.Fatalf("Expected an error for the cancelled change")
+	}
+
+	loadBalancer, _ := server.get(loadBalancerID)
+	probes := loadBalancer["properties"].(map[string]interface{})["probes"].([]interface{})
+	if len(probes) != 1 || probes[0].(map[string]interface{})["name"] != "waiting" {
+		t.Fatalf("Expected only the Probe which was still waiting to be written but got %+v", probes)
+	}
+	if count := server.requestCount("PUT", "/loadBalancers/example"); count != 1 {
+		t.Fatalf("Expected the Load Balancer to be updated once but got %d", count)
+	}
+}
@@ -1,8 +1,16 @@
 package azurerm
 
 import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
 	"strings"
+	"sync"
 
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -46,3 +54,270 @@ func azureRMNormalizeLocation(location interface{}) string {
 func azureRMSuppressLocationDiff(k, old, new string, d *schema.ResourceData) bool {
 	return azureRMNormalizeLocation(old) == azureRMNormalizeLocation(new)
 }
+
+// locationResourceTypes are the Resource Manager types of the resources which have a location,
+// used to check the resource type is available in the configured location.
+var locationResourceTypes = map[string]string{
+	"azurerm_application_insights":      "Microsoft.Insights/components",
+	"azurerm_availability_set":          "Microsoft.Compute/availabilitySets",
+	"azurerm_cdn_endpoint":              "Microsoft.Cdn/profiles/endpoints",
+	"azurerm_cdn_profile":               "Microsoft.Cdn/profiles",
+	"azurerm_container_registry":        "Microsoft.ContainerRegistry/registries",
+	"azurerm_container_service":         "Microsoft.ContainerService/containerServices",
+	"azurerm_eventhub_namespace":        "Microsoft.EventHub/namespaces",
+	"azurerm_express_route_circuit":     "Microsoft.Network/expressRouteCircuits",
+	"azurerm_image":                     "Microsoft.Compute/images",
+	"azurerm_key_vault":                 "Microsoft.KeyVault/vaults",
+	"azurerm_lb":                        "Microsoft.Network/loadBalancers",
+	"azurerm_local_network_gateway":     "Microsoft.Network/localNetworkGateways",
+	"azurerm_managed_disk":              "Microsoft.Compute/disks",
+	"azurerm_network_interface":         "Microsoft.Network/networkInterfaces",
+	"azurerm_network_security_group":    "Microsoft.Network/networkSecurityGroups",
+	"azurerm_public_ip":                 "Microsoft.Network/publicIPAddresses",
+	"azurerm_resource_group":            "Microsoft.Resources/resourceGroups",
+	"azurerm_route_table":               "Microsoft.Network/routeTables",
+	"azurerm_search_service":            "Microsoft.Search/searchServices",
+	"azurerm_servicebus_namespace":      "Microsoft.ServiceBus/namespaces",
+	"azurerm_sql_database":              "Microsoft.Sql/servers/databases",
+	"azurerm_sql_elasticpool":           "Microsoft.Sql/servers/elasticPools",
+	"azurerm_sql_server":                "Microsoft.Sql/servers",
+	"azurerm_storage_account":           "Microsoft.Storage/storageAccounts",
+	"azurerm_virtual_machine":           "Microsoft.Compute/virtualMachines",
+	"azurerm_virtual_machine_extension": "Microsoft.Compute/virtualMachines/extensions",
+	"azurerm_virtual_machine_scale_set": "Microsoft.Compute/virtualMachineScaleSets",
+	"azurerm_virtual_network":           "Microsoft.Network/virtualNetworks",
+}
+
+// locationCache contains the locations available to the subscription and the locations each
+// resource type is available in. It's populated from the API the first time it's used, and
+// is then shared by all of the resources using the provider instance.
+type locationCache struct {
+	once sync.Once
+	err  error
+
+	list func() ([]subscriptionLocation, []resources.Provider, error)
+
+	// names maps the lower-cased name and display name of each location to its name
+	names map[string]string
+
+	// resourceTypes maps each lower-cased resource type (e.g. `microsoft.compute/disks`) to
+	// the names of the locations it's available in
+	resourceTypes map[string][]string
+}
+
+func newLocationCache(list func() ([]subscriptionLocation, []resources.Provider, error)) *locationCache {
+	return &locationCache{
+		list: list,
+	}
+}
+
+func (c *locationCache) load() error {
+	c.once.Do(func() {
+		locations, providers, err := c.list()
+		if err != nil {
+			c.err = err
+			return
+		}
+
+		c.names = make(map[string]string)
+		for _, location := range locations {
+			if location.Name == nil {
+				continue
+			}
+			c.names[strings.ToLower(*location.Name)] = *location.Name
+			if location.DisplayName != nil {
+				c.names[strings.ToLower(*location.DisplayName)] = *location.Name
+			}
+		}
+
+		c.resourceTypes = make(map[string][]string)
+		for _, provider := range providers {
+			if provider.Namespace == nil || provider.ResourceTypes == nil {
+				continue
+			}
+
+			for _, resourceType := range *provider.ResourceTypes {
+				if resourceType.ResourceType == nil || resourceType.Locations == nil {
+					continue
+				}
+
+				key := strings.ToLower(*provider.Namespace + "/" + *resourceType.ResourceType)
+				for _, location := range *resourceType.Locations {
+					// the locations of each resource type are returned as display names
+					if name, ok := c.names[strings.ToLower(location)]; ok {
+						c.resourceTypes[key] = append(c.resourceTypes[key], name)
+					} else {
+						c.resourceTypes[key] = append(c.resourceTypes[key], azureRMNormalizeLocation(location))
+					}
+				}
+			}
+		}
+	})
+
+	return c.err
+}
+
+// normalize returns the name of the location, which can be specified as either its name or
+// display name. When the locations can't be retrieved the simple normalization is used.
+func (c *locationCache) normalize(location string) (string, bool) {
+	if c == nil || c.load() != nil {
+		return azureRMNormalizeLocation(location), true
+	}
+
+	if name, ok := c.names[strings.ToLower(strings.TrimSpace(location))]; ok {
+		return name, true
+	}
+
+	if name, ok := c.names[azureRMNormalizeLocation(location)]; ok {
+		return name, true
+	}
+
+	return azureRMNormalizeLocation(location), false
+}
+
+// validate checks the location is available to the subscription and, if a resource type is
+// specified, that the resource type is available in that location.
+func (c *locationCache) validate(location, resourceType string) error {
+	if c == nil {
+		return nil
+	}
+
+	if err := c.load(); err != nil {
+		// validation is best-effort, since the credentials may not permit listing locations
+		log.Printf("[WARN] Unable to validate the location %q: %s", location, err)
+		return nil
+	}
+
+	name, ok := c.normalize(location)
+	if !ok {
+		return fmt.Errorf("%q was not found in the locations available to the subscription: %s", location, strings.Join(c.sortedNames(), ", "))
+	}
+
+	if resourceType == "" {
+		return nil
+	}
+
+	available, ok := c.resourceTypes[strings.ToLower(resourceType)]
+	if !ok || len(available) == 0 {
+		// the resource type isn't restricted to specific locations
+		return nil
+	}
+
+	for _, candidate := range available {
+		if strings.EqualFold(candidate, name) || strings.EqualFold(candidate, "global") {
+			return nil
+		}
+	}
+
+	sort.Strings(available)
+	return fmt.Errorf("%s is not available in %q, it's available in: %s", resourceType, name, strings.Join(available, ", "))
+}
+
+func (c *locationCache) sortedNames() []string {
+	unique := make(map[string]struct{})
+	for _, name := range c.names {
+		unique[name] = struct{}{}
+	}
+
+	names := make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// subscriptionLocation is a location returned from the Subscriptions API.
+type subscriptionLocation struct {
+	Name        *string `json:"name,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+}
+
+type subscriptionLocationListResult struct {
+	autorest.Response `json:"-"`
+	Value             *[]subscriptionLocation `json:"value,omitempty"`
+}
+
+// listLocations retrieves the locations available to the subscription and the locations each
+// resource type is available in, using the Resource Providers client (since the Subscriptions
+// client isn't vendored).
+func (c *ArmClient) listLocations() ([]subscriptionLocation, []resources.Provider, error) {
+	client := c.providers()
+
+	req, err := autorest.Prepare(&http.Request{},
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/subscriptions/{subscriptionId}/locations", map[string]interface{}{
+			"subscriptionId": autorest.Encode("path", client.SubscriptionID),
+		}),
+		autorest.WithQueryParameters(map[string]interface{}{
+			"api-version": "2016-06-01",
+		}))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error preparing the request to list Locations: %s", err)
+	}
+
+	resp, err := autorest.SendWithSender(client, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error listing Locations: %s", err)
+	}
+
+	var result subscriptionLocationListResult
+	err = autorest.Respond(resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error listing Locations: %s", err)
+	}
+
+	providers, err := client.List(nil, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error listing Resource Providers: %s", err)
+	}
+
+	var locations []subscriptionLocation
+	if result.Value != nil {
+		locations = *result.Value
+	}
+
+	var providerList []resources.Provider
+	if providers.Value != nil {
+		providerList = *providers.Value
+	}
+
+	return locations, providerList, nil
+}
+
+// configureLocationSchema replaces the normalization and validation of the location of the
+// resource with functions which use the locations cached by the provider, once it's been
+// configured.
+func configureLocationSchema(p *schema.Provider, location *schema.Schema, resourceType string) {
+	location.StateFunc = func(v interface{}) string {
+		name, _ := providerLocations(p).normalize(v.(string))
+		return name
+	}
+
+	location.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+		locations := providerLocations(p)
+		oldName, _ := locations.normalize(old)
+		newName, _ := locations.normalize(new)
+		return strings.EqualFold(oldName, newName)
+	}
+
+	location.ValidateFunc = func(v interface{}, k string) (ws []string, es []error) {
+		if err := providerLocations(p).validate(v.(string), resourceType); err != nil {
+			es = append(es, fmt.Errorf("%q is invalid: %s", k, err))
+		}
+		return
+	}
+}
+
+func providerLocations(p *schema.Provider) *locationCache {
+	if client, ok := p.Meta().(*ArmClient); ok {
+		return client.locations
+	}
+
+	return nil
+}
@@ -1,6 +1,13 @@
 package azurerm
 
-import "testing"
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
 
 func TestAzureRMNormalizeLocation(t *testing.T) {
 	s := azureRMNormalizeLocation("West US")
@@ -8,3 +15,109 @@ func TestAzureRMNormalizeLocation(t *testing.T) {
 		t.Fatalf("expected location to equal westus, actual %s", s)
 	}
 }
+
+func TestLocationCache(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	server.handle("GET", "/locations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value":[
+			{"name":"westeurope","displayName":"West Europe"},
+			{"name":"usgovvirginia","displayName":"US Gov Virginia"},
+			{"name":"southcentralus","displayName":"South Central US"}
+		]}`)
+	})
+	server.handle("GET", "/providers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value":[
+			{"namespace":"Microsoft.Compute","resourceTypes":[
+				{"resourceType":"virtualMachines","locations":["West Europe","South Central US"]},
+				{"resourceType":"availabilitySets","locations":[]}
+			]},
+			{"namespace":"Microsoft.Cdn","resourceTypes":[
+				{"resourceType":"profiles","locations":["global"]}
+			]}
+		]}`)
+	})
+
+	client := server.armClient(t)
+	locations := client.locations
+
+	normalizeCases := []struct {
+		Location string
+		Expected string
+		Found    bool
+	}{
+		{Location: "westeurope", Expected: "westeurope", Found: true},
+		{Location: "West Europe", Expected: "westeurope", Found: true},
+		{Location: "US Gov Virginia", Expected: "usgovvirginia", Found: true},
+		{Location: "southcentralus", Expected: "southcentralus", Found: true},
+		{Location: "westeurop", Expected: "westeurop", Found: false},
+	}
+
+	for _, tc := range normalizeCases {
+		name, found := locations.normalize(tc.Location)
+		if name != tc.Expected || found != tc.Found {
+			t.Fatalf("Expected %q to normalize to %q (found %t) but got %q (found %t)", tc.Location, tc.Expected, tc.Found, name, found)
+		}
+	}
+
+	validateCases := []struct {
+		Location     string
+		ResourceType string
+		ErrCount     int
+		Message      string
+	}{
+		{Location: "West Europe", ResourceType: "", ErrCount: 0},
+		{Location: "westeurop", ResourceType: "", ErrCount: 1, Message: "southcentralus, usgovvirginia, westeurope"},
+		{Location: "westeurope", ResourceType: "Microsoft.Compute/virtualMachines", ErrCount: 0},
+		{Location: "usgovvirginia", ResourceType: "Microsoft.Compute/virtualMachines", ErrCount: 1, Message: "southcentralus, westeurope"},
+		{Location: "usgovvirginia", ResourceType: "microsoft.compute/availabilitySets", ErrCount: 0},
+		{Location: "usgovvirginia", ResourceType: "Microsoft.Cdn/profiles", ErrCount: 0},
+		{Location: "usgovvirginia", ResourceType: "Microsoft.Unknown/things", ErrCount: 0},
+	}
+
+	for _, tc := range validateCases {
+		err := locations.validate(tc.Location, tc.ResourceType)
+		if tc.ErrCount == 0 && err != nil {
+			t.Fatalf("Expected %q to be valid for %q but got: %s", tc.Location, tc.ResourceType, err)
+		}
+		if tc.ErrCount > 0 && (err == nil || !strings.Contains(err.Error(), tc.Message)) {
+			t.Fatalf("Expected %q to be invalid for %q with the message %q but got: %v", tc.Location, tc.ResourceType, tc.Message, err)
+		}
+	}
+
+	// the locations are only retrieved once per provider instance
+	if count := server.requestCount("GET", "/locations"); count != 1 {
+		t.Fatalf("Expected the locations to be retrieved once but they were retrieved %d times", count)
+	}
+}
+
+func TestLocationCache_unavailable(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	server.handle("GET", "/locations", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"code":"AuthorizationFailed","message":"Forbidden"}}`)
+	})
+
+	locations := server.armClient(t).locations
+
+	// validation is skipped when the locations can't be retrieved
+	if err := locations.validate("westeurop", "Microsoft.Compute/virtualMachines"); err != nil {
+		t.Fatalf("Expected validation to be skipped but got: %s", err)
+	}
+
+	if name, _ := locations.normalize("West Europe"); name != "westeurope" {
+		t.Fatalf("Expected the location to be normalized to %q but got %q", "westeurope", name)
+	}
+}
+
+func TestLocationResourceTypes(t *testing.T) {
+	p := Provider()
+	for name := range locationResourceTypes {
+		if _, ok := p.(*schema.Provider).ResourcesMap[name]; !ok {
+			t.Fatalf("Expected %q to be a resource", name)
+		}
+	}
+}
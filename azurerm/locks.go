@@ -1,24 +1,71 @@
 package azurerm
 
-// handle the case of using the same name for different kinds of resources
-func azureRMLockByName(name string, resourceType string) {
-	updatedName := resourceType + "." + name
-	armMutexKV.Lock(updatedName)
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Resources which modify the same resource in Azure - such as the Subnets of a Virtual Network,
+// the Rules of a Network Security Group or the Rules of a Load Balancer - or which reference a
+// resource Azure updates at the same time (for example a Subnet associated with a Network
+// Security Group) lock the full ID of that resource, so that their operations are serialized
+// rather than failing with `AnotherOperationInProgress`. Since the locks are keyed by ID, two
+// resources with the same name in different Resource Groups (or subscriptions) don't block
+// each other.
+//
+// Lock ordering: every lock an operation requires is acquired by a single call to
+// lockResourceIDs, which acquires them in a consistent (sorted) order - so two operations
+// which require overlapping locks can't deadlock. Locks must never be acquired while others
+// are held (for example by calling lockResourceIDs twice within the same operation).
+
+// lockResourceIDs locks the resources with the specified IDs (ignoring any which are empty,
+// such as optional references which aren't set) and returns a function which unlocks them.
+func lockResourceIDs(ids ...string) func() {
+	keys := resourceLockKeys(ids)
+	for _, key := range keys {
+		armMutexKV.Lock(key)
+	}
+
+	return func() {
+		for i := len(keys) - 1; i >= 0; i-- {
+			armMutexKV.Unlock(keys[i])
+		}
+	}
 }
 
-func azureRMLockMultipleByName(names *[]string, resourceType string) {
-	for _, name := range *names {
-		azureRMLockByName(name, resourceType)
+// resourceLockKeys returns the keys which lock the resources, in the order they're acquired.
+// Resource IDs are case-insensitive, so the keys are normalised.
+func resourceLockKeys(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		key := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(id), "/"))
+		if key == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		keys = append(keys, key)
 	}
+
+	sort.Strings(keys)
+	return keys
 }
 
-func azureRMUnlockByName(name string, resourceType string) {
-	updatedName := resourceType + "." + name
-	armMutexKV.Unlock(updatedName)
+// networkResourceID returns the ID of a resource within the `Microsoft.Network` provider, such
+// as a `virtualNetworks` or `networkSecurityGroups`, for resources which reference it by name.
+func networkResourceID(subscriptionID, resourceGroup, resourceType, name string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/%s/%s", subscriptionID, resourceGroup, resourceType, name)
 }
 
-func azureRMUnlockMultipleByName(names *[]string, resourceType string) {
-	for _, name := range *names {
-		azureRMUnlockByName(name, resourceType)
+// parentResourceID returns the ID of the resource a child resource belongs to, such as the
+// Virtual Network of a Subnet.
+func parentResourceID(id string) string {
+	segments := strings.Split(strings.TrimSuffix(id, "/"), "/")
+	if len(segments) < 3 {
+		return ""
 	}
+
+	return strings.Join(segments[:len(segments)-2], "/")
 }
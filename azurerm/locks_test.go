@@ -0,0 +1,222 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceLockKeys(t *testing.T) {
+	vnetId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example"
+	nsgId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/networkSecurityGroups/example"
+
+	cases := []struct {
+		Name     string
+		IDs      []string
+		Expected []string
+	}{
+		{
+			Name:     "none",
+			IDs:      []string{},
+			Expected: []string{},
+		},
+		{
+			Name:     "sorted",
+			IDs:      []string{vnetId, nsgId},
+			Expected: []string{strings.ToLower(nsgId), strings.ToLower(vnetId)},
+		},
+		{
+			Name:     "empty references are ignored",
+			IDs:      []string{"", vnetId, ""},
+			Expected: []string{strings.ToLower(vnetId)},
+		},
+		{
+			Name:     "case-insensitive duplicates",
+			IDs:      []string{vnetId, strings.ToUpper(vnetId), vnetId + "/"},
+			Expected: []string{strings.ToLower(vnetId)},
+		},
+	}
+
+	for _, tc := range cases {
+		keys := resourceLockKeys(tc.IDs)
+		if !reflect.DeepEqual(keys, tc.Expected) {
+			t.Fatalf("%s: Expected the keys %+v but got %+v", tc.Name, tc.Expected, keys)
+		}
+	}
+}
+
+func TestParentResourceID(t *testing.T) {
+	cases := []struct {
+		ID       string
+		Expected string
+	}{
+		{
+			ID:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example/subnets/first",
+			Expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example",
+		},
+		{
+			ID:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/routeTables/example/routes/first/",
+			Expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/routeTables/example",
+		},
+		{
+			ID:       "",
+			Expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		if parent := parentResourceID(tc.ID); parent != tc.Expected {
+			t.Fatalf("Expected the parent of %q to be %q but got %q", tc.ID, tc.Expected, parent)
+		}
+	}
+}
+
+func TestNetworkInterfaceIpConfigurationIdsToLock(t *testing.T) {
+	vnetId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example"
+	lbId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/loadBalancers/example"
+
+	data := map[string]interface{}{
+		"subnet_id": vnetId + "/subnets/example",
+		"load_balancer_backend_address_pools_ids": schema.NewSet(schema.HashString, []interface{}{lbId + "/backendAddressPools/example"}),
+		"load_balancer_inbound_nat_rules_ids":     schema.NewSet(schema.HashString, []interface{}{lbId + "/inboundNatRules/first", lbId + "/inboundNatRules/second"}),
+	}
+
+	keys := resourceLockKeys(networkInterfaceIpConfigurationIdsToLock(data))
+	expected := []string{strings.ToLower(lbId), strings.ToLower(vnetId)}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("Expected the keys %+v but got %+v", expected, keys)
+	}
+}
+
+func TestLockResourceIDs_overlapping(t *testing.T) {
+	first := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/first"
+	second := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/second"
+
+	// operations which lock the same resources in the opposite order would deadlock if the
+	// locks weren't acquired in a consistent order
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			unlock := lockResourceIDs(first, second)
+			unlock()
+		}()
+		go func() {
+			defer wg.Done()
+			unlock := lockResourceIDs(strings.ToUpper(second), first)
+			unlock()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected the locks to be acquired without deadlocking")
+	}
+}
+
+func TestResourceAzureRMSubnet_fakeArmConcurrentCreate(t *testing.T) {
+	cases := []struct {
+		Name           string
+		ResourceGroups []string
+		Concurrent     bool
+	}{
+		{
+			Name:           "same virtual network",
+			ResourceGroups: []string{"example", "example", "EXAMPLE"},
+			Concurrent:     false,
+		},
+		{
+			Name:           "virtual networks with the same name in other resource groups",
+			ResourceGroups: []string{"first", "second", "third"},
+			Concurrent:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		server := newFakeArmServer()
+		defer server.Close()
+
+		meta := server.armClient(t)
+		r := Provider().(*schema.Provider).ResourcesMap["azurerm_subnet"]
+
+		// each PUT is held open, so that overlapping requests are seen - when the subnets can
+		// be created concurrently the requests wait for one another, which they only can if
+		// they're sent at the same time
+		var lock sync.Mutex
+		inFlight, maxInFlight := 0, 0
+		allInFlight := make(chan struct{})
+		server.handle("PUT", "", func(w http.ResponseWriter, req *http.Request) {
+			lock.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			if inFlight == len(tc.ResourceGroups) {
+				close(allInFlight)
+			}
+			lock.Unlock()
+
+			if tc.Concurrent {
+				select {
+				case <-allInFlight:
+				case <-time.After(10 * time.Second):
+				}
+			} else {
+				time.Sleep(50 * time.Millisecond)
+			}
+
+			lock.Lock()
+			inFlight--
+			lock.Unlock()
+
+			server.serveResource(w, req, strings.TrimSuffix(req.URL.Path, "/"))
+		})
+
+		var wg sync.WaitGroup
+		errors := make(chan error, len(tc.ResourceGroups))
+		for i, resourceGroup := range tc.ResourceGroups {
+			wg.Add(1)
+			go func(i int, resourceGroup string) {
+				defer wg.Done()
+
+				raw := map[string]interface{}{
+					"name":                 fmt.Sprintf("subnet%d", i),
+					"resource_group_name":  resourceGroup,
+					"virtual_network_name": "example",
+					"address_prefix":       fmt.Sprintf("10.0.%d.0/24", i),
+				}
+				if _, err := createResource(t, r, raw, meta); err != nil {
+					errors <- err
+				}
+			}(i, resourceGroup)
+		}
+		wg.Wait()
+		close(errors)
+
+		for err := range errors {
+			t.Fatalf("%s: Error creating the Subnet: %s", tc.Name, err)
+		}
+
+		expected := 1
+		if tc.Concurrent {
+			expected = len(tc.ResourceGroups)
+		}
+		if maxInFlight != expected {
+			t.Fatalf("%s: Expected at most %d Subnets to be created at the same time but got %d", tc.Name, expected, maxInFlight)
+		}
+	}
+}
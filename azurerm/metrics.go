@@ -0,0 +1,123 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// runMetrics records the requests sent by every instance of the provider within the process,
+// which lives for a single Terraform run.
+var runMetrics = newAPIMetrics()
+
+// apiMetrics records the number of requests sent by each of the SDK clients (keyed by the
+// service, such as `network.VirtualNetworks`), how long they took and how many were throttled,
+// so it's possible to see which resources are making the most requests to Azure.
+type apiMetrics struct {
+	lock     sync.Mutex
+	services map[string]*serviceMetrics
+}
+
+type serviceMetrics struct {
+	service   string
+	requests  int
+	throttled int
+	failed    int
+	total     time.Duration
+	max       time.Duration
+}
+
+func newAPIMetrics() *apiMetrics {
+	return &apiMetrics{
+		services: make(map[string]*serviceMetrics),
+	}
+}
+
+// withMetrics returns a SendDecorator which records each request sent by the client for the
+// service - including each retry, so that throttled requests are counted.
+func withMetrics(metrics *apiMetrics, service string) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			started := time.Now()
+			resp, err := s.Do(r)
+			metrics.record(service, time.Since(started), resp, err)
+			return resp, err
+		})
+	}
+}
+
+func (m *apiMetrics) record(service string, elapsed time.Duration, resp *http.Response, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	metrics, ok := m.services[service]
+	if !ok {
+		metrics = &serviceMetrics{service: service}
+		m.services[service] = metrics
+	}
+
+	metrics.requests++
+	metrics.total += elapsed
+	if elapsed > metrics.max {
+		metrics.max = elapsed
+	}
+
+	switch {
+	case err != nil || resp == nil:
+		metrics.failed++
+	case resp.StatusCode == http.StatusTooManyRequests:
+		metrics.throttled++
+	case resp.StatusCode >= http.StatusInternalServerError:
+		metrics.failed++
+	}
+}
+
+// summary returns the metrics of each service, ordered by the number of requests (the most
+// first).
+func (m *apiMetrics) summary() []serviceMetrics {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	summary := make([]serviceMetrics, 0, len(m.services))
+	for _, metrics := range m.services {
+		summary = append(summary, *metrics)
+	}
+
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].requests != summary[j].requests {
+			return summary[i].requests > summary[j].requests
+		}
+		return summary[i].service < summary[j].service
+	})
+	return summary
+}
+
+func (s serviceMetrics) String() string {
+	average := s.total / time.Duration(s.requests)
+	return fmt.Sprintf("%s: %d requests (%d throttled, %d failed), %s average, %s max",
+		s.service, s.requests, s.throttled, s.failed, average.Round(time.Millisecond), s.max.Round(time.Millisecond))
+}
+
+// LogAPIMetrics logs the requests sent to Azure by each of the SDK clients during the run,
+// and is called once the provider's plugin has been asked to exit.
+func LogAPIMetrics() {
+	summary := runMetrics.summary()
+	if len(summary) == 0 {
+		return
+	}
+
+	requests := 0
+	for _, metrics := range summary {
+		requests += metrics.requests
+	}
+
+	log.Printf("[INFO] %d requests were sent to Azure during this run:", requests)
+	for _, metrics := range summary {
+		log.Printf("[INFO]   %s", metrics)
+	}
+}
@@ -0,0 +1,64 @@
+package azurerm
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAPIMetrics_fakeArm(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+	server.handle("GET", "/subnets/throttled", func(w http.ResponseWriter, r *http.Request) {
+		server.writeJSON(w, http.StatusTooManyRequests, map[string]interface{}{
+			"error": map[string]interface{}{"code": "TooManyRequests"},
+		})
+	})
+
+	client := server.armClient(t)
+	client.metrics = newAPIMetrics()
+
+	for i := 0; i < 3; i++ {
+		client.vnetClient().Get("example", "example", "")
+	}
+	client.subnetClient().Get("example", "example", "throttled", "")
+
+	summary := client.metrics.summary()
+	if len(summary) != 2 {
+		t.Fatalf("Expected the requests of 2 clients to be recorded but got %+v", summary)
+	}
+
+	cases := []struct {
+		Service   string
+		Requests  int
+		Throttled int
+	}{
+		{"network.VirtualNetworks", 3, 0},
+		{"network.Subnets", 1, 1},
+	}
+	for i, tc := range cases {
+		metrics := summary[i]
+		if metrics.service != tc.Service || metrics.requests != tc.Requests || metrics.throttled != tc.Throttled {
+			t.Fatalf("Expected %d requests (%d throttled) by %q but got %+v", tc.Requests, tc.Throttled, tc.Service, metrics)
+		}
+		if metrics.total <= 0 || metrics.max <= 0 {
+			t.Fatalf("Expected the latency of the requests by %q to be recorded but got %+v", tc.Service, metrics)
+		}
+	}
+}
+
+func TestServiceMetrics_String(t *testing.T) {
+	metrics := serviceMetrics{
+		service:   "network.VirtualNetworks",
+		requests:  4,
+		throttled: 1,
+		failed:    2,
+		total:     2 * time.Second,
+		max:       1200 * time.Millisecond,
+	}
+
+	expected := "network.VirtualNetworks: 4 requests (1 throttled, 2 failed), 500ms average, 1.2s max"
+	if actual := metrics.String(); actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
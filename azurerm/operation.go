@@ -0,0 +1,155 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// stopCh returns a channel which is closed when Terraform asks the provider to stop (for
+// example when an apply is interrupted). It's used as the cancel channel of long-running
+// operations, so that the SDK stops sending (and polling) the request.
+func (armClient *ArmClient) stopCh() <-chan struct{} {
+	if armClient.StopContext == nil {
+		// a nil channel is never closed, so the operation is never cancelled
+		return nil
+	}
+
+	return armClient.StopContext.Done()
+}
+
+// operationContext returns a context which is done once the timeout of the operation has
+// elapsed, or when Terraform asks the provider to stop. Its Done channel is used as the cancel
+// channel of long-running operations.
+func (armClient *ArmClient) operationContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	parent := armClient.StopContext
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	if armClient.operations == nil {
+		return ctx, cancel
+	}
+
+	return ctx, func() {
+		armClient.operations.forget(ctx.Done())
+		cancel()
+	}
+}
+
+// createOrUpdateTimeout returns the timeout for the operation made by the Create function of a
+// resource, which for many resources is also its Update function.
+func createOrUpdateTimeout(d *schema.ResourceData) time.Duration {
+	if d.IsNewResource() {
+		return d.Timeout(schema.TimeoutCreate)
+	}
+
+	return d.Timeout(schema.TimeoutUpdate)
+}
+
+// waitForState waits for the StateChangeConf to reach one of its target states, returning an
+// error as soon as Terraform asks the provider to stop rather than waiting for the timeout.
+func (armClient *ArmClient) waitForState(conf *resource.StateChangeConf) (interface{}, error) {
+	return waitForStateUntilStopped(conf, armClient.stopCh())
+}
+
+func waitForStateUntilStopped(conf *resource.StateChangeConf, stop <-chan struct{}) (interface{}, error) {
+	// once stopped, the next refresh ends the polling of the state in the background
+	refresh := conf.Refresh
+	conf.Refresh = func() (interface{}, string, error) {
+		select {
+		case <-stop:
+			return nil, "", fmt.Errorf("Terraform is stopping")
+		default:
+			return refresh()
+		}
+	}
+
+	type result struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		value, err := conf.WaitForState()
+		done <- result{value, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err
+	case <-stop:
+		return nil, fmt.Errorf("Stopped waiting for the state to become %q since Terraform is stopping", strings.Join(conf.Target, ", "))
+	}
+}
+
+// trackInterruptedCreate sets the ID of a resource whose creation failed because the operation
+// timed out or Terraform asked the provider to stop, so that the resource (which may have been created in Azure) is
+// saved to the state rather than being left untracked. When the operation had been started,
+// only its URL is saved alongside the ID, so that it's checked when the resource is next read
+// (see checkOperationBeforeRead). The resource type and names are in the format used by
+// parseAzureResourceIDOfType, e.g. `Microsoft.Network/loadBalancers`.
+func trackInterruptedCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, resourceGroup string, resourceType string, names ...string) {
+	client := meta.(*ArmClient)
+	if d.Id() != "" || ctx.Err() == nil {
+		return
+	}
+
+	types := strings.Split(resourceType, "/")
+	id := &ResourceID{
+		SubscriptionID: client.subscriptionId,
+		ResourceGroup:  resourceGroup,
+		Provider:       types[0],
+	}
+	for i, key := range types[1:] {
+		if i >= len(names) {
+			break
+		}
+		id.Segments = append(id.Segments, ResourceIDSegment{Key: key, Value: names[i]})
+	}
+
+	resourceID, err := composeAzureResourceID(id)
+	if err != nil {
+		log.Printf("[WARN] Unable to compose the ID of the interrupted %s %q: %s", resourceType, strings.Join(names, "/"), err)
+		return
+	}
+
+	log.Printf("[INFO] The creation of %q was interrupted, saving it to the state", resourceID)
+	d.SetId(resourceID)
+
+	if client.operations == nil {
+		return
+	}
+	if operationURL := client.operations.operationURL(ctx.Done()); operationURL != "" {
+		// the remaining attributes are read once the operation has completed
+		d.Partial(true)
+		if err := d.Set(operationURLKey, operationURL); err != nil {
+			log.Printf("[WARN] Unable to save the operation creating %q: %s", resourceID, err)
+			return
+		}
+		d.SetPartial(operationURLKey)
+	}
+}
+
+// defaultTimeouts are the timeouts of the operations of each resource, unless the resource
+// defines its own - which can be overridden using the `timeouts` block in the configuration.
+func defaultTimeouts(r *schema.Resource) *schema.ResourceTimeout {
+	timeouts := &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(30 * time.Minute),
+		Read:   schema.DefaultTimeout(5 * time.Minute),
+		Delete: schema.DefaultTimeout(30 * time.Minute),
+	}
+
+	// the timeout for updates can only be configured on resources which can be updated
+	if r.Update != nil {
+		timeouts.Update = schema.DefaultTimeout(30 * time.Minute)
+	}
+
+	return timeouts
+}
@@ -0,0 +1,413 @@
+package azurerm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// operationURLKey is the attribute (added to every resource) which stores the URL of the
+// operation that was still running when the creation of the resource was interrupted, so that
+// the operation can be resumed (rather than the resource recreated) on the next run.
+const operationURLKey = "operation_url"
+
+const (
+	operationStatusInProgress = "InProgress"
+	operationStatusSucceeded  = "Succeeded"
+	operationStatusFailed     = "Failed"
+	operationStatusCanceled   = "Canceled"
+)
+
+// operationPoller waits for the long running operations started by Resource Manager, using
+// the `Azure-AsyncOperation` or `Location` header returned when the operation was started
+// (or the `provisioningState` of the resource when neither is returned).
+//
+// Every SDK client sends its requests through the poller (see withPolling), so that the
+// operation has completed by the time the SDK's own polling sees the response. The URL of
+// each operation which is in flight is kept by the cancel channel of its request, so that an
+// interrupted creation can be resumed later (see trackInterruptedCreate).
+type operationPoller struct {
+	sender     autorest.Sender
+	authorizer autorest.Authorizer
+	userAgent  string
+
+	// delay is the time between polls when Resource Manager doesn't return a `Retry-After`
+	delay time.Duration
+
+	// stopCh is closed when Terraform asks the provider to stop, ending the polling of requests
+	// which can't otherwise be cancelled - which are also given up on after timeout
+	stopCh  func() <-chan struct{}
+	timeout time.Duration
+
+	lock     sync.Mutex
+	inFlight map[<-chan struct{}]string
+}
+
+func newOperationPoller(sender autorest.Sender, authorizer autorest.Authorizer) *operationPoller {
+	return &operationPoller{
+		sender:     sender,
+		authorizer: authorizer,
+		userAgent:  armUserAgent(),
+		delay:      10 * time.Second,
+		timeout:    60 * time.Minute,
+		inFlight:   make(map[<-chan struct{}]string),
+	}
+}
+
+// operationStatus is the status of a long running operation, read from either an operation
+// resource (returned by the `Azure-AsyncOperation` URL) or the resource itself.
+type operationStatus struct {
+	Status          string  `json:"status"`
+	PercentComplete float64 `json:"percentComplete"`
+	Error           struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	Properties struct {
+		ProvisioningState string `json:"provisioningState"`
+	} `json:"properties"`
+
+	// state is the Status or provisioningState, inferred from the status code if neither is set
+	state string
+}
+
+func (s *operationStatus) terminated() bool {
+	switch strings.ToLower(s.state) {
+	case strings.ToLower(operationStatusSucceeded), strings.ToLower(operationStatusFailed), strings.ToLower(operationStatusCanceled):
+		return true
+	}
+	return false
+}
+
+func (s *operationStatus) succeeded() bool {
+	return strings.EqualFold(s.state, operationStatusSucceeded)
+}
+
+// readOperationStatus reads the status of the operation from the response, leaving the body
+// intact for the SDK. The `status` is only read from operation resources, since a resource
+// can have a property of the same name.
+func readOperationStatus(resp *http.Response, operationResource bool) (*operationStatus, error) {
+	body, err := readAndRestoreBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the status of the operation: %s", err)
+	}
+
+	status := &operationStatus{}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		// the body of a completed operation needn't be a status (e.g. the result of an action)
+		json.Unmarshal(body, status)
+	}
+
+	switch {
+	case operationResource && status.Status != "":
+		status.state = status.Status
+	case status.Properties.ProvisioningState != "" && resp.StatusCode < 300:
+		status.state = status.Properties.ProvisioningState
+	case resp.StatusCode == http.StatusAccepted:
+		status.state = operationStatusInProgress
+	case resp.StatusCode == http.StatusOK, resp.StatusCode == http.StatusCreated, resp.StatusCode == http.StatusNoContent:
+		status.state = operationStatusSucceeded
+	default:
+		status.state = operationStatusFailed
+	}
+
+	// any state other than a terminal one (e.g. `Creating` or `ResolvingDNS`) is in progress
+	if !status.terminated() {
+		status.state = operationStatusInProgress
+	}
+
+	return status, nil
+}
+
+// withPolling returns a SendDecorator which waits for any long running operation started by
+// the request to complete, polling it using the Authorizer of the SDK client.
+func (p *operationPoller) withPolling(authorizer autorest.Authorizer) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			resp, err := s.Do(r)
+			if err != nil || r.Method == http.MethodGet || resp == nil {
+				return resp, err
+			}
+			if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+				return resp, nil
+			}
+
+			// as with the SDK's polling, the first response is always the resource
+			status, err := readOperationStatus(resp, false)
+			if err != nil || status.terminated() {
+				return resp, err
+			}
+
+			pollingURL := resp.Header.Get("Azure-AsyncOperation")
+			if pollingURL == "" {
+				pollingURL = resp.Header.Get("Location")
+			}
+			if pollingURL == "" && (r.Method == http.MethodPut || r.Method == http.MethodPatch) {
+				pollingURL = r.URL.String()
+			}
+			if pollingURL == "" {
+				// there's nothing to poll, so the SDK returns the error
+				return resp, nil
+			}
+
+			log.Printf("[INFO] %s %s started a long running operation, polling %s", r.Method, r.URL.Path, pollingURL)
+			asyncOperation := resp.Header.Get("Azure-AsyncOperation") != ""
+			cancel := r.Cancel
+			if cancel == nil {
+				var stop func()
+				cancel, stop = p.stopOrTimeout()
+				defer stop()
+			}
+			p.track(r.Cancel, pollingURL)
+			final, err := p.wait(s, authorizer, pollingURL, asyncOperation, resp, cancel)
+			if err != nil {
+				return final, err
+			}
+			p.forget(r.Cancel)
+
+			// the result of a create or update is the resource, rather than the operation
+			if (r.Method == http.MethodPut || r.Method == http.MethodPatch) && pollingURL != r.URL.String() {
+				return p.get(s, authorizer, r.URL.String(), cancel)
+			}
+
+			return final, nil
+		})
+	}
+}
+
+// stopOrTimeout returns a channel which is closed when Terraform asks the provider to stop, or
+// once the timeout of the poller has passed, for the requests sent without a cancel channel.
+// The returned function must be called once the channel is no longer needed.
+func (p *operationPoller) stopOrTimeout() (<-chan struct{}, func()) {
+	var stopCh <-chan struct{}
+	if p.stopCh != nil {
+		stopCh = p.stopCh()
+	}
+
+	cancel := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		timer := time.NewTimer(p.timeout)
+		defer timer.Stop()
+		select {
+		case <-stopCh:
+			close(cancel)
+		case <-timer.C:
+			close(cancel)
+		case <-done:
+		}
+	}()
+
+	return cancel, func() { close(done) }
+}
+
+// wait polls the operation until it completes, returning the final response - or an error
+// if the operation fails or the cancel channel is closed.
+func (p *operationPoller) wait(s autorest.Sender, authorizer autorest.Authorizer, pollingURL string, asyncOperation bool, resp *http.Response, cancel <-chan struct{}) (*http.Response, error) {
+	started := time.Now()
+	for {
+		// there's no need to wait before the first poll of a resumed operation
+		var delay time.Duration
+		if resp != nil {
+			delay = autorest.GetRetryAfter(resp, p.delay)
+		}
+		select {
+		case <-time.After(delay):
+		case <-cancel:
+			return resp, fmt.Errorf("Stopped waiting for the operation %s after %s since it was cancelled", pollingURL, time.Since(started).Round(time.Second))
+		}
+
+		var err error
+		resp, err = p.get(s, authorizer, pollingURL, cancel)
+		if err != nil {
+			return resp, fmt.Errorf("Error polling the operation %s: %s", pollingURL, err)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return resp, fmt.Errorf("The operation %s wasn't found", pollingURL)
+		}
+
+		status, err := readOperationStatus(resp, asyncOperation)
+		if err != nil {
+			return resp, err
+		}
+
+		elapsed := time.Since(started).Round(time.Second)
+		if !status.terminated() {
+			if status.PercentComplete > 0 {
+				log.Printf("[INFO] The operation %s is %.0f%% complete (%s elapsed)", pollingURL, status.PercentComplete, elapsed)
+			} else {
+				log.Printf("[INFO] The operation %s is still in progress (%s elapsed)", pollingURL, elapsed)
+			}
+			continue
+		}
+
+		log.Printf("[INFO] The operation %s finished with the status %q after %s", pollingURL, status.state, elapsed)
+		if !status.succeeded() {
+			p.forget(cancel)
+			return resp, fmt.Errorf("The operation %s finished with the status %q: Code=%q Message=%q", pollingURL, status.state, status.Error.Code, status.Error.Message)
+		}
+
+		return resp, nil
+	}
+}
+
+func (p *operationPoller) get(s autorest.Sender, authorizer autorest.Authorizer, url string, cancel <-chan struct{}) (*http.Response, error) {
+	req, err := autorest.Prepare(&http.Request{Cancel: cancel},
+		autorest.AsGet(),
+		autorest.WithBaseURL(url),
+		autorest.WithUserAgent(p.userAgent),
+		authorizer.WithAuthorization())
+	if err != nil {
+		return nil, fmt.Errorf("Error preparing the request to %s: %s", url, err)
+	}
+
+	return s.Do(req)
+}
+
+// status checks the status of an operation which was started by a previous run, without
+// waiting for it to complete. It returns nil when the operation isn't found, which happens
+// once it's expired; any other response which isn't a status is returned as an error.
+func (p *operationPoller) status(pollingURL string, cancel <-chan struct{}) (*operationStatus, error) {
+	resp, err := p.get(p.sender, p.authorizer, pollingURL, cancel)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking the operation %s: %s", pollingURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		log.Printf("[INFO] The operation %s wasn't found", pollingURL)
+		return nil, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("Error checking the operation %s: unexpected status %d: %s", pollingURL, resp.StatusCode, body)
+	}
+
+	status, err := readOperationStatus(resp, true)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] The operation %s has the status %q", pollingURL, status.state)
+	return status, nil
+}
+
+// resume waits for an operation which was started by a previous run to complete.
+func (p *operationPoller) resume(pollingURL string, cancel <-chan struct{}) error {
+	log.Printf("[INFO] Resuming the operation %s", pollingURL)
+	// the URL which was saved is usually an `Azure-AsyncOperation`, which is preferred
+	_, err := p.wait(p.sender, p.authorizer, pollingURL, true, nil, cancel)
+	return err
+}
+
+func (p *operationPoller) track(cancel <-chan struct{}, pollingURL string) {
+	if cancel == nil {
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.inFlight[cancel] = pollingURL
+}
+
+func (p *operationPoller) forget(cancel <-chan struct{}) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.inFlight, cancel)
+}
+
+// operationURL returns the URL of the operation, started using the cancel channel, which is
+// still in flight.
+func (p *operationPoller) operationURL(cancel <-chan struct{}) string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.inFlight[cancel]
+}
+
+// checkOperationBeforeRead wraps the Read function of a resource, so that the operation which
+// was still running when the creation of the resource was interrupted is checked (once) before
+// the resource is read. If the operation failed, the resource is removed from the state so
+// that it's created again; if it's still running, the resource is read as it currently is and
+// the operation is waited for by the next update of the resource (see resumeOperationBeforeUpdate).
+func checkOperationBeforeRead(read schema.ReadFunc) schema.ReadFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		pollingURL, _ := d.Get(operationURLKey).(string)
+		if pollingURL == "" {
+			return read(d, meta)
+		}
+
+		client := meta.(*ArmClient)
+		ctx, cancel := client.operationContext(d.Timeout(schema.TimeoutRead))
+		defer cancel()
+		status, err := client.operations.status(pollingURL, ctx.Done())
+		if err != nil {
+			return fmt.Errorf("Error checking the creation of %q: %s", d.Id(), err)
+		}
+
+		// an operation which has expired has long since completed, the resource is read to
+		// find out whether it was created
+		if status == nil || status.succeeded() {
+			d.Set(operationURLKey, "")
+			return read(d, meta)
+		}
+		if status.terminated() {
+			log.Printf("[WARN] The creation of %q finished with the status %q, removing it from the state so that it's created again", d.Id(), status.state)
+			d.SetId("")
+			return nil
+		}
+
+		log.Printf("[INFO] The creation of %q is still in progress", d.Id())
+		id := d.Id()
+		if err := read(d, meta); err != nil {
+			return err
+		}
+		if d.Id() == "" {
+			// the resource may not be returned until it's been created
+			d.SetId(id)
+		}
+		return nil
+	}
+}
+
+// resumeOperationBeforeUpdate wraps the Update function of a resource, so that the operation
+// which was still running when the creation of the resource was interrupted is waited for
+// before the resource is updated.
+func resumeOperationBeforeUpdate(update schema.UpdateFunc) schema.UpdateFunc {
+	if update == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, meta interface{}) error {
+		pollingURL, _ := d.Get(operationURLKey).(string)
+		if pollingURL == "" {
+			return update(d, meta)
+		}
+
+		client := meta.(*ArmClient)
+		ctx, cancel := client.operationContext(d.Timeout(schema.TimeoutCreate))
+		defer cancel()
+
+		status, err := client.operations.status(pollingURL, ctx.Done())
+		if err != nil {
+			return fmt.Errorf("Error checking the creation of %q: %s", d.Id(), err)
+		}
+		if status == nil {
+			d.Set(operationURLKey, "")
+			return update(d, meta)
+		}
+		if err := client.operations.resume(pollingURL, ctx.Done()); err != nil {
+			return fmt.Errorf("Error waiting for the creation of %q to complete: %s", d.Id(), err)
+		}
+
+		d.Set(operationURLKey, "")
+		return update(d, meta)
+	}
+}
@@ -0,0 +1,228 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestReadOperationStatus(t *testing.T) {
+	cases := []struct {
+		StatusCode        int
+		Body              string
+		OperationResource bool
+		Expected          string
+	}{
+		{http.StatusOK, `{"status": "Succeeded"}`, true, operationStatusSucceeded},
+		{http.StatusOK, `{"status": "InProgress", "percentComplete": 50}`, true, operationStatusInProgress},
+		{http.StatusOK, `{"status": "Failed", "error": {"code": "Conflict"}}`, true, operationStatusFailed},
+		{http.StatusOK, `{"status": "Canceled"}`, true, operationStatusCanceled},
+		{http.StatusCreated, `{"properties": {"provisioningState": "Creating"}}`, false, operationStatusInProgress},
+		{http.StatusOK, `{"properties": {"provisioningState": "ResolvingDNS"}}`, false, operationStatusInProgress},
+		{http.StatusOK, `{"properties": {"provisioningState": "succeeded"}}`, false, "succeeded"},
+		{http.StatusOK, `{"status": "Running", "properties": {"provisioningState": "Succeeded"}}`, false, operationStatusSucceeded},
+		{http.StatusAccepted, ``, false, operationStatusInProgress},
+		{http.StatusNoContent, ``, false, operationStatusSucceeded},
+		{http.StatusOK, `{"value": "the result of an action"}`, false, operationStatusSucceeded},
+		{http.StatusInternalServerError, `{"error": {"code": "InternalError"}}`, false, operationStatusFailed},
+	}
+
+	for _, tc := range cases {
+		resp := &http.Response{
+			StatusCode: tc.StatusCode,
+			Body:       ioutil.NopCloser(strings.NewReader(tc.Body)),
+		}
+
+		status, err := readOperationStatus(resp, tc.OperationResource)
+		if err != nil {
+			t.Fatalf("Error reading the status from %q: %s", tc.Body, err)
+		}
+		if status.state != tc.Expected {
+			t.Fatalf("Expected the status of %d %q to be %q but got %q", tc.StatusCode, tc.Body, tc.Expected, status.state)
+		}
+
+		// the body is left for the SDK to read
+		body, _ := ioutil.ReadAll(resp.Body)
+		if string(body) != tc.Body {
+			t.Fatalf("Expected the body %q to be left intact but got %q", tc.Body, body)
+		}
+	}
+}
+
+func TestOperationPoller_failed(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+	server.operationPolls = 2
+	server.handle("GET", "/fakeOperations/1", func(w http.ResponseWriter, r *http.Request) {
+		server.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status": "Failed",
+			"error":  map[string]interface{}{"code": "InternalServerError", "message": "Something went wrong"},
+		})
+	})
+
+	client := server.armClient(t).vnetClient()
+	_, errChan := client.Delete("example", "example", make(chan struct{}))
+	if err := <-errChan; err != nil {
+		t.Fatalf("Expected deleting a Virtual Network which doesn't exist to succeed but got: %s", err)
+	}
+
+	server.put(fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example", fakeArmSubscriptionID), map[string]interface{}{})
+	_, errChan = client.Delete("example", "example", make(chan struct{}))
+	err := <-errChan
+	if err == nil || !strings.Contains(err.Error(), "Something went wrong") {
+		t.Fatalf("Expected the failed operation to be returned as an error but got %v", err)
+	}
+	if count := server.requestCount("GET", "/fakeOperations/1"); count != 1 {
+		t.Fatalf("Expected the failed operation to be polled once but got %d", count)
+	}
+}
+
+func TestOperationPoller_withoutCancel(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Stopped bool
+	}{
+		{Name: "timeout"},
+		{Name: "stopped", Stopped: true},
+	}
+
+	for _, tc := range cases {
+		server := newFakeArmServer()
+		server.operationPolls = 1 << 30
+
+		client := server.armClient(t)
+		if tc.Stopped {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			client.StopContext = ctx
+		} else {
+			client.operations.timeout = 100 * time.Millisecond
+		}
+
+		// creating a Resource Group is synchronous, so the request has no cancel channel
+		location := "westeurope"
+		_, err := client.resourceGroupClient().CreateOrUpdate("example", resources.Group{Location: &location})
+		server.Close()
+		if err == nil || !strings.Contains(err.Error(), "cancel") {
+			t.Fatalf("%s: Expected the operation to be given up on but got %v", tc.Name, err)
+		}
+	}
+}
+
+func TestResourceAzureRMVirtualNetwork_fakeArmResume(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Polls      int
+		Failed     bool
+		InProgress bool
+		StatusCode int
+	}{
+		{Name: "completed", Polls: 1},
+		{Name: "failed", Polls: 1, Failed: true},
+		{Name: "in progress", Polls: 3, InProgress: true},
+		// the operation has expired, so the resource is read to find out whether it exists
+		{Name: "expired", Polls: 1, StatusCode: http.StatusNotFound},
+		// the status can't be read, which isn't a reason to remove the resource from the state
+		{Name: "forbidden", Polls: 1, StatusCode: http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		server := newFakeArmServer()
+		defer server.Close()
+
+		// the creation never completes, so it times out
+		server.operationPolls = 1 << 30
+
+		meta := server.armClient(t)
+		r := Provider().(*schema.Provider).ResourcesMap["azurerm_virtual_network"]
+		raw := fakeArmVirtualNetworkConfig()
+		raw["timeouts"] = []map[string]interface{}{{"create": "100ms"}}
+
+		state, err := createResource(t, r, raw, meta)
+		if err == nil {
+			t.Fatalf("%s: Expected an error once the creation timed out", tc.Name)
+		}
+
+		expected := server.URL + "/fakeOperations/1"
+		if state == nil || state.Attributes[operationURLKey] != expected {
+			t.Fatalf("%s: Expected the operation %q to be saved to the state but got %+v", tc.Name, expected, state)
+		}
+		if address := state.Attributes["address_space.0"]; address != "" {
+			t.Fatalf("%s: Expected only the operation to be saved to the state but got the address space %q", tc.Name, address)
+		}
+
+		// the next run checks the operation once, without waiting for it - which is replaced by
+		// another, so that polls of the operation still in flight when it timed out aren't counted
+		server.operationPolls = 0
+		server.lock.Lock()
+		server.operations[2] = tc.Polls
+		server.lock.Unlock()
+		expected = server.URL + "/fakeOperations/2"
+		state.Attributes[operationURLKey] = expected
+		if tc.Failed {
+			server.handle("GET", "/fakeOperations/2", func(w http.ResponseWriter, r *http.Request) {
+				server.writeJSON(w, http.StatusOK, map[string]interface{}{"status": "Failed"})
+			})
+		}
+		if tc.StatusCode != 0 {
+			server.handle("GET", "/fakeOperations/2", func(w http.ResponseWriter, r *http.Request) {
+				server.writeJSON(w, tc.StatusCode, fakeArmError(http.StatusText(tc.StatusCode), "The operation can't be read"))
+			})
+		}
+
+		refreshed, err := r.Refresh(state, meta)
+		if tc.StatusCode == http.StatusForbidden {
+			if err == nil {
+				t.Fatalf("%s: Expected an error refreshing the Virtual Network", tc.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: Error refreshing the Virtual Network: %s", tc.Name, err)
+		}
+		if count := server.requestCount("GET", "/fakeOperations/2"); count != 1 {
+			t.Fatalf("%s: Expected the operation to be checked once but got %d", tc.Name, count)
+		}
+
+		if tc.Failed {
+			if refreshed != nil {
+				t.Fatalf("%s: Expected the Virtual Network to be removed from the state so it's created again, but got %+v", tc.Name, refreshed)
+			}
+			continue
+		}
+
+		if refreshed == nil || refreshed.Attributes["address_space.0"] != "10.0.0.0/16" {
+			t.Fatalf("%s: Expected the Virtual Network to be read but got %+v", tc.Name, refreshed)
+		}
+		if !tc.InProgress {
+			if refreshed.Attributes[operationURLKey] != "" {
+				t.Fatalf("%s: Expected the completed operation to be removed from the state but got %q", tc.Name, refreshed.Attributes[operationURLKey])
+			}
+			continue
+		}
+		if refreshed.Attributes[operationURLKey] != expected {
+			t.Fatalf("%s: Expected the operation which is still running to remain in the state but got %+v", tc.Name, refreshed)
+		}
+
+		// updating the Virtual Network waits for the operation to complete first
+		raw["tags"] = map[string]interface{}{"environment": "example"}
+		raw["timeouts"] = []map[string]interface{}{}
+		updated, err := updateResource(t, r, refreshed, raw, meta)
+		if err != nil {
+			t.Fatalf("%s: Error updating the Virtual Network: %s", tc.Name, err)
+		}
+		if count := server.requestCount("GET", "/fakeOperations/2"); count != tc.Polls {
+			t.Fatalf("%s: Expected the operation to be resumed until it completed but got %d polls", tc.Name, count)
+		}
+		if updated == nil || updated.Attributes[operationURLKey] != "" || updated.Attributes["tags.environment"] != "example" {
+			t.Fatalf("%s: Expected the Virtual Network to be updated once the operation completed but got %+v", tc.Name, updated)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestArmClient_waitForStateStopped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &ArmClient{StopContext: ctx}
+
+	refreshes := make(chan struct{}, 100)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"Creating"},
+		Target:  []string{"Succeeded"},
+		Refresh: func() (interface{}, string, error) {
+			refreshes <- struct{}{}
+			return "example", "Creating", nil
+		},
+		Timeout:      time.Hour,
+		PollInterval: time.Millisecond,
+	}
+
+	go func() {
+		<-refreshes
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.waitForState(stateConf)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatalf("Expected an error once Terraform was stopping")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected waiting for the state to stop once Terraform was stopping")
+	}
+}
+
+func TestArmClient_stopChWithoutContext(t *testing.T) {
+	client := &ArmClient{}
+	if client.stopCh() != nil {
+		t.Fatalf("Expected an ArmClient without a StopContext never to be stopped")
+	}
+}
+
+func TestResourceAzureRMVirtualNetwork_fakeArmInterrupted(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	// the creation never completes, unless it's interrupted
+	server.operationPolls = 1 << 30
+
+	ctx, cancel := context.WithCancel(context.Background())
+	meta := server.armClient(t)
+	meta.StopContext = ctx
+
+	raw := fakeArmVirtualNetworkConfig()
+
+	go func() {
+		for server.requestCount("GET", "/fakeOperations/1") == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+
+	type result struct {
+		state *terraform.InstanceState
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		state, err := createResource(t, resourceArmVirtualNetwork(), raw, meta)
+		done <- result{state, err}
+	}()
+
+	var state *terraform.InstanceState
+	select {
+	case r := <-done:
+		state = r.state
+		if r.err == nil {
+			t.Fatalf("Expected an error once the creation was interrupted")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected the creation to stop once it was interrupted")
+	}
+
+	// the Virtual Network may have been created, so it's tracked in the state
+	expected := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example", fakeArmSubscriptionID)
+	if state == nil || state.ID != expected {
+		t.Fatalf("Expected the ID %q but got %+v", expected, state)
+	}
+}
+
+func TestResourceAzureRMVirtualNetwork_fakeArmTimeout(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	// the creation never completes, so it times out
+	server.operationPolls = 1 << 30
+
+	meta := server.armClient(t)
+	raw := fakeArmVirtualNetworkConfig()
+	raw["timeouts"] = []map[string]interface{}{{"create": "100ms"}}
+
+	start := time.Now()
+	state, err := createResource(t, Provider().(*schema.Provider).ResourcesMap["azurerm_virtual_network"], raw, meta)
+	if err == nil {
+		t.Fatalf("Expected an error once the creation timed out")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("Expected the creation to time out after 100ms but it took %s", elapsed)
+	}
+
+	expected := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example", fakeArmSubscriptionID)
+	if state == nil || state.ID != expected {
+		t.Fatalf("Expected the ID %q but got %+v", expected, state)
+	}
+}
+
+func TestProvider_timeouts(t *testing.T) {
+	p := Provider().(*schema.Provider)
+	for name, r := range p.ResourcesMap {
+		if r.Timeouts == nil || r.Timeouts.Create == nil || r.Timeouts.Read == nil || r.Timeouts.Delete == nil {
+			t.Fatalf("Expected %q to have timeouts for Create, Read and Delete", name)
+		}
+		if (r.Update != nil) != (r.Timeouts.Update != nil) {
+			t.Fatalf("Expected %q to have a timeout for Update only if it can be updated", name)
+		}
+	}
+}
@@ -6,17 +6,13 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log"
-	"reflect"
 	"strings"
-	"sync"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform/helper/mutexkv"
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
-	riviera "github.com/jen20/riviera/azure"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -26,25 +22,37 @@ func Provider() terraform.ResourceProvider {
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_SUBSCRIPTION_ID", ""),
 			},
 
 			"client_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_ID", ""),
 			},
 
 			"client_secret": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_SECRET", ""),
 			},
 
+			"client_certificate_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE_PATH", ""),
+			},
+
+			"client_certificate_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_CLIENT_CERTIFICATE_PASSWORD", ""),
+			},
+
 			"tenant_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_TENANT_ID", ""),
 			},
 
@@ -54,11 +62,120 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("ARM_ENVIRONMENT", "public"),
 			},
 
+			"metadata_url": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ARM_METADATA_URL", ""),
+				ConflictsWith: []string{"environment_file"},
+			},
+
+			"environment_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("ARM_ENVIRONMENT_FILE", ""),
+				ConflictsWith: []string{"metadata_url"},
+			},
+
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_SKIP_CREDENTIALS_VALIDATION", false),
+			},
+
 			"skip_provider_registration": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_SKIP_PROVIDER_REGISTRATION", false),
 			},
+
+			"resource_providers_to_register": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"additional_resource_providers_to_register"},
+			},
+
+			"additional_resource_providers_to_register": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"resource_providers_to_register"},
+			},
+
+			"resource_provider_registration_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "5m",
+				ValidateFunc: validateDuration,
+			},
+
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_MAX_RETRIES", 10),
+			},
+
+			"max_retry_duration": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_RETRY_DURATION", "15m"),
+				ValidateFunc: validateDuration,
+			},
+
+			"log_redacted_fields": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"default_tags": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:         schema.TypeMap,
+							Optional:     true,
+							ValidateFunc: validateAzureRMTags,
+						},
+					},
+				},
+			},
+
+			"ignore_tag_keys": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"ignore_tag_prefixes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"auxiliary_tenant_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: maxAuxiliaryTenants,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateUUID,
+				},
+			},
+
+			"use_msi": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_USE_MSI", false),
+			},
+
+			"msi_endpoint": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_MSI_ENDPOINT", ""),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -69,7 +186,6 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			// These resources use the Azure ARM SDK
 			"azurerm_application_insights": resourceArmApplicationInsights(),
 
 			"azurerm_automation_account":    resourceArmAutomationAccount(),
@@ -116,15 +232,20 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_network_security_rule":  resourceArmNetworkSecurityRule(),
 			"azurerm_public_ip":              resourceArmPublicIp(),
 
-			"azurerm_redis_cache": resourceArmRedisCache(),
-			"azurerm_route":       resourceArmRoute(),
-			"azurerm_route_table": resourceArmRouteTable(),
+			"azurerm_redis_cache":    resourceArmRedisCache(),
+			"azurerm_resource_group": resourceArmResourceGroup(),
+			"azurerm_route":          resourceArmRoute(),
+			"azurerm_route_table":    resourceArmRouteTable(),
 
+			"azurerm_search_service":          resourceArmSearchService(),
 			"azurerm_servicebus_namespace":    resourceArmServiceBusNamespace(),
 			"azurerm_servicebus_queue":        resourceArmServiceBusQueue(),
 			"azurerm_servicebus_subscription": resourceArmServiceBusSubscription(),
 			"azurerm_servicebus_topic":        resourceArmServiceBusTopic(),
+			"azurerm_sql_database":            resourceArmSqlDatabase(),
 			"azurerm_sql_elasticpool":         resourceArmSqlElasticPool(),
+			"azurerm_sql_firewall_rule":       resourceArmSqlFirewallRule(),
+			"azurerm_sql_server":              resourceArmSqlServer(),
 			"azurerm_storage_account":         resourceArmStorageAccount(),
 			"azurerm_storage_blob":            resourceArmStorageBlob(),
 			"azurerm_storage_container":       resourceArmStorageContainer(),
@@ -141,16 +262,62 @@ func Provider() terraform.ResourceProvider {
 			"azurerm_virtual_machine_scale_set": resourceArmVirtualMachineScaleSet(),
 			"azurerm_virtual_network":           resourceArmVirtualNetwork(),
 			"azurerm_virtual_network_peering":   resourceArmVirtualNetworkPeering(),
-
-			// These resources use the Riviera SDK
-			"azurerm_resource_group":    resourceArmResourceGroup(),
-			"azurerm_search_service":    resourceArmSearchService(),
-			"azurerm_sql_database":      resourceArmSqlDatabase(),
-			"azurerm_sql_firewall_rule": resourceArmSqlFirewallRule(),
-			"azurerm_sql_server":        resourceArmSqlServer(),
 		},
 	}
 
+	// the limit on the number of tags applies to the tags on the resource once the defaults
+	// have been merged in, the tags to ignore are configured on the provider and the valid
+	// locations depend on the subscription - all of which are only known once the provider
+	// has been configured
+	for name, r := range p.ResourcesMap {
+		if tags, ok := r.Schema["tags"]; ok && tags.ValidateFunc != nil {
+			tags.ValidateFunc = validateAzureRMTagsWithDefaults(p)
+
+			// the tags ignored by the provider are kept apart from the tags, and sent back
+			// when the resource is updated
+			r.Schema[ignoredTagsKey] = ignoredTagsSchema()
+		}
+
+		// locations are validated against those available to the subscription
+		if location, ok := r.Schema["location"]; ok && location.StateFunc != nil && location.Deprecated == "" {
+			configureLocationSchema(p, location, locationResourceTypes[name])
+		}
+
+		if r.Timeouts == nil {
+			r.Timeouts = defaultTimeouts(r)
+		}
+
+		if namespaces, ok := resourceProviderNamespaces[name]; ok {
+			r.Create = registerResourceProvidersBeforeCreate(r.Create, namespaces)
+		}
+
+		// an interrupted creation is checked the next time the resource is read, and resumed
+		// the next time it's updated
+		r.Schema[operationURLKey] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+		r.Read = checkOperationBeforeRead(r.Read)
+		r.Update = resumeOperationBeforeUpdate(r.Update)
+
+		// resources can be managed in a subscription other than the provider's
+		if _, ok := r.Schema[subscriptionIDKey]; !ok {
+			r.Schema[subscriptionIDKey] = subscriptionIDSchema()
+			r.Create = inResourceSubscription(r.Create)
+			r.Read = inResourceSubscription(r.Read)
+			r.Update = inResourceSubscription(r.Update)
+			r.Delete = inResourceSubscription(r.Delete)
+			r.Exists = existsInResourceSubscription(r.Exists)
+		}
+	}
+
+	for _, r := range p.DataSourcesMap {
+		if _, ok := r.Schema[subscriptionIDKey]; !ok {
+			r.Schema[subscriptionIDKey] = subscriptionIDForDataSourceSchema()
+			r.Read = inResourceSubscription(r.Read)
+		}
+	}
+
 	p.ConfigureFunc = providerConfigure(p)
 
 	return p
@@ -164,11 +331,59 @@ type Config struct {
 	SubscriptionID           string
 	ClientID                 string
 	ClientSecret             string
+	ClientCertPath           string
+	ClientCertPassword       string
 	TenantID                 string
 	Environment              string
 	SkipProviderRegistration bool
 
-	validateCredentialsOnce sync.Once
+	// When SkipCredentialsValidation is set no requests are made when the provider is
+	// configured, tokens are obtained when the first request which needs one is made.
+	SkipCredentialsValidation bool
+
+	// The Resource Providers required by each resource are registered before it's created,
+	// in addition to AdditionalResourceProvidersToRegister which are registered when the
+	// provider is configured. When ResourceProvidersToRegister is specified only those are
+	// registered, when the provider is configured.
+	ResourceProvidersToRegister           []string
+	AdditionalResourceProvidersToRegister []string
+	ResourceProviderRegistrationTimeout   time.Duration
+
+	// Custom Clouds (such as Azure Stack) are configured using either the Resource Manager
+	// endpoint, from which the metadata is discovered - or a local Environment file.
+	MetadataURL         string
+	EnvironmentFilePath string
+
+	// Requests which are throttled or fail with a transient error are retried up to
+	// MaxRetries times, providing the total time spent doesn't exceed MaxRetryDuration.
+	MaxRetries       int
+	MaxRetryDuration time.Duration
+
+	// Secrets are redacted from the requests and responses written to the debug log, the
+	// JSON fields in LogRedactedFields are redacted in addition to the built-in list.
+	LogRedactedFields []string
+
+	// DefaultTags are merged into the tags of every resource which supports them
+	DefaultTags map[string]string
+
+	// Changes to the tags matching IgnoreTagKeys or IgnoreTagPrefixes are ignored
+	IgnoreTagKeys     []string
+	IgnoreTagPrefixes []string
+
+	// Managed Service Identity authentication, available when running on an Azure VM
+	// with the MSI extension installed. When no endpoint is configured, it's discovered
+	// from the settings file written by the extension.
+	UseMsi      bool
+	MsiEndpoint string
+
+	// Tokens are obtained for the AuxiliaryTenantIDs using the same Service Principal, which
+	// are sent with requests to Resource Manager so that resources in those tenants can be
+	// referenced (for example when peering Virtual Networks across tenants).
+	AuxiliaryTenantIDs []string
+
+	// azureCliToken is populated from the Azure CLI's token cache when no other
+	// credentials have been configured
+	azureCliToken *azureCliToken
 }
 
 func (c *Config) validate() error {
@@ -177,15 +392,27 @@ func (c *Config) validate() error {
 	if c.SubscriptionID == "" {
 		err = multierror.Append(err, fmt.Errorf("Subscription ID must be configured for the AzureRM provider"))
 	}
-	if c.ClientID == "" {
-		err = multierror.Append(err, fmt.Errorf("Client ID must be configured for the AzureRM provider"))
-	}
-	if c.ClientSecret == "" {
-		err = multierror.Append(err, fmt.Errorf("Client Secret must be configured for the AzureRM provider"))
+	if c.UseMsi {
+		if c.ClientSecret != "" || c.ClientCertPath != "" {
+			err = multierror.Append(err, fmt.Errorf("Neither a Client Secret or a Client Certificate can be configured when using Managed Service Identity authentication"))
+		}
+	} else if c.azureCliToken == nil {
+		if c.ClientID == "" {
+			err = multierror.Append(err, fmt.Errorf("Client ID must be configured for the AzureRM provider"))
+		}
+		if c.ClientSecret == "" && c.ClientCertPath == "" {
+			err = multierror.Append(err, fmt.Errorf("Either a Client Secret or a Client Certificate Path must be configured for the AzureRM provider"))
+		}
+		if c.ClientSecret != "" && c.ClientCertPath != "" {
+			err = multierror.Append(err, fmt.Errorf("Only one of Client Secret or Client Certificate Path can be configured for the AzureRM provider"))
+		}
 	}
 	if c.TenantID == "" {
 		err = multierror.Append(err, fmt.Errorf("Tenant ID must be configured for the AzureRM provider"))
 	}
+	if len(c.AuxiliaryTenantIDs) > 0 && (c.UseMsi || c.azureCliToken != nil) {
+		err = multierror.Append(err, fmt.Errorf("Auxiliary Tenant IDs can only be configured when authenticating using a Service Principal"))
+	}
 	if c.Environment == "" {
 		err = multierror.Append(err, fmt.Errorf("Environment must be configured for the AzureRM provider"))
 	}
@@ -193,15 +420,79 @@ func (c *Config) validate() error {
 	return err.ErrorOrNil()
 }
 
+// shouldUseAzureCliCredentials returns whether the provider should fall back to the
+// credentials cached by the Azure CLI, which is the case when no other authentication
+// method has been configured. A Client ID without a secret or certificate is an incomplete
+// Service Principal, which is reported as such rather than being ignored.
+func (c *Config) shouldUseAzureCliCredentials() bool {
+	return !c.UseMsi && c.ClientID == "" && c.ClientSecret == "" && c.ClientCertPath == ""
+}
+
 func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 	return func(d *schema.ResourceData) (interface{}, error) {
 		config := &Config{
-			SubscriptionID:           d.Get("subscription_id").(string),
-			ClientID:                 d.Get("client_id").(string),
-			ClientSecret:             d.Get("client_secret").(string),
-			TenantID:                 d.Get("tenant_id").(string),
-			Environment:              d.Get("environment").(string),
-			SkipProviderRegistration: d.Get("skip_provider_registration").(bool),
+			SubscriptionID:            d.Get("subscription_id").(string),
+			ClientID:                  d.Get("client_id").(string),
+			ClientSecret:              d.Get("client_secret").(string),
+			ClientCertPath:            d.Get("client_certificate_path").(string),
+			ClientCertPassword:        d.Get("client_certificate_password").(string),
+			TenantID:                  d.Get("tenant_id").(string),
+			Environment:               d.Get("environment").(string),
+			MetadataURL:               d.Get("metadata_url").(string),
+			EnvironmentFilePath:       d.Get("environment_file").(string),
+			SkipProviderRegistration:  d.Get("skip_provider_registration").(bool),
+			SkipCredentialsValidation: d.Get("skip_credentials_validation").(bool),
+			UseMsi:                    d.Get("use_msi").(bool),
+			MsiEndpoint:               d.Get("msi_endpoint").(string),
+		}
+
+		maxRetryDuration, err := time.ParseDuration(d.Get("max_retry_duration").(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing `max_retry_duration`: %s", err)
+		}
+		config.MaxRetries = d.Get("max_retries").(int)
+		config.MaxRetryDuration = maxRetryDuration
+
+		registrationTimeout, err := time.ParseDuration(d.Get("resource_provider_registration_timeout").(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing `resource_provider_registration_timeout`: %s", err)
+		}
+		config.ResourceProviderRegistrationTimeout = registrationTimeout
+
+		for _, namespace := range d.Get("resource_providers_to_register").([]interface{}) {
+			config.ResourceProvidersToRegister = append(config.ResourceProvidersToRegister, namespace.(string))
+		}
+		for _, namespace := range d.Get("additional_resource_providers_to_register").([]interface{}) {
+			config.AdditionalResourceProvidersToRegister = append(config.AdditionalResourceProvidersToRegister, namespace.(string))
+		}
+
+		for _, tenantID := range d.Get("auxiliary_tenant_ids").([]interface{}) {
+			config.AuxiliaryTenantIDs = append(config.AuxiliaryTenantIDs, tenantID.(string))
+		}
+
+		for _, field := range d.Get("log_redacted_fields").([]interface{}) {
+			config.LogRedactedFields = append(config.LogRedactedFields, field.(string))
+		}
+
+		for _, key := range d.Get("ignore_tag_keys").([]interface{}) {
+			config.IgnoreTagKeys = append(config.IgnoreTagKeys, key.(string))
+		}
+		for _, prefix := range d.Get("ignore_tag_prefixes").([]interface{}) {
+			config.IgnoreTagPrefixes = append(config.IgnoreTagPrefixes, prefix.(string))
+		}
+
+		if v, ok := d.GetOk("default_tags"); ok && v.([]interface{})[0] != nil {
+			defaultTags := v.([]interface{})[0].(map[string]interface{})
+			config.DefaultTags = make(map[string]string)
+			for key, value := range defaultTags["tags"].(map[string]interface{}) {
+				config.DefaultTags[key], _ = tagValueToString(value)
+			}
+		}
+
+		if config.shouldUseAzureCliCredentials() {
+			if err := config.loadAzureCliCredentials(); err != nil {
+				return nil, fmt.Errorf("Error loading credentials from the Azure CLI: %s", err)
+			}
 		}
 
 		if err := config.validate(); err != nil {
@@ -217,13 +508,21 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 
 		// replaces the context between tests
 		p.MetaReset = func() error {
-			client.StopContext = p.StopContext()
+			client.setStopContext(p.StopContext())
 			return nil
 		}
 
+		if config.SkipCredentialsValidation {
+			// the locations are validated using the API, which would require the credentials
+			client.locations = nil
+
+			log.Printf("[DEBUG] Skipping the validation of the credentials, Resource Providers will be registered when first required")
+			return client, nil
+		}
+
 		// List all the available providers and their registration state to avoid unnecessary
 		// requests. This also lets us check if the provider credentials are correct.
-		providerList, err := client.providers.List(nil, "")
+		providerList, err := client.providers().List(nil, "")
 		if err != nil {
 			return nil, fmt.Errorf("Unable to list provider registration status, it is possible that this is due to invalid "+
 				"credentials or the service principal does not have permission to use the Resource Manager API, Azure "+
@@ -231,8 +530,9 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 		}
 
 		if !config.SkipProviderRegistration {
-			err = registerAzureResourceProvidersWithSubscription(*providerList.Value, client.providers)
-			if err != nil {
+			client.resourceProviders.registered(*providerList.Value)
+
+			if err := client.resourceProviders.register(); err != nil {
 				return nil, err
 			}
 		}
@@ -241,103 +541,9 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 	}
 }
 
-func registerProviderWithSubscription(providerName string, client resources.ProvidersClient) error {
-	_, err := client.Register(providerName)
-	if err != nil {
-		return fmt.Errorf("Cannot register provider %s with Azure Resource Manager: %s.", providerName, err)
-	}
-
-	return nil
-}
-
-var providerRegistrationOnce sync.Once
-
-// registerAzureResourceProvidersWithSubscription uses the providers client to register
-// all Azure resource providers which the Terraform provider may require (regardless of
-// whether they are actually used by the configuration or not). It was confirmed by Microsoft
-// that this is the approach their own internal tools also take.
-func registerAzureResourceProvidersWithSubscription(providerList []resources.Provider, client resources.ProvidersClient) error {
-	var err error
-	providerRegistrationOnce.Do(func() {
-		providers := map[string]struct{}{
-			"Microsoft.Cache":             struct{}{},
-			"Microsoft.Cdn":               struct{}{},
-			"Microsoft.Compute":           struct{}{},
-			"Microsoft.ContainerRegistry": struct{}{},
-			"Microsoft.ContainerService":  struct{}{},
-			"Microsoft.DocumentDB":        struct{}{},
-			"Microsoft.EventHub":          struct{}{},
-			"Microsoft.KeyVault":          struct{}{},
-			"Microsoft.Insights":          struct{}{},
-			"Microsoft.Network":           struct{}{},
-			"Microsoft.Resources":         struct{}{},
-			"Microsoft.Search":            struct{}{},
-			"Microsoft.ServiceBus":        struct{}{},
-			"Microsoft.Sql":               struct{}{},
-			"Microsoft.Storage":           struct{}{},
-		}
-
-		// filter out any providers already registered
-		for _, p := range providerList {
-			if _, ok := providers[*p.Namespace]; !ok {
-				continue
-			}
-
-			if strings.ToLower(*p.RegistrationState) == "registered" {
-				log.Printf("[DEBUG] Skipping provider registration for namespace %s\n", *p.Namespace)
-				delete(providers, *p.Namespace)
-			}
-		}
-
-		var wg sync.WaitGroup
-		wg.Add(len(providers))
-		for providerName := range providers {
-			go func(p string) {
-				defer wg.Done()
-				log.Printf("[DEBUG] Registering provider with namespace %s\n", p)
-				if innerErr := registerProviderWithSubscription(p, client); err != nil {
-					err = innerErr
-				}
-			}(providerName)
-		}
-		wg.Wait()
-	})
-
-	return err
-}
-
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = mutexkv.NewMutexKV()
 
-func azureStateRefreshFunc(resourceURI string, client *ArmClient, command riviera.APICall) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		req := client.rivieraClient.NewRequestForURI(resourceURI)
-		req.Command = command
-
-		res, err := req.Execute()
-		if err != nil {
-			return nil, "", fmt.Errorf("Error executing %T command in azureStateRefreshFunc", req.Command)
-		}
-
-		var value reflect.Value
-		if reflect.ValueOf(res.Parsed).Kind() == reflect.Ptr {
-			value = reflect.ValueOf(res.Parsed).Elem()
-		} else {
-			value = reflect.ValueOf(res.Parsed)
-		}
-
-		for i := 0; i < value.NumField(); i++ { // iterates through every struct type field
-			tag := value.Type().Field(i).Tag // returns the tag string
-			tagValue := tag.Get("mapstructure")
-			if tagValue == "provisioningState" {
-				return res.Parsed, value.Field(i).Elem().String(), nil
-			}
-		}
-
-		panic(fmt.Errorf("azureStateRefreshFunc called on structure %T with no mapstructure:provisioningState tag. This is a bug", res.Parsed))
-	}
-}
-
 // Resource group names can be capitalised, but we store them in lowercase.
 // Use a custom diff function to avoid creation of new resources.
 func resourceAzurermResourceGroupNameDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
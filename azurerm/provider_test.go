@@ -2,22 +2,64 @@ package azurerm
 
 import (
 	"fmt"
+	"math/rand"
+	"net/http"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
+// Placeholders for the identifiers used in the acceptance test recordings, which are also
+// used as the credentials when replaying them.
+const (
+	testRecordingSubscriptionID = "00000000-0000-0000-0000-000000000000"
+	testRecordingTenantID       = "00000000-0000-0000-0000-000000000001"
+	testRecordingClientID       = "00000000-0000-0000-0000-000000000002"
+)
+
 var testAccProviders map[string]terraform.ResourceProvider
 var testAccProvider *schema.Provider
 
+// testRecorder records the requests made by the clients configured during the current
+// acceptance test to (or replays them from) its cassette, when `ARM_TEST_RECORDING_MODE` is
+// set to either `record` or `replay`. Each test has its own recorder (see testStartRecording).
+var testRecorder *recorder
+var testRecorderLock sync.Mutex
+
+var testRandSourcesLock sync.Mutex
+var testRandSources = make(map[string]*rand.Rand)
+
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
 	testAccProviders = map[string]terraform.ResourceProvider{
 		"azurerm": testAccProvider,
 	}
+
+	if mode := os.Getenv("ARM_TEST_RECORDING_MODE"); mode != "" {
+		directory := os.Getenv("ARM_TEST_RECORDINGS_PATH")
+		if directory == "" {
+			directory = filepath.Join("testdata", "recordings")
+		}
+
+		// until a test starts there's no cassette, so requests fail rather than reaching Azure
+		r, err := newRecorder(mode, directory, "", http.DefaultTransport)
+		if err != nil {
+			panic(fmt.Sprintf("Error configuring the acceptance test recorder: %s", err))
+		}
+		testRecorder = r
+		armTestTransport = func() http.RoundTripper {
+			testRecorderLock.Lock()
+			defer testRecorderLock.Unlock()
+			return testRecorder
+		}
+	}
 }
 
 func TestProvider(t *testing.T) {
@@ -31,6 +73,15 @@ func TestProvider_impl(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
+	recording := testStartRecording(t)
+	if recording != nil && testRecorder.mode == recordingModeReplay {
+		for variable, recorded := range recording.Variables {
+			if value := os.Getenv(variable); value != recorded {
+				t.Fatalf("`%s` must be set to %q (rather than %q) to replay this test", variable, recorded, value)
+			}
+		}
+	}
+
 	variables := []string{
 		"ARM_SUBSCRIPTION_ID",
 		"ARM_CLIENT_ID",
@@ -48,6 +99,107 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
+// testStartRecording creates the recorder (when enabled) for the test, which is used by the
+// clients configured from then on. When replaying, the placeholders are used as the
+// credentials and the locations the test was recorded in are used, unless they've been set.
+func testStartRecording(t *testing.T) *cassette {
+	testRecorderLock.Lock()
+	defer testRecorderLock.Unlock()
+
+	if testRecorder == nil {
+		return nil
+	}
+	if testRecorder.name == t.Name() {
+		return testRecorder.cassette
+	}
+
+	placeholders := map[string]string{
+		os.Getenv("ARM_SUBSCRIPTION_ID"): testRecordingSubscriptionID,
+		os.Getenv("ARM_TENANT_ID"):       testRecordingTenantID,
+		os.Getenv("ARM_CLIENT_ID"):       testRecordingClientID,
+	}
+	variables := map[string]string{
+		"ARM_TEST_LOCATION":     testLocation(),
+		"ARM_TEST_LOCATION_ALT": testAltLocation(),
+	}
+
+	r, err := newRecorder(testRecorder.mode, testRecorder.directory, t.Name(), http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("Error creating the recorder: %s", err)
+	}
+	recording, err := r.start(placeholders, variables)
+	if err != nil {
+		t.Fatalf("Error starting the recording: %s", err)
+	}
+	testRecorder = r
+
+	if testRecorder.mode == recordingModeReplay {
+		os.Setenv("ARM_SUBSCRIPTION_ID", testRecordingSubscriptionID)
+		os.Setenv("ARM_TENANT_ID", testRecordingTenantID)
+		os.Setenv("ARM_CLIENT_ID", testRecordingClientID)
+		os.Setenv("ARM_CLIENT_SECRET", recordedSecretValue)
+
+		for variable, value := range recording.Variables {
+			if os.Getenv(variable) == "" {
+				os.Setenv(variable, value)
+			}
+		}
+	}
+
+	return recording
+}
+
+// testReplaying returns whether the requests of the test are being replayed, in which case
+// there's no need to wait for anything to happen in Azure.
+func testReplaying() bool {
+	testRecorderLock.Lock()
+	defer testRecorderLock.Unlock()
+	return testRecorder != nil && testRecorder.mode == recordingModeReplay
+}
+
+// testRandSource returns the source of random numbers for the test when it's being recorded
+// or replayed, which is seeded from the cassette so the same names are used in both.
+func testRandSource(t *testing.T) *rand.Rand {
+	recording := testStartRecording(t)
+	if recording == nil {
+		return nil
+	}
+
+	testRandSourcesLock.Lock()
+	defer testRandSourcesLock.Unlock()
+
+	source, ok := testRandSources[t.Name()]
+	if !ok {
+		source = rand.New(rand.NewSource(recording.Seed))
+		testRandSources[t.Name()] = source
+	}
+	return source
+}
+
+func testRandInt(t *testing.T) int {
+	if source := testRandSource(t); source != nil {
+		return source.Int()
+	}
+	return acctest.RandInt()
+}
+
+func testRandString(t *testing.T, length int) string {
+	return testRandStringFromCharSet(t, length, acctest.CharSetAlphaNum)
+}
+
+func testRandStringFromCharSet(t *testing.T, length int, charSet string) string {
+	source := testRandSource(t)
+	if source == nil {
+		return acctest.RandStringFromCharSet(length, charSet)
+	}
+
+	result := make([]byte, length)
+	for i := range result {
+		result[i] = charSet[source.Intn(len(charSet))]
+	}
+	return string(result)
+}
+
 func testLocation() string {
 	return os.Getenv("ARM_TEST_LOCATION")
 }
@@ -62,16 +214,190 @@ func testArmEnvironment() (*azure.Environment, error) {
 		envName = "public"
 	}
 
-	// detect cloud from environment
-	env, envErr := azure.EnvironmentFromName(envName)
-	if envErr != nil {
-		// try again with wrapped value to support readable values like german instead of AZUREGERMANCLOUD
-		wrapped := fmt.Sprintf("AZURE%sCLOUD", envName)
-		var innerErr error
-		if env, innerErr = azure.EnvironmentFromName(wrapped); innerErr != nil {
-			return nil, envErr
+	env, err := environmentFromName(envName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &env.Environment, nil
+}
+
+func TestProvider_skipCredentialsValidation(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	server.handle("GET", "/providers/Microsoft.Foo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"namespace":"Microsoft.Foo","registrationState":"Registered"}`)
+	})
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"subscription_id":             fakeArmSubscriptionID,
+		"client_id":                   fakeArmClientID,
+		"client_secret":               "fake-secret",
+		"tenant_id":                   fakeArmTenantID,
+		"environment":                 "public",
+		"metadata_url":                server.URL,
+		"skip_credentials_validation": true,
+	})
+	if err != nil {
+		t.Fatalf("Error building the provider config: %s", err)
+	}
+
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+		t.Fatalf("Error configuring the provider: %s", err)
+	}
+
+	// only the metadata of the custom cloud is retrieved when the provider is configured
+	if count := server.requestCount("POST", "/oauth2/token"); count != 0 {
+		t.Fatalf("Expected no tokens to be requested when configuring the provider but %d were", count)
+	}
+	if count := server.requestCount("GET", "/providers"); count != 0 {
+		t.Fatalf("Expected the Resource Providers not to be listed when configuring the provider but they were listed %d times", count)
+	}
+
+	client := p.Meta().(*ArmClient)
+	for i := 0; i < 2; i++ {
+		if _, err := client.providers().Get("Microsoft.Foo", ""); err != nil {
+			t.Fatalf("Error retrieving the Resource Provider: %s", err)
+		}
+	}
+
+	// the token is obtained when it's first needed, and then reused
+	if count := server.requestCount("POST", "/oauth2/token"); count != 1 {
+		t.Fatalf("Expected a token to be requested once it was needed but %d were", count)
+	}
+}
+
+func TestProvider_auxiliaryTenants(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	var auxiliaryHeader string
+	server.handle("GET", "/virtualNetworkPeerings/example", func(w http.ResponseWriter, r *http.Request) {
+		auxiliaryHeader = r.Header.Get(auxiliaryAuthorizationHeader)
+		server.writeJSON(w, http.StatusOK, map[string]interface{}{"name": "example"})
+	})
+
+	auxiliaryTenantIDs := []interface{}{
+		"00000000-0000-0000-0000-000000000003",
+		"00000000-0000-0000-0000-000000000004",
+	}
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"subscription_id":             fakeArmSubscriptionID,
+		"client_id":                   fakeArmClientID,
+		"client_secret":               "fake-secret",
+		"tenant_id":                   fakeArmTenantID,
+		"environment":                 "public",
+		"metadata_url":                server.URL,
+		"skip_credentials_validation": true,
+		"auxiliary_tenant_ids":        auxiliaryTenantIDs,
+	})
+	if err != nil {
+		t.Fatalf("Error building the provider config: %s", err)
+	}
+
+	p := Provider().(*schema.Provider)
+	if err := p.Configure(terraform.NewResourceConfig(raw)); err != nil {
+		t.Fatalf("Error configuring the provider: %s", err)
+	}
+
+	client := p.Meta().(*ArmClient)
+	if _, err := client.vnetPeeringsClient().Get("example", "example", "example"); err != nil {
+		t.Fatalf("Error retrieving the Virtual Network Peering: %s", err)
+	}
+	if expected := "Bearer fake-token, Bearer fake-token"; auxiliaryHeader != expected {
+		t.Fatalf("Expected the tokens for the auxiliary tenants %q to be sent but got %q", expected, auxiliaryHeader)
+	}
+	for _, tenantID := range append([]interface{}{fakeArmTenantID}, auxiliaryTenantIDs...) {
+		if count := server.requestCount("POST", fmt.Sprintf("/%s/oauth2/token", tenantID)); count != 1 {
+			t.Fatalf("Expected a token to be requested for the tenant %q once but %d were", tenantID, count)
+		}
+	}
+}
+
+func TestConfig_validateAuxiliaryTenants(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Config      Config
+		ExpectError bool
+	}{
+		{
+			Name: "Service Principal",
+			Config: Config{
+				ClientID:     fakeArmClientID,
+				ClientSecret: "fake-secret",
+			},
+			ExpectError: false,
+		},
+		{
+			Name: "Managed Service Identity",
+			Config: Config{
+				UseMsi: true,
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "Azure CLI",
+			Config: Config{
+				azureCliToken: &azureCliToken{},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc.Config.SubscriptionID = fakeArmSubscriptionID
+		tc.Config.TenantID = fakeArmTenantID
+		tc.Config.Environment = "public"
+		tc.Config.AuxiliaryTenantIDs = []string{"00000000-0000-0000-0000-000000000003"}
+
+		err := tc.Config.validate()
+		if tc.ExpectError && err == nil {
+			t.Fatalf("%s: Expected auxiliary tenants to be rejected", tc.Name)
+		}
+		if !tc.ExpectError && err != nil {
+			t.Fatalf("%s: Expected auxiliary tenants to be allowed but got: %s", tc.Name, err)
 		}
 	}
+}
+
+func TestConfig_shouldUseAzureCliCredentials(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   Config
+		Expected bool
+	}{
+		{
+			Name:     "nothing configured",
+			Config:   Config{},
+			Expected: true,
+		},
+		{
+			Name:     "Client ID without a secret",
+			Config:   Config{ClientID: fakeArmClientID},
+			Expected: false,
+		},
+		{
+			Name:     "Client Secret",
+			Config:   Config{ClientSecret: "fake-secret"},
+			Expected: false,
+		},
+		{
+			Name:     "Client Certificate",
+			Config:   Config{ClientCertPath: "example.pfx"},
+			Expected: false,
+		},
+		{
+			Name:     "Managed Service Identity",
+			Config:   Config{UseMsi: true},
+			Expected: false,
+		},
+	}
 
-	return &env, nil
+	for _, tc := range cases {
+		if actual := tc.Config.shouldUseAzureCliCredentials(); actual != tc.Expected {
+			t.Fatalf("%s: Expected %t but got %t", tc.Name, tc.Expected, actual)
+		}
+	}
 }
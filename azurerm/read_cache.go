@@ -0,0 +1,242 @@
+package azurerm
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// readCache caches the resources which are read by many other resources during a run - such
+// as the Load Balancer read by each of its Rules, or the keys of a Storage Account read by each
+// of its Containers - so that they're read once rather than once per resource.
+//
+// Entries are keyed by the (case-insensitive) ID of the resource they're read from, and are
+// removed whenever a request which modifies that resource - or one of its parents or children,
+// such as a Subnet of a Virtual Network - is sent (see withReadCacheInvalidation).
+type readCache struct {
+	lock    sync.Mutex
+	entries map[string]*readCacheEntry
+}
+
+type readCacheEntry struct {
+	ready chan struct{}
+	value interface{}
+	err   error
+
+	// references are the IDs of the resources referenced by the response of the read
+	references []string
+}
+
+func newReadCache() *readCache {
+	return &readCache{
+		entries: make(map[string]*readCacheEntry),
+	}
+}
+
+// get returns the cached read of the resource with the ID, calling read if it isn't cached.
+// Concurrent calls for the same resource wait for the first of them to read it; errors are
+// returned to each of them, but aren't cached.
+func (c *readCache) get(id string, read func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return read()
+	}
+
+	key := readCacheKey(id)
+	c.lock.Lock()
+	entry, cached := c.entries[key]
+	if !cached {
+		entry = &readCacheEntry{ready: make(chan struct{})}
+		c.entries[key] = entry
+	}
+	c.lock.Unlock()
+
+	if cached {
+		<-entry.ready
+		log.Printf("[DEBUG] Using the cached read of %q", id)
+		return entry.value, entry.err
+	}
+
+	log.Printf("[DEBUG] Reading %q, which is cached until it's modified", id)
+	entry.value, entry.err = read()
+	close(entry.ready)
+
+	if entry.err != nil {
+		c.lock.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.lock.Unlock()
+	}
+
+	return entry.value, entry.err
+}
+
+// invalidate removes the cached reads of the resources with the IDs, along with those of their
+// parents and children - since modifying a resource can also modify its parent.
+func (c *readCache) invalidate(ids ...string) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+
+		key := readCacheKey(id)
+		for cached := range c.entries {
+			if cached == key || strings.HasPrefix(cached, key+"/") || strings.HasPrefix(key, cached+"/") {
+				log.Printf("[DEBUG] Removing the cached read of %q since %q is being modified", cached, id)
+				delete(c.entries, cached)
+			}
+		}
+	}
+}
+
+// invalidateReferencing removes the cached reads which reference the resource with the ID (or
+// one of its children) - such as a Virtual Network, whose Subnets reference the IP
+// Configurations of each Network Interface within them.
+func (c *readCache) invalidateReferencing(id string) {
+	if c == nil || id == "" {
+		return
+	}
+
+	key := readCacheKey(id)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for cached, entry := range c.entries {
+		for _, reference := range entry.references {
+			if reference == key || strings.HasPrefix(reference, key+"/") {
+				log.Printf("[DEBUG] Removing the cached read of %q since it references %q, which is being modified", cached, id)
+				delete(c.entries, cached)
+				break
+			}
+		}
+	}
+}
+
+// setReferences records the resources referenced by the read of the resource with the ID (its
+// response body), if the read is being cached.
+func (c *readCache) setReferences(id string, body []byte) {
+	if c == nil {
+		return
+	}
+
+	key := readCacheKey(id)
+	c.lock.Lock()
+	_, cached := c.entries[key]
+	c.lock.Unlock()
+	if !cached {
+		return
+	}
+
+	references := make([]string, 0)
+	for _, reference := range referencedResourceIDs(body) {
+		references = append(references, readCacheKey(reference))
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		entry.references = references
+	}
+}
+
+func readCacheKey(id string) string {
+	return strings.ToLower(strings.Trim(id, "/"))
+}
+
+// withReadCacheInvalidation returns a SendDecorator which removes the cached reads of the
+// resource each request which modifies a resource is sent to. This happens both before the
+// request is sent and once it (along with any long running operation it starts) completes,
+// so that reads made while the resource's being modified aren't cached either.
+//
+// Resources also reference each other, and Resource Manager keeps both sides of the reference
+// up to date - for example writing a Network Interface changes the Subnets and Load Balancer
+// Backend Address Pools it's in. So the cached reads of the resources referenced by the body
+// of the request are also removed, as are those whose response referenced the modified
+// resource (such as the Subnets of a Network Interface which is deleted).
+func withReadCacheInvalidation(cache *readCache) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			id, modifies := modifiedResourceID(r)
+			if !modifies {
+				resp, err := s.Do(r)
+				if err == nil && resp != nil && r.Method == http.MethodGet {
+					body, _ := readAndRestoreBody(&resp.Body)
+					cache.setReferences(r.URL.Path, body)
+				}
+				return resp, err
+			}
+
+			body, _ := readAndRestoreBody(&r.Body)
+			ids := append([]string{id}, referencedResourceIDs(body)...)
+			cache.invalidate(ids...)
+			cache.invalidateReferencing(id)
+			resp, err := s.Do(r)
+			cache.invalidate(ids...)
+			cache.invalidateReferencing(id)
+			return resp, err
+		})
+	}
+}
+
+// referencedResourceIDs returns the IDs of the resources referenced by the body of a request or
+// response - which are the `id` of any object within it.
+func referencedResourceIDs(body []byte) []string {
+	var value interface{}
+	if len(body) == 0 || json.Unmarshal(body, &value) != nil {
+		return nil
+	}
+
+	ids := make([]string, 0)
+	var find func(value interface{})
+	find = func(value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for key, child := range v {
+				if id, ok := child.(string); ok && strings.EqualFold(key, "id") && strings.HasPrefix(strings.ToLower(id), "/subscriptions/") {
+					ids = append(ids, id)
+				}
+				find(child)
+			}
+		case []interface{}:
+			for _, child := range v {
+				find(child)
+			}
+		}
+	}
+	find(value)
+
+	return ids
+}
+
+// modifiedResourceID returns the ID of the resource modified by the request, if it modifies
+// one. Actions (such as a `POST` to `regenerateKey`) modify the resource they're made on,
+// with the exception of those which list something (such as `listKeys`).
+func modifiedResourceID(r *http.Request) (string, bool) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return "", false
+	case http.MethodPost:
+		if strings.HasPrefix(strings.ToLower(segments[len(segments)-1]), "list") {
+			return "", false
+		}
+	}
+
+	// Resource IDs have an even number of segments, the paths of actions an odd number
+	if len(segments)%2 == 1 {
+		segments = segments[:len(segments)-1]
+	}
+
+	return "/" + strings.Join(segments, "/"), true
+}
@@ -0,0 +1,401 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/arm/storage"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestReadCache_get(t *testing.T) {
+	cache := newReadCache()
+	id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/loadBalancers/example"
+
+	var reads int32
+	read := func() (interface{}, error) {
+		atomic.AddInt32(&reads, 1)
+		time.Sleep(50 * time.Millisecond)
+		return "example", nil
+	}
+
+	// concurrent reads of the same resource wait for the first
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, err := cache.get(id, read); err != nil || value != "example" {
+				t.Errorf("Expected the cached value but got %v (%v)", value, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := cache.get(strings.ToUpper(id), read); err != nil {
+		t.Fatalf("Error reading the cached value: %s", err)
+	}
+	if reads != 1 {
+		t.Fatalf("Expected the resource to be read once but got %d", reads)
+	}
+
+	// errors aren't cached
+	failed := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/loadBalancers/failed"
+	if _, err := cache.get(failed, func() (interface{}, error) { return nil, fmt.Errorf("throttled") }); err == nil {
+		t.Fatalf("Expected the error to be returned")
+	}
+	if value, err := cache.get(failed, read); err != nil || value != "example" {
+		t.Fatalf("Expected the resource to be read again after an error but got %v (%v)", value, err)
+	}
+}
+
+func TestReadCache_invalidate(t *testing.T) {
+	vnetId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example"
+	accountId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+
+	cases := []struct {
+		Name     string
+		ID       string
+		Expected []string
+	}{
+		{
+			Name:     "unrelated",
+			ID:       vnetId + "2",
+			Expected: []string{accountId + "/listKeys", vnetId},
+		},
+		{
+			Name:     "resource",
+			ID:       vnetId,
+			Expected: []string{accountId + "/listKeys"},
+		},
+		{
+			Name:     "child",
+			ID:       vnetId + "/subnets/example",
+			Expected: []string{accountId + "/listKeys"},
+		},
+		{
+			Name:     "parent",
+			ID:       accountId,
+			Expected: []string{vnetId},
+		},
+		{
+			Name:     "resource group",
+			ID:       "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/EXAMPLE",
+			Expected: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		cache := newReadCache()
+		for _, id := range []string{vnetId, accountId + "/listKeys"} {
+			cache.get(id, func() (interface{}, error) { return id, nil })
+		}
+
+		cache.invalidate(tc.ID)
+
+		cached := make([]string, 0)
+		for key := range cache.entries {
+			cached = append(cached, key)
+		}
+		sort.Strings(cached)
+
+		expected := make([]string, 0)
+		for _, id := range tc.Expected {
+			expected = append(expected, readCacheKey(id))
+		}
+		sort.Strings(expected)
+
+		if fmt.Sprintf("%v", cached) != fmt.Sprintf("%v", expected) {
+			t.Fatalf("%s: Expected %v to remain cached but got %v", tc.Name, expected, cached)
+		}
+	}
+}
+
+func TestModifiedResourceID(t *testing.T) {
+	accountId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+
+	cases := []struct {
+		Method   string
+		Path     string
+		Expected string
+		Modifies bool
+	}{
+		{"GET", accountId, "", false},
+		{"HEAD", accountId, "", false},
+		{"POST", accountId + "/listKeys", "", false},
+		{"POST", accountId + "/regenerateKey", accountId, true},
+		{"PUT", accountId, accountId, true},
+		{"PATCH", accountId + "/", accountId, true},
+		{"DELETE", accountId, accountId, true},
+	}
+
+	for _, tc := range cases {
+		r := &http.Request{Method: tc.Method, URL: &url.URL{Path: tc.Path}}
+		id, modifies := modifiedResourceID(r)
+		if id != tc.Expected || modifies != tc.Modifies {
+			t.Fatalf("Expected %s %s to modify %q (%t) but got %q (%t)", tc.Method, tc.Path, tc.Expected, tc.Modifies, id, modifies)
+		}
+	}
+}
+
+func TestResourceAzureRMLoadBalancerRule_fakeArmReadCache(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	loadBalancerID := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/loadBalancers/example", fakeArmSubscriptionID)
+	rules := make([]interface{}, 0)
+	for i := 0; i < 5; i++ {
+		rules = append(rules, map[string]interface{}{
+			"name": fmt.Sprintf("rule%d", i),
+			"properties": map[string]interface{}{
+				"protocol":     "Tcp",
+				"frontendPort": 8000 + i,
+				"backendPort":  8000 + i,
+			},
+		})
+	}
+	server.put(loadBalancerID, map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"loadBalancingRules": rules,
+		},
+	})
+
+	meta := server.armClient(t)
+	r := resourceArmLoadBalancerRule()
+	refresh := func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				state := &terraform.InstanceState{
+					ID: fmt.Sprintf("%s/loadBalancingRules/rule%d", loadBalancerID, i),
+					Attributes: map[string]string{
+						"name":            fmt.Sprintf("rule%d", i),
+						"loadbalancer_id": loadBalancerID,
+					},
+				}
+				refreshed, err := r.Refresh(state, meta)
+				if err != nil || refreshed == nil {
+					t.Errorf("Error refreshing the Load Balancer Rule %q: %v", state.ID, err)
+				}
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	refresh()
+	refresh()
+	if count := server.requestCount("GET", "/loadBalancers/example"); count != 1 {
+		t.Fatalf("Expected the Load Balancer to be read once but got %d", count)
+	}
+
+	// once the Load Balancer's modified, it's read again
+	if err := destroyResource(r, &terraform.InstanceState{
+		ID: loadBalancerID + "/loadBalancingRules/rule0",
+		Attributes: map[string]string{
+			"name":            "rule0",
+			"loadbalancer_id": loadBalancerID,
+		},
+	}, meta); err != nil {
+		t.Fatalf("Error deleting the Load Balancer Rule: %s", err)
+	}
+
+	before := server.requestCount("GET", "/loadBalancers/example")
+	state, err := r.Refresh(&terraform.InstanceState{
+		ID: loadBalancerID + "/loadBalancingRules/rule0",
+		Attributes: map[string]string{
+			"name":            "rule0",
+			"loadbalancer_id": loadBalancerID,
+		},
+	}, meta)
+	if err != nil {
+		t.Fatalf("Error refreshing the Load Balancer Rule: %s", err)
+	}
+	if state != nil {
+		t.Fatalf("Expected the deleted Load Balancer Rule to be removed from the state")
+	}
+	if count := server.requestCount("GET", "/loadBalancers/example"); count != before+1 {
+		t.Fatalf("Expected the modified Load Balancer to be read again")
+	}
+}
+
+func TestArmClient_fakeArmStorageAccountKeyCache(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	server.handle("POST", "/listKeys", func(w http.ResponseWriter, req *http.Request) {
+		server.writeJSON(w, http.StatusOK, map[string]interface{}{
+			"keys": []interface{}{
+				map[string]interface{}{"keyName": "key1", "value": "fake-key"},
+			},
+		})
+	})
+
+	meta := server.armClient(t)
+	for i := 0; i < 3; i++ {
+		key, exists, err := meta.getKeyForStorageAccount("example", "example")
+		if err != nil || !exists || key != "fake-key" {
+			t.Fatalf("Expected the key of the Storage Account but got %q (%t, %v)", key, exists, err)
+		}
+	}
+	if count := server.requestCount("POST", "/listKeys"); count != 1 {
+		t.Fatalf("Expected the keys to be listed once but got %d", count)
+	}
+
+	// regenerating the keys removes them from the cache
+	keyName := "key1"
+	if _, err := meta.storageServiceClient().RegenerateKey("example", "example", storage.AccountRegenerateKeyParameters{KeyName: &keyName}); err != nil {
+		t.Fatalf("Error regenerating the key: %s", err)
+	}
+	if _, _, err := meta.getKeyForStorageAccount("example", "example"); err != nil {
+		t.Fatalf("Error getting the key: %s", err)
+	}
+	if count := server.requestCount("POST", "/listKeys"); count != 2 {
+		t.Fatalf("Expected the keys to be listed again once they're regenerated but got %d", count)
+	}
+}
+
+func TestReferencedResourceIDs(t *testing.T) {
+	subnetId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example/subnets/example"
+
+	cases := []struct {
+		Body     string
+		Expected []string
+	}{
+		{"", nil},
+		{"not json", nil},
+		{`{"name":"example","properties":{"id":"example"}}`, []string{}},
+		{`{"properties":{"ipConfigurations":[{"properties":{"subnet":{"id":"` + subnetId + `"}}}]}}`, []string{subnetId}},
+	}
+
+	for _, tc := range cases {
+		ids := referencedResourceIDs([]byte(tc.Body))
+		if fmt.Sprintf("%v", ids) != fmt.Sprintf("%v", tc.Expected) {
+			t.Fatalf("Expected %q to reference %v but got %v", tc.Body, tc.Expected, ids)
+		}
+	}
+}
+
+func TestResourceAzureRMNetworkInterface_fakeArmReadCache(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	vnetId := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example", fakeArmSubscriptionID)
+	server.put(vnetId, map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"subnets": []interface{}{
+				map[string]interface{}{
+					"id":         vnetId + "/subnets/example",
+					"name":       "example",
+					"properties": map[string]interface{}{"addressPrefix": "10.0.0.0/24"},
+				},
+			},
+		},
+	})
+	loadBalancerID := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/loadBalancers/example", fakeArmSubscriptionID)
+	server.put(loadBalancerID, map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"backendAddressPools": []interface{}{
+				map[string]interface{}{"name": "example", "properties": map[string]interface{}{}},
+			},
+		},
+	})
+
+	meta := server.armClient(t)
+	subnet := resourceArmSubnet()
+	pool := resourceArmLoadBalancerBackendAddressPool()
+
+	// the Subnet and Backend Address Pool are refreshed from the cache, unless the Network
+	// Interface referencing them has been written since they were last read
+	refresh := func(reads int) {
+		if _, err := subnet.Refresh(&terraform.InstanceState{
+			ID: vnetId + "/subnets/example",
+			Attributes: map[string]string{
+				"name":                 "example",
+				"resource_group_name":  "example",
+				"virtual_network_name": "example",
+			},
+		}, meta); err != nil {
+			t.Fatalf("Error refreshing the Subnet: %s", err)
+		}
+		if _, err := pool.Refresh(&terraform.InstanceState{
+			ID: loadBalancerID + "/backendAddressPools/example",
+			Attributes: map[string]string{
+				"name":            "example",
+				"loadbalancer_id": loadBalancerID,
+			},
+		}, meta); err != nil {
+			t.Fatalf("Error refreshing the Backend Address Pool: %s", err)
+		}
+
+		if count := server.requestCount("GET", "/virtualNetworks/example"); count != reads {
+			t.Fatalf("Expected the Virtual Network to be read %d times but got %d", reads, count)
+		}
+		if count := server.requestCount("GET", "/loadBalancers/example"); count != reads {
+			t.Fatalf("Expected the Load Balancer to be read %d times but got %d", reads, count)
+		}
+	}
+
+	refresh(1)
+	refresh(1)
+
+	iface := resourceArmNetworkInterface()
+	state, err := createResource(t, iface, map[string]interface{}{
+		"name":                "example",
+		"resource_group_name": "example",
+		"location":            "westeurope",
+		"ip_configuration": []interface{}{
+			map[string]interface{}{
+				"name":                          "example",
+				"subnet_id":                     vnetId + "/subnets/example",
+				"private_ip_address_allocation": "static",
+				"private_ip_address":            "10.0.0.4",
+				"load_balancer_backend_address_pools_ids": []interface{}{loadBalancerID + "/backendAddressPools/example"},
+			},
+		},
+	}, meta)
+	if err != nil {
+		t.Fatalf("Error creating the Network Interface: %s", err)
+	}
+
+	// Resource Manager adds the IP Configuration to the Subnet and Backend Address Pool, so
+	// they're read again once the Network Interface is deleted
+	ipConfigurations := []interface{}{map[string]interface{}{"id": state.ID + "/ipConfigurations/example"}}
+	server.put(vnetId, map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"subnets": []interface{}{
+				map[string]interface{}{
+					"id":         vnetId + "/subnets/example",
+					"name":       "example",
+					"properties": map[string]interface{}{"addressPrefix": "10.0.0.0/24", "ipConfigurations": ipConfigurations},
+				},
+			},
+		},
+	})
+	server.put(loadBalancerID, map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"backendAddressPools": []interface{}{
+				map[string]interface{}{"name": "example", "properties": map[string]interface{}{"backendIPConfigurations": ipConfigurations}},
+			},
+		},
+	})
+	refresh(2)
+
+	if err := destroyResource(iface, state, meta); err != nil {
+		t.Fatalf("Error deleting the Network Interface: %s", err)
+	}
+	refresh(3)
+}
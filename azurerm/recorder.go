@@ -0,0 +1,346 @@
+package azurerm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	recordingModeRecord = "record"
+	recordingModeReplay = "replay"
+
+	// secrets within cassettes are replaced with a valid base64 value, since some (such as
+	// the Storage Account Keys) are parsed by the SDK when they're replayed
+	recordedSecretValue = "cmVkYWN0ZWQ="
+)
+
+// armTestTransport, when set, returns the transport used by each ArmClient to send requests
+// to Azure in place of the default. It's used by the acceptance tests to record the requests
+// made by the clients configured during each test to (and replay them from) its cassette.
+var armTestTransport func() http.RoundTripper
+
+// cassette contains the requests sent during an acceptance test and the responses received,
+// along with the seed used to generate the random names used in the test.
+type cassette struct {
+	Seed         int64                 `json:"seed"`
+	Variables    map[string]string     `json:"variables,omitempty"`
+	Interactions []cassetteInteraction `json:"interactions"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// recorder is a http.RoundTripper which either records the requests it sends to the cassette
+// of a test, or replays the responses from it without making any requests. Cassettes are
+// sanitized as they're recorded - removing secrets and replacing identifiers (such as the
+// Subscription ID) with placeholders, which are used in place of credentials when replaying.
+//
+// Each test has its own recorder, which is used by the clients configured during the test -
+// so that requests still being made by the clients of a previous test aren't recorded to (or
+// replayed from) the cassette of the next.
+type recorder struct {
+	mode      string
+	directory string
+	name      string
+	transport http.RoundTripper
+	redactor  *logRedactor
+
+	lock         sync.Mutex
+	cassette     *cassette
+	replacements map[string]*regexp.Regexp
+
+	// replayed is the number of times each request has been replayed, since identical
+	// requests (such as when polling) are replayed in the order they were recorded
+	replayed map[string]int
+}
+
+func newRecorder(mode, directory, name string, transport http.RoundTripper) (*recorder, error) {
+	if mode != recordingModeRecord && mode != recordingModeReplay {
+		return nil, fmt.Errorf("The recording mode must be either %q or %q but got %q", recordingModeRecord, recordingModeReplay, mode)
+	}
+
+	redactor := newLogRedactor(nil)
+	redactor.maxBodySize = 0
+	redactor.replacement = recordedSecretValue
+	redactor.preserveTypes = true
+
+	return &recorder{
+		mode:      mode,
+		directory: directory,
+		name:      name,
+		transport: transport,
+		redactor:  redactor,
+	}, nil
+}
+
+// start opens the cassette for the test - which when recording is created with a new seed,
+// and when replaying must already exist. The placeholders map values (such as the
+// Subscription ID) which should be replaced within the cassette to their placeholders.
+func (r *recorder) start(placeholders map[string]string, variables map[string]string) (*cassette, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.replayed = make(map[string]int)
+	r.replacements = make(map[string]*regexp.Regexp)
+	for value, placeholder := range placeholders {
+		if value != "" && value != placeholder {
+			r.replacements[placeholder] = regexp.MustCompile("(?i)" + regexp.QuoteMeta(value))
+		}
+	}
+
+	if r.mode == recordingModeRecord {
+		r.cassette = &cassette{
+			Seed:      time.Now().UnixNano(),
+			Variables: variables,
+		}
+		return r.cassette, r.save()
+	}
+
+	data, err := ioutil.ReadFile(r.path())
+	if err != nil {
+		return nil, fmt.Errorf("Error reading the cassette for %q: %s", r.name, err)
+	}
+
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("Error parsing the cassette %q: %s", r.path(), err)
+	}
+	r.cassette = &c
+	return r.cassette, nil
+}
+
+func (r *recorder) path() string {
+	name := strings.Map(func(c rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, c) {
+			return '_'
+		}
+		return c
+	}, r.name)
+	return filepath.Join(r.directory, name+".json")
+}
+
+// save writes the cassette to disk; it's called after each interaction is recorded so that
+// the cassette is complete even if the test panics.
+func (r *recorder) save() error {
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error serializing the cassette for %q: %s", r.name, err)
+	}
+
+	if err := os.MkdirAll(r.directory, 0755); err != nil {
+		return fmt.Errorf("Error creating the cassette directory %q: %s", r.directory, err)
+	}
+
+	if err := ioutil.WriteFile(r.path(), data, 0644); err != nil {
+		return fmt.Errorf("Error writing the cassette %q: %s", r.path(), err)
+	}
+
+	return nil
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isTokenRequest(req) {
+		// tokens (and the credentials used to obtain them) are never recorded
+		if r.mode == recordingModeReplay {
+			return replayedTokenResponse(req), nil
+		}
+		return r.transport.RoundTrip(req)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.cassette == nil {
+		return nil, fmt.Errorf("No cassette has been started for the request to %s", req.URL)
+	}
+
+	if r.mode == recordingModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+func (r *recorder) record(req *http.Request) (*http.Response, error) {
+	requestBody, err := readAndRestoreBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	responseBody, err := readAndRestoreBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(http.Header)
+	for key, values := range r.redactor.redactHeaders(resp.Header) {
+		for _, value := range values {
+			headers.Add(key, r.sanitize(value))
+		}
+	}
+
+	interaction := cassetteInteraction{
+		Request: cassetteRequest{
+			Method: req.Method,
+			URL:    r.sanitizedURL(req),
+			Body:   r.sanitizeBody(requestBody),
+		},
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    headers,
+			Body:       r.sanitizeBody(responseBody),
+		},
+	}
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+
+	if err := r.save(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
+
+	return resp, nil
+}
+
+// replay returns the response recorded for the request with the same method, URL and body -
+// ignoring any timestamps within the body, since they're usually derived from the time the
+// test is run. When no request with the same body was recorded, the responses recorded for
+// the method and URL are replayed in order instead.
+func (r *recorder) replay(req *http.Request) (*http.Response, error) {
+	requestBody, err := readAndRestoreBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	url := r.sanitizedURL(req)
+	body := withoutTimestamps(r.sanitizeBody(requestBody))
+	key := fmt.Sprintf("%s %s %s", req.Method, url, body)
+
+	var matches, sameURL []cassetteInteraction
+	for _, interaction := range r.cassette.Interactions {
+		request := interaction.Request
+		if request.Method != req.Method || request.URL != url {
+			continue
+		}
+		sameURL = append(sameURL, interaction)
+		if withoutTimestamps(request.Body) == body {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 && len(sameURL) > 0 {
+		log.Printf("[DEBUG] No interaction was recorded for %s %s with the same body, replaying them in order", req.Method, url)
+		matches = sameURL
+		key = fmt.Sprintf("%s %s", req.Method, url)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("No interaction was recorded for %s %s in %q", req.Method, url, r.path())
+	}
+
+	// once the recorded responses are exhausted the last is repeated
+	index := r.replayed[key]
+	if index >= len(matches) {
+		index = len(matches) - 1
+	}
+	r.replayed[key]++
+
+	recorded := matches[index].Response
+	headers := make(http.Header)
+	for key, values := range recorded.Headers {
+		headers[key] = values
+	}
+
+	// there's no need to wait between polls of long running operations when replaying
+	headers.Set("Retry-After", "0")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// sanitize replaces any identifiers within the value with their placeholders.
+func (r *recorder) sanitize(value string) string {
+	for placeholder, expression := range r.replacements {
+		value = expression.ReplaceAllLiteralString(value, placeholder)
+	}
+	return value
+}
+
+func (r *recorder) sanitizedURL(req *http.Request) string {
+	return r.sanitize(r.redactor.redactURL(req.URL).String())
+}
+
+func (r *recorder) sanitizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	return r.sanitize(string(r.redactor.redactBody(body)))
+}
+
+var recordedTimestamp = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})?`)
+
+// withoutTimestamps replaces the timestamps within the body of a request with a placeholder.
+func withoutTimestamps(body string) string {
+	return recordedTimestamp.ReplaceAllLiteralString(body, "{timestamp}")
+}
+
+// isTokenRequest returns whether the request is for an Azure Active Directory token.
+func isTokenRequest(req *http.Request) bool {
+	return strings.HasSuffix(req.URL.Path, "/oauth2/token") || strings.HasSuffix(req.URL.Path, "/oauth2/v2.0/token")
+}
+
+// replayedTokenResponse returns a token which is valid for an hour, so that no credentials
+// are required when replaying.
+func replayedTokenResponse(req *http.Request) *http.Response {
+	expiresOn := time.Now().Add(time.Hour).Unix()
+	body := fmt.Sprintf(`{"access_token":%q,"token_type":"Bearer","expires_in":"3600","expires_on":"%d","not_before":"%d","resource":%q}`,
+		recordedSecretValue, expiresOn, time.Now().Unix(), req.FormValue("resource"))
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,219 @@
+package azurerm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_recordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "azurerm-recordings")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	subscriptionId := "11111111-2222-3333-4444-555555555555"
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/tenant/oauth2/token":
+			fmt.Fprint(w, `{"access_token":"real-token","token_type":"Bearer"}`)
+		case r.URL.Path == "/operations/1":
+			polls++
+			if polls < 3 {
+				w.Header().Set("Retry-After", "10")
+				fmt.Fprint(w, `{"status":"InProgress"}`)
+				return
+			}
+			fmt.Fprint(w, `{"status":"Succeeded"}`)
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/listKeys"):
+			fmt.Fprint(w, `{"keys":[{"keyName":"key1","value":"c3RvcmFnZWtleQ==","permissions":"Full"}]}`)
+		default:
+			w.Header().Set("Azure-AsyncOperation", fmt.Sprintf("http://%s/operations/1", r.Host))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id":"/subscriptions/%s/resourceGroups/example","properties":{"administratorLoginPassword":"Password1234!"}}`, subscriptionId)
+		}
+	}))
+	defer server.Close()
+
+	placeholders := map[string]string{subscriptionId: testRecordingSubscriptionID}
+	variables := map[string]string{"ARM_TEST_LOCATION": "westeurope"}
+	resourceURL := fmt.Sprintf("%s/subscriptions/%s/resourceGroups/example?api-version=2017-05-10", server.URL, subscriptionId)
+	keysURL := fmt.Sprintf("%s/subscriptions/%s/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys", server.URL, subscriptionId)
+
+	record, err := newRecorder(recordingModeRecord, dir, "TestExample", http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("Error creating the recorder: %s", err)
+	}
+	recorded, err := record.start(placeholders, variables)
+	if err != nil {
+		t.Fatalf("Error starting the recording: %s", err)
+	}
+
+	client := http.Client{Transport: record}
+	sendRecorderRequests(t, &client, server.URL, resourceURL, keysURL)
+
+	data, err := ioutil.ReadFile(record.path())
+	if err != nil {
+		t.Fatalf("Error reading the cassette: %s", err)
+	}
+	for _, secret := range []string{subscriptionId, "real-token", "Password1234!", "c3RvcmFnZWtleQ=="} {
+		if strings.Contains(string(data), secret) {
+			t.Fatalf("Expected %q to be sanitized from the cassette but got:\n%s", secret, data)
+		}
+	}
+
+	// the server is no longer required to replay the requests
+	server.Close()
+
+	replay, err := newRecorder(recordingModeReplay, dir, "TestExample", nil)
+	if err != nil {
+		t.Fatalf("Error creating the recorder: %s", err)
+	}
+	replayed, err := replay.start(nil, nil)
+	if err != nil {
+		t.Fatalf("Error starting the replay: %s", err)
+	}
+	if replayed.Seed != recorded.Seed || replayed.Variables["ARM_TEST_LOCATION"] != "westeurope" {
+		t.Fatalf("Expected the seed and variables to be replayed but got %d and %+v", replayed.Seed, replayed.Variables)
+	}
+
+	client = http.Client{Transport: replay}
+	resourceURL = strings.Replace(resourceURL, subscriptionId, testRecordingSubscriptionID, 1)
+	keysURL = strings.Replace(keysURL, subscriptionId, testRecordingSubscriptionID, 1)
+	sendRecorderRequests(t, &client, server.URL, resourceURL, keysURL)
+
+	resp, err := client.Get(server.URL + "/not-recorded")
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("Expected an error for a request which wasn't recorded")
+	}
+}
+
+// sendRecorderRequests creates a resource, polls the operation until it completes and then
+// lists the Storage Account Keys - checking the responses are the same when replayed.
+func sendRecorderRequests(t *testing.T, client *http.Client, serverURL, resourceURL, keysURL string) {
+	resp, err := client.PostForm(serverURL+"/tenant/oauth2/token", url.Values{"resource": []string{"https://management.azure.com/"}})
+	if err != nil {
+		t.Fatalf("Error retrieving a token: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected a token to be issued but got %d", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest("PUT", resourceURL, strings.NewReader(`{"location":"westeurope"}`))
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Error creating the resource: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Expected the resource to be created but got %d", resp.StatusCode)
+	}
+
+	pollingURL := resp.Header.Get("Azure-AsyncOperation")
+	for attempt := 1; ; attempt++ {
+		resp, err = client.Get(pollingURL)
+		if err != nil {
+			t.Fatalf("Error polling %q: %s", pollingURL, err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if strings.Contains(string(body), "Succeeded") {
+			if attempt != 3 {
+				t.Fatalf("Expected the operation to complete after 3 polls but it took %d", attempt)
+			}
+			break
+		}
+		if attempt >= 3 {
+			t.Fatalf("Expected the operation to complete after 3 polls but got %q", string(body))
+		}
+	}
+
+	resp, err = client.Post(keysURL, "application/json", nil)
+	if err != nil {
+		t.Fatalf("Error listing the keys: %s", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), `"keyName"`) {
+		t.Fatalf("Expected the structure of the keys to be preserved but got %q", string(body))
+	}
+}
+
+func TestRecorder_replayMatchesBody(t *testing.T) {
+	dir, err := ioutil.TempDir("", "azurerm-recordings")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the response echoes the request, so each is only replayed for the same body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	bodies := []string{`{"name":"first"}`, `{"name":"second"}`, `{"name":"third","startTime":"2017-11-01T10:00:00.123Z"}`}
+	send := func(transport http.RoundTripper, body string) string {
+		client := http.Client{Transport: transport}
+		resp, err := client.Post(server.URL+"/example", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("Error sending %q: %s", body, err)
+		}
+		defer resp.Body.Close()
+		response, _ := ioutil.ReadAll(resp.Body)
+		return string(response)
+	}
+
+	record, err := newRecorder(recordingModeRecord, dir, "TestExample", http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("Error creating the recorder: %s", err)
+	}
+	if _, err := record.start(nil, nil); err != nil {
+		t.Fatalf("Error starting the recording: %s", err)
+	}
+	for _, body := range bodies {
+		send(record, body)
+	}
+
+	replay, err := newRecorder(recordingModeReplay, dir, "TestExample", nil)
+	if err != nil {
+		t.Fatalf("Error creating the recorder: %s", err)
+	}
+	if _, err := replay.start(nil, nil); err != nil {
+		t.Fatalf("Error starting the replay: %s", err)
+	}
+	for i := len(bodies) - 1; i >= 0; i-- {
+		if response := send(replay, bodies[i]); response != bodies[i] {
+			t.Fatalf("Expected the response recorded for %q but got %q", bodies[i], response)
+		}
+	}
+
+	// timestamps (which are usually derived from the current time) are ignored
+	if response := send(replay, `{"name":"third","startTime":"2019-02-03T04:05:06+01:00"}`); response != bodies[2] {
+		t.Fatalf("Expected the response recorded for %q but got %q", bodies[2], response)
+	}
+
+	// otherwise the requests for the same URL are replayed in order
+	for _, expected := range bodies {
+		if response := send(replay, `{"name":"fourth"}`); response != expected {
+			t.Fatalf("Expected the response recorded for %q but got %q", expected, response)
+		}
+	}
+}
+
+func TestRecorder_invalidMode(t *testing.T) {
+	if _, err := newRecorder("live", "", "TestExample", nil); err == nil {
+		t.Fatalf("Expected an error for an invalid recording mode")
+	}
+}
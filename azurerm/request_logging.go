@@ -0,0 +1,298 @@
+package azurerm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/logging"
+)
+
+const (
+	redactedValue = "[REDACTED]"
+
+	// bodies larger than this (such as Page Blob uploads) are truncated in the logs
+	maxLoggedBodySize = 16 * 1024
+)
+
+// defaultRedactedHeaders are the HTTP headers whose values are never logged.
+var defaultRedactedHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"x-ms-authorization-auxiliary",
+}
+
+// defaultRedactedFields are the JSON fields, in either requests or responses, whose values
+// are secrets and so are never logged. Fields are matched regardless of casing.
+var defaultRedactedFields = []string{
+	// Credentials
+	"access_token",
+	"accessToken",
+	"adminPassword",
+	"administratorLoginPassword",
+	"client_secret",
+	"password",
+	"refresh_token",
+	"secret",
+
+	// Access Keys (e.g. Storage Accounts, Redis, Event Hubs, Service Bus and Cosmos DB)
+	"keys",
+	"primaryKey",
+	"secondaryKey",
+	"primaryConnectionString",
+	"secondaryConnectionString",
+	"primaryMasterKey",
+	"secondaryMasterKey",
+	"primaryReadonlyMasterKey",
+	"secondaryReadonlyMasterKey",
+
+	// Shared Keys for Virtual Network Gateway Connections and ExpressRoute Circuits
+	"authorizationKey",
+	"sharedKey",
+
+	// Virtual Machine settings which commonly contain secrets
+	"customData",
+	"protectedSettings",
+}
+
+// defaultRedactedQueryParameters are query string parameters whose values are never logged,
+// such as the signature of a SAS Token.
+var defaultRedactedQueryParameters = []string{
+	"sig",
+}
+
+// logRedactor removes secrets from the HTTP requests and responses written to the debug log.
+type logRedactor struct {
+	headers         map[string]struct{}
+	fields          map[string]struct{}
+	queryParameters map[string]struct{}
+	maxBodySize     int
+
+	// replacement is the value secrets are replaced with, when preserveTypes is set only
+	// the strings within a secret field are replaced so that the body can still be parsed
+	replacement   string
+	preserveTypes bool
+}
+
+// newLogRedactor returns a logRedactor which redacts the default headers and fields, in
+// addition to any extra JSON fields specified.
+func newLogRedactor(extraFields []string) *logRedactor {
+	redactor := logRedactor{
+		headers:         make(map[string]struct{}),
+		fields:          make(map[string]struct{}),
+		queryParameters: make(map[string]struct{}),
+		maxBodySize:     maxLoggedBodySize,
+		replacement:     redactedValue,
+	}
+
+	for _, header := range defaultRedactedHeaders {
+		redactor.headers[http.CanonicalHeaderKey(header)] = struct{}{}
+	}
+	for _, field := range defaultRedactedFields {
+		redactor.fields[strings.ToLower(field)] = struct{}{}
+	}
+	for _, field := range extraFields {
+		redactor.fields[strings.ToLower(field)] = struct{}{}
+	}
+	for _, parameter := range defaultRedactedQueryParameters {
+		redactor.queryParameters[strings.ToLower(parameter)] = struct{}{}
+	}
+
+	return &redactor
+}
+
+// withRequestLogging returns a SendDecorator which writes each request and response to the
+// debug log, with any secrets redacted.
+func withRequestLogging(redactor *logRedactor) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			// there's no need to buffer the bodies when they won't be logged
+			if !logging.IsDebugOrHigher() {
+				return s.Do(r)
+			}
+
+			// dump request to wire format
+			if dump, err := redactor.dumpRequest(r); err == nil {
+				log.Printf("[DEBUG] AzureRM Request: \n%s\n", dump)
+			} else {
+				// fallback to basic message
+				log.Printf("[DEBUG] AzureRM Request: %s to %s\n", r.Method, redactor.redactURL(r.URL))
+			}
+
+			resp, err := s.Do(r)
+			if resp != nil {
+				// dump response to wire format
+				if dump, err := redactor.dumpResponse(resp); err == nil {
+					log.Printf("[DEBUG] AzureRM Response for %s: \n%s\n", redactor.redactURL(r.URL), dump)
+				} else {
+					// fallback to basic message
+					log.Printf("[DEBUG] AzureRM Response: %s for %s\n", resp.Status, redactor.redactURL(r.URL))
+				}
+			} else {
+				log.Printf("[DEBUG] Request to %s completed with no response", redactor.redactURL(r.URL))
+			}
+			return resp, err
+		})
+	}
+}
+
+// dumpRequest returns the wire format of the request with any secrets redacted, leaving
+// the original request intact.
+func (lr *logRedactor) dumpRequest(r *http.Request) ([]byte, error) {
+	body, err := readAndRestoreBody(&r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := *r
+	redacted.URL = lr.redactURL(r.URL)
+	redacted.Header = lr.redactHeaders(r.Header)
+	if body != nil {
+		redactedBody := lr.redactBody(body)
+		redacted.Body = ioutil.NopCloser(bytes.NewReader(redactedBody))
+		redacted.ContentLength = int64(len(redactedBody))
+	}
+
+	return httputil.DumpRequestOut(&redacted, true)
+}
+
+// dumpResponse returns the wire format of the response with any secrets redacted, leaving
+// the original response intact.
+func (lr *logRedactor) dumpResponse(resp *http.Response) ([]byte, error) {
+	body, err := readAndRestoreBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	redacted := *resp
+	redacted.Header = lr.redactHeaders(resp.Header)
+	if body != nil {
+		redactedBody := lr.redactBody(body)
+		redacted.Body = ioutil.NopCloser(bytes.NewReader(redactedBody))
+		redacted.ContentLength = int64(len(redactedBody))
+	}
+
+	return httputil.DumpResponse(&redacted, true)
+}
+
+// readAndRestoreBody reads the entire body, replacing it with an unread copy.
+func readAndRestoreBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	*body = ioutil.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+// redactURL returns a copy of the URL with the values of any secret query parameters removed.
+func (lr *logRedactor) redactURL(u *url.URL) *url.URL {
+	if u == nil || u.RawQuery == "" {
+		return u
+	}
+
+	query := u.Query()
+	changed := false
+	for key := range query {
+		if _, ok := lr.queryParameters[strings.ToLower(key)]; ok {
+			query.Set(key, lr.replacement)
+			changed = true
+		}
+	}
+	if !changed {
+		return u
+	}
+
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+	return &redacted
+}
+
+func (lr *logRedactor) redactHeaders(headers http.Header) http.Header {
+	redacted := make(http.Header, len(headers))
+	for key, values := range headers {
+		if _, ok := lr.headers[http.CanonicalHeaderKey(key)]; ok {
+			redacted[key] = []string{lr.replacement}
+			continue
+		}
+		redacted[key] = values
+	}
+	return redacted
+}
+
+// redactBody removes the values of any secret fields from a JSON body and truncates large
+// bodies. Bodies which aren't JSON are only truncated.
+func (lr *logRedactor) redactBody(body []byte) []byte {
+	// numbers are kept as-is, rather than being parsed as floats
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err == nil && !decoder.More() {
+		if redacted, err := json.Marshal(lr.redactValue(parsed)); err == nil {
+			body = redacted
+		}
+	}
+
+	if lr.maxBodySize > 0 && len(body) > lr.maxBodySize {
+		truncated := append([]byte{}, body[:lr.maxBodySize]...)
+		return append(truncated, []byte(fmt.Sprintf("\n[%d bytes truncated]", len(body)-lr.maxBodySize))...)
+	}
+
+	return body
+}
+
+func (lr *logRedactor) redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, inner := range value {
+			if _, ok := lr.fields[strings.ToLower(key)]; ok {
+				value[key] = lr.redactSecret(inner)
+				continue
+			}
+			value[key] = lr.redactValue(inner)
+		}
+		return value
+	case []interface{}:
+		for i, inner := range value {
+			value[i] = lr.redactValue(inner)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+func (lr *logRedactor) redactSecret(v interface{}) interface{} {
+	if !lr.preserveTypes {
+		return lr.replacement
+	}
+
+	switch value := v.(type) {
+	case string:
+		return lr.replacement
+	case map[string]interface{}:
+		for key, inner := range value {
+			value[key] = lr.redactSecret(inner)
+		}
+		return value
+	case []interface{}:
+		for i, inner := range value {
+			value[i] = lr.redactSecret(inner)
+		}
+		return value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,138 @@
+package azurerm
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLogRedactor_dumpRequest(t *testing.T) {
+	body := `{"location":"westeurope","properties":{"osProfile":{"adminUsername":"testadmin","adminPassword":"Password1234!","customData":"c2VjcmV0"},"extensions":[{"protectedSettings":{"commandToExecute":"echo hunter2"}}]}}`
+	req, err := http.NewRequest("PUT", "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Compute/virtualMachines/example?api-version=2017-03-30", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Error building request: %s", err)
+	}
+	req.Header.Set("Authorization", "Bearer eyJ0eXAiOiJKV1Qi")
+	req.Header.Set("x-ms-authorization-auxiliary", "Bearer eyJhbGciOiJSUzI1NiJ9")
+	req.Header.Set("Content-Type", "application/json")
+
+	dump, err := newLogRedactor(nil).dumpRequest(req)
+	if err != nil {
+		t.Fatalf("Unexpected error dumping the request: %s", err)
+	}
+
+	for _, secret := range []string{"eyJ0eXAiOiJKV1Qi", "eyJhbGciOiJSUzI1NiJ9", "Password1234!", "c2VjcmV0", "hunter2"} {
+		if strings.Contains(string(dump), secret) {
+			t.Fatalf("Expected %q to be redacted but got:\n%s", secret, dump)
+		}
+	}
+	for _, expected := range []string{"application/json", "testadmin", "westeurope", redactedValue} {
+		if !strings.Contains(string(dump), expected) {
+			t.Fatalf("Expected %q to be logged but got:\n%s", expected, dump)
+		}
+	}
+
+	// the request sent to the API must be unchanged
+	if req.Header.Get("Authorization") != "Bearer eyJ0eXAiOiJKV1Qi" {
+		t.Fatalf("Expected the Authorization header to be left intact but got %q", req.Header.Get("Authorization"))
+	}
+	sent, _ := ioutil.ReadAll(req.Body)
+	if string(sent) != body {
+		t.Fatalf("Expected the request body to be left intact but got %q", string(sent))
+	}
+}
+
+func TestLogRedactor_dumpResponse(t *testing.T) {
+	body := `{"keys":[{"keyName":"key1","value":"c3RvcmFnZWtleQ==","permissions":"Full"}]}`
+	resp := &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Set-Cookie": []string{"session=abc123"}},
+		Body:          ioutil.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+
+	dump, err := newLogRedactor(nil).dumpResponse(resp)
+	if err != nil {
+		t.Fatalf("Unexpected error dumping the response: %s", err)
+	}
+
+	for _, secret := range []string{"c3RvcmFnZWtleQ==", "abc123"} {
+		if strings.Contains(string(dump), secret) {
+			t.Fatalf("Expected %q to be redacted but got:\n%s", secret, dump)
+		}
+	}
+
+	received, _ := ioutil.ReadAll(resp.Body)
+	if string(received) != body {
+		t.Fatalf("Expected the response body to be left intact but got %q", string(received))
+	}
+}
+
+func TestLogRedactor_redactBody(t *testing.T) {
+	testCases := []struct {
+		name        string
+		extraFields []string
+		input       string
+		expected    string
+	}{
+		{
+			name:     "nested and case-insensitive",
+			input:    `{"properties":{"AdministratorLoginPassword":"secret","administratorLogin":"admin"}}`,
+			expected: `{"properties":{"AdministratorLoginPassword":"[REDACTED]","administratorLogin":"admin"}}`,
+		},
+		{
+			name:     "within arrays",
+			input:    `[{"primaryKey":"abc","keyName":"RootManageSharedAccessKey"}]`,
+			expected: `[{"keyName":"RootManageSharedAccessKey","primaryKey":"[REDACTED]"}]`,
+		},
+		{
+			name:        "additional fields",
+			extraFields: []string{"value"},
+			input:       `{"name":"example","value":"abc"}`,
+			expected:    `{"name":"example","value":"[REDACTED]"}`,
+		},
+		{
+			name:     "not json",
+			input:    `<?xml version="1.0"?><password>abc</password>`,
+			expected: `<?xml version="1.0"?><password>abc</password>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		actual := string(newLogRedactor(tc.extraFields).redactBody([]byte(tc.input)))
+		if actual != tc.expected {
+			t.Fatalf("[%s] Expected %q but got %q", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestLogRedactor_truncatesLargeBodies(t *testing.T) {
+	redactor := newLogRedactor(nil)
+	redactor.maxBodySize = 10
+
+	actual := string(redactor.redactBody([]byte(strings.Repeat("a", 25))))
+	expected := strings.Repeat("a", 10) + "\n[15 bytes truncated]"
+	if actual != expected {
+		t.Fatalf("Expected %q but got %q", expected, actual)
+	}
+}
+
+func TestLogRedactor_redactURL(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://example.blob.core.windows.net/vhds/example.vhd?sv=2016-05-31&sr=b&sig=c2lnbmF0dXJl&se=2017-12-01", nil)
+
+	redacted := newLogRedactor(nil).redactURL(req.URL)
+	if strings.Contains(redacted.String(), "c2lnbmF0dXJl") {
+		t.Fatalf("Expected the SAS signature to be redacted but got %q", redacted)
+	}
+	if redacted.Query().Get("sv") != "2016-05-31" {
+		t.Fatalf("Expected the other query parameters to be logged but got %q", redacted)
+	}
+	if req.URL.Query().Get("sig") != "c2lnbmF0dXJl" {
+		t.Fatalf("Expected the request URL to be left intact but got %q", req.URL)
+	}
+}
@@ -62,7 +62,7 @@ func resourceArmApplicationInsights() *schema.Resource {
 }
 
 func resourceArmApplicationInsightsCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).appInsightsClient
+	client := meta.(*ArmClient).appInsightsClient()
 
 	log.Printf("[INFO] preparing arguments for AzureRM Application Insights creation.")
 
@@ -70,7 +70,6 @@ func resourceArmApplicationInsightsCreateOrUpdate(d *schema.ResourceData, meta i
 	resGroup := d.Get("resource_group_name").(string)
 	applicationType := d.Get("application_type").(string)
 	location := d.Get("location").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	applicationInsightsComponentProperties := appinsights.ApplicationInsightsComponentProperties{
 		ApplicationID:   &name,
@@ -82,7 +81,7 @@ func resourceArmApplicationInsightsCreateOrUpdate(d *schema.ResourceData, meta i
 		Location: &location,
 		Kind:     &applicationType,
 		ApplicationInsightsComponentProperties: &applicationInsightsComponentProperties,
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
 	_, err := client.CreateOrUpdate(resGroup, name, insightProperties)
@@ -104,9 +103,9 @@ func resourceArmApplicationInsightsCreateOrUpdate(d *schema.ResourceData, meta i
 }
 
 func resourceArmApplicationInsightsRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).appInsightsClient
+	client := meta.(*ArmClient).appInsightsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Insights/components")
 	if err != nil {
 		return err
 	}
@@ -114,7 +113,7 @@ func resourceArmApplicationInsightsRead(d *schema.ResourceData, meta interface{}
 	log.Printf("[DEBUG] Reading AzureRM Application Insights '%s'", id)
 
 	resGroup := id.ResourceGroup
-	name := id.Path["components"]
+	name := names[0]
 
 	resp, err := client.Get(resGroup, name)
 	if err != nil {
@@ -135,20 +134,20 @@ func resourceArmApplicationInsightsRead(d *schema.ResourceData, meta interface{}
 		d.Set("instrumentation_key", props.InstrumentationKey)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmApplicationInsightsDelete(d *schema.ResourceData, meta interface{}) error {
-	AppInsightsClient := meta.(*ArmClient).appInsightsClient
+	AppInsightsClient := meta.(*ArmClient).appInsightsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Insights/components")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["components"]
+	name := names[0]
 
 	log.Printf("[DEBUG] Deleting AzureRM Application Insights '%s' (resource group '%s')", name, resGroup)
 
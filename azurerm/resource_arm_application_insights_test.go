@@ -5,14 +5,13 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMApplicationInsights_basicWeb(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMApplicationInsights_basicWeb(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -32,7 +31,7 @@ func TestAccAzureRMApplicationInsights_basicWeb(t *testing.T) {
 
 func TestAccAzureRMApplicationInsights_basicOther(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMApplicationInsights_basicOther(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -51,7 +50,7 @@ func TestAccAzureRMApplicationInsights_basicOther(t *testing.T) {
 }
 
 func testCheckAzureRMApplicationInsightsDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).appInsightsClient
+	conn := testAccProvider.Meta().(*ArmClient).appInsightsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_application_insights" {
@@ -89,7 +88,7 @@ func testCheckAzureRMApplicationInsightsExists(name string) resource.TestCheckFu
 			return fmt.Errorf("Bad: no resource group found in state for App Insights: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).appInsightsClient
+		conn := testAccProvider.Meta().(*ArmClient).appInsightsClient()
 
 		resp, err := conn.Get(resourceGroup, name)
 		if err != nil {
@@ -74,13 +74,12 @@ func resourceAzureRMAutomationAccountSkuHash(v interface{}) int {
 }
 
 func resourceArmAutomationAccountCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationAccountClient
+	client := meta.(*ArmClient).automationAccountClient()
 	log.Printf("[INFO] preparing arguments for AzureRM Automation Account creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	sku := expandSku(d)
 
@@ -91,7 +90,7 @@ func resourceArmAutomationAccountCreateUpdate(d *schema.ResourceData, meta inter
 
 		Name:     &name,
 		Location: &location,
-		Tags:     expandTags(tags),
+		Tags:     expandTagsForResource(d, meta),
 	}
 
 	_, err := client.CreateOrUpdate(resGroup, name, parameters)
@@ -114,13 +113,13 @@ func resourceArmAutomationAccountCreateUpdate(d *schema.ResourceData, meta inter
 }
 
 func resourceArmAutomationAccountRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationAccountClient
-	id, err := parseAzureResourceID(d.Id())
+	client := meta.(*ArmClient).automationAccountClient()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Automation/automationAccounts")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["automationAccounts"]
+	name := names[0]
 
 	resp, err := client.Get(resGroup, name)
 	if err != nil {
@@ -137,20 +136,20 @@ func resourceArmAutomationAccountRead(d *schema.ResourceData, meta interface{})
 	d.Set("resource_group_name", resGroup)
 	flattenAndSetSku(d, resp.Sku)
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmAutomationAccountDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationAccountClient
+	client := meta.(*ArmClient).automationAccountClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Automation/automationAccounts")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["automationAccounts"]
+	name := names[0]
 
 	resp, err := client.Delete(resGroup, name)
 
@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 
@@ -13,7 +12,7 @@ import (
 )
 
 func TestAccAzureRMAutomationAccount_skuBasic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAutomationAccount_skuBasic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -32,7 +31,7 @@ func TestAccAzureRMAutomationAccount_skuBasic(t *testing.T) {
 }
 
 func TestAccAzureRMAutomationAccount_skuFree(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAutomationAccount_skuFree(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -51,7 +50,7 @@ func TestAccAzureRMAutomationAccount_skuFree(t *testing.T) {
 }
 
 func testCheckAzureRMAutomationAccountDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).automationAccountClient
+	conn := testAccProvider.Meta().(*ArmClient).automationAccountClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_automation_account" {
@@ -90,7 +89,7 @@ func testCheckAzureRMAutomationAccountExistsAndSku(name string, sku automation.S
 			return fmt.Errorf("Bad: no resource group found in state for Automation Account: '%s'", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).automationAccountClient
+		conn := testAccProvider.Meta().(*ArmClient).automationAccountClient()
 
 		resp, err := conn.Get(resourceGroup, name)
 
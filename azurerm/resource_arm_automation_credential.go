@@ -54,7 +54,7 @@ func resourceArmAutomationCredential() *schema.Resource {
 }
 
 func resourceArmAutomationCredentialCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationCredentialClient
+	client := meta.(*ArmClient).automationCredentialClient()
 	log.Printf("[INFO] preparing arguments for AzureRM Automation Credential creation.")
 
 	name := d.Get("name").(string)
@@ -94,14 +94,14 @@ func resourceArmAutomationCredentialCreateUpdate(d *schema.ResourceData, meta in
 }
 
 func resourceArmAutomationCredentialRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationCredentialClient
-	id, err := parseAzureResourceID(d.Id())
+	client := meta.(*ArmClient).automationCredentialClient()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Automation/automationAccounts/credentials")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	accName := id.Path["automationAccounts"]
-	name := id.Path["credentials"]
+	accName := names[0]
+	name := names[1]
 
 	resp, err := client.Get(resGroup, accName, name)
 
@@ -125,15 +125,15 @@ func resourceArmAutomationCredentialRead(d *schema.ResourceData, meta interface{
 }
 
 func resourceArmAutomationCredentialDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationCredentialClient
+	client := meta.(*ArmClient).automationCredentialClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Automation/automationAccounts/credentials")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	accName := id.Path["automationAccounts"]
-	name := id.Path["credentials"]
+	accName := names[0]
+	name := names[1]
 
 	resp, err := client.Delete(resGroup, accName, name)
 
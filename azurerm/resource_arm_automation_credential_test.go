@@ -5,13 +5,12 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMAutomationCredential_testCredential(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAutomationCredential_testCredential(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -31,7 +30,7 @@ func TestAccAzureRMAutomationCredential_testCredential(t *testing.T) {
 }
 
 func testCheckAzureRMAutomationCredentialDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).automationCredentialClient
+	conn := testAccProvider.Meta().(*ArmClient).automationCredentialClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_automation_credential" {
@@ -73,7 +72,7 @@ func testCheckAzureRMAutomationCredentialExistsAndUserName(name string, username
 			return fmt.Errorf("Bad: no resource group found in state for Automation Credential: '%s'", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).automationCredentialClient
+		conn := testAccProvider.Meta().(*ArmClient).automationCredentialClient()
 
 		resp, err := conn.Get(resourceGroup, accName, name)
 
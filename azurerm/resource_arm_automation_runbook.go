@@ -106,13 +106,12 @@ func resourceAzureRMAutomationRunbookContentLinkHash(v interface{}) int {
 }
 
 func resourceArmAutomationRunbookCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationRunbookClient
+	client := meta.(*ArmClient).automationRunbookClient()
 	log.Printf("[INFO] preparing arguments for AzureRM Automation Runbook creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	accName := d.Get("account_name").(string)
 	runbookType := automation.RunbookTypeEnum(d.Get("runbook_type").(string))
@@ -133,7 +132,7 @@ func resourceArmAutomationRunbookCreateUpdate(d *schema.ResourceData, meta inter
 
 		Name:     &name,
 		Location: &location,
-		Tags:     expandTags(tags),
+		Tags:     expandTagsForResource(d, meta),
 	}
 
 	_, err := client.CreateOrUpdate(resGroup, accName, name, parameters)
@@ -156,14 +155,14 @@ func resourceArmAutomationRunbookCreateUpdate(d *schema.ResourceData, meta inter
 }
 
 func resourceArmAutomationRunbookRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationRunbookClient
-	id, err := parseAzureResourceID(d.Id())
+	client := meta.(*ArmClient).automationRunbookClient()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Automation/automationAccounts/runbooks")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	accName := id.Path["automationAccounts"]
-	name := id.Path["runbooks"]
+	accName := names[0]
+	name := names[1]
 
 	resp, err := client.Get(resGroup, accName, name)
 	if err != nil {
@@ -186,21 +185,21 @@ func resourceArmAutomationRunbookRead(d *schema.ResourceData, meta interface{})
 	d.Set("description", resp.Description)
 	d.Set("publish_content_link", nil) //publish content link is not set during Get()
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmAutomationRunbookDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationRunbookClient
+	client := meta.(*ArmClient).automationRunbookClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Automation/automationAccounts/runbooks")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	accName := id.Path["automationAccounts"]
-	name := id.Path["runbooks"]
+	accName := names[0]
+	name := names[1]
 
 	resp, err := client.Delete(resGroup, accName, name)
 
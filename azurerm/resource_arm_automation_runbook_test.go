@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 
@@ -13,7 +12,7 @@ import (
 )
 
 func TestAccAzureRMAutomationRunbook_PSWorkflow(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAutomationRunbook_PSWorkflow(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMAutomationRunbook_PSWorkflow(t *testing.T) {
 }
 
 func testCheckAzureRMAutomationRunbookDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).automationRunbookClient
+	conn := testAccProvider.Meta().(*ArmClient).automationRunbookClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_automation_runbook" {
@@ -75,7 +74,7 @@ func testCheckAzureRMAutomationRunbookExistsAndType(name string, runbookType aut
 			return fmt.Errorf("Bad: no resource group found in state for Automation Runbook: '%s'", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).automationRunbookClient
+		conn := testAccProvider.Meta().(*ArmClient).automationRunbookClient()
 
 		resp, err := conn.Get(resourceGroup, accName, name)
 
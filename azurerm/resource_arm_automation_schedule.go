@@ -198,7 +198,7 @@ func computeValidStartTime(firstRunSet *schema.Set, freq automation.ScheduleFreq
 }
 
 func resourceArmAutomationScheduleCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationScheduleClient
+	client := meta.(*ArmClient).automationScheduleClient()
 	log.Printf("[INFO] preparing arguments for AzureRM Automation Schedule creation.")
 
 	name := d.Get("name").(string)
@@ -259,14 +259,14 @@ func resourceArmAutomationScheduleCreateUpdate(d *schema.ResourceData, meta inte
 }
 
 func resourceArmAutomationScheduleRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationScheduleClient
-	id, err := parseAzureResourceID(d.Id())
+	client := meta.(*ArmClient).automationScheduleClient()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Automation/automationAccounts/schedules")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	accName := id.Path["automationAccounts"]
-	name := id.Path["schedules"]
+	accName := names[0]
+	name := names[1]
 
 	resp, err := client.Get(resGroup, accName, name)
 	if err != nil {
@@ -290,15 +290,15 @@ func resourceArmAutomationScheduleRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceArmAutomationScheduleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).automationScheduleClient
+	client := meta.(*ArmClient).automationScheduleClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Automation/automationAccounts/schedules")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	accName := id.Path["automationAccounts"]
-	name := id.Path["schedules"]
+	accName := names[0]
+	name := names[1]
 
 	resp, err := client.Delete(resGroup, accName, name)
 
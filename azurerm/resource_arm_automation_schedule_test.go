@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 
@@ -13,7 +12,7 @@ import (
 )
 
 func TestAccAzureRMAutomationSchedule_oneTime(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAutomationSchedule_oneTime(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMAutomationSchedule_oneTime(t *testing.T) {
 }
 
 func testCheckAzureRMAutomationScheduleDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).automationScheduleClient
+	conn := testAccProvider.Meta().(*ArmClient).automationScheduleClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_automation_schedule" {
@@ -75,7 +74,7 @@ func testCheckAzureRMAutomationScheduleExistsAndFrequencyType(name string, freq
 			return fmt.Errorf("Bad: no resource group found in state for Automation Schedule: '%s'", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).automationScheduleClient
+		conn := testAccProvider.Meta().(*ArmClient).automationScheduleClient()
 
 		resp, err := conn.Get(resourceGroup, accName, name)
 
@@ -65,7 +65,7 @@ func resourceArmAvailabilitySet() *schema.Resource {
 }
 
 func resourceArmAvailabilitySetCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).availSetClient
+	client := meta.(*ArmClient).availSetClient()
 
 	log.Printf("[INFO] preparing arguments for AzureRM Availability Set creation.")
 
@@ -75,7 +75,6 @@ func resourceArmAvailabilitySetCreate(d *schema.ResourceData, meta interface{})
 	updateDomainCount := d.Get("platform_update_domain_count").(int)
 	faultDomainCount := d.Get("platform_fault_domain_count").(int)
 	managed := d.Get("managed").(bool)
-	tags := d.Get("tags").(map[string]interface{})
 
 	availSet := compute.AvailabilitySet{
 		Name:     &name,
@@ -84,7 +83,7 @@ func resourceArmAvailabilitySetCreate(d *schema.ResourceData, meta interface{})
 			PlatformFaultDomainCount:  azure.Int32(int32(faultDomainCount)),
 			PlatformUpdateDomainCount: azure.Int32(int32(updateDomainCount)),
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
 	if managed == true {
@@ -105,14 +104,14 @@ func resourceArmAvailabilitySetCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceArmAvailabilitySetRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).availSetClient
+	client := meta.(*ArmClient).availSetClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/availabilitySets")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["availabilitySets"]
+	name := names[0]
 
 	resp, err := client.Get(resGroup, name)
 	if err != nil {
@@ -134,20 +133,20 @@ func resourceArmAvailabilitySetRead(d *schema.ResourceData, meta interface{}) er
 		d.Set("managed", strings.EqualFold(*resp.Sku.Name, "Aligned"))
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmAvailabilitySetDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).availSetClient
+	client := meta.(*ArmClient).availSetClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/availabilitySets")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["availabilitySets"]
+	name := names[0]
 
 	_, err = client.Delete(resGroup, name)
 
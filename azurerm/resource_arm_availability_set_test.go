@@ -5,14 +5,13 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMAvailabilitySet_basic(t *testing.T) {
 	resourceName := "azurerm_availability_set.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAvailabilitySet_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -34,7 +33,7 @@ func TestAccAzureRMAvailabilitySet_basic(t *testing.T) {
 
 func TestAccAzureRMAvailabilitySet_disappears(t *testing.T) {
 	resourceName := "azurerm_availability_set.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAvailabilitySet_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -58,7 +57,7 @@ func TestAccAzureRMAvailabilitySet_disappears(t *testing.T) {
 
 func TestAccAzureRMAvailabilitySet_withTags(t *testing.T) {
 	resourceName := "azurerm_availability_set.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMAvailabilitySet_withTags(ri, location)
 	postConfig := testAccAzureRMAvailabilitySet_withUpdatedTags(ri, location)
@@ -91,7 +90,7 @@ func TestAccAzureRMAvailabilitySet_withTags(t *testing.T) {
 
 func TestAccAzureRMAvailabilitySet_withDomainCounts(t *testing.T) {
 	resourceName := "azurerm_availability_set.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAvailabilitySet_withDomainCounts(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -113,7 +112,7 @@ func TestAccAzureRMAvailabilitySet_withDomainCounts(t *testing.T) {
 
 func TestAccAzureRMAvailabilitySet_managed(t *testing.T) {
 	resourceName := "azurerm_availability_set.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMAvailabilitySet_managed(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -146,7 +145,7 @@ func testCheckAzureRMAvailabilitySetExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for availability set: %s", availSetName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).availSetClient
+		conn := testAccProvider.Meta().(*ArmClient).availSetClient()
 
 		resp, err := conn.Get(resourceGroup, availSetName)
 		if err != nil {
@@ -175,7 +174,7 @@ func testCheckAzureRMAvailabilitySetDisappears(name string) resource.TestCheckFu
 			return fmt.Errorf("Bad: no resource group found in state for availability set: %s", availSetName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).availSetClient
+		conn := testAccProvider.Meta().(*ArmClient).availSetClient()
 
 		_, err := conn.Delete(resourceGroup, availSetName)
 		if err != nil {
@@ -187,7 +186,7 @@ func testCheckAzureRMAvailabilitySetDisappears(name string) resource.TestCheckFu
 }
 
 func testCheckAzureRMAvailabilitySetDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).availSetClient
+	conn := testAccProvider.Meta().(*ArmClient).availSetClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_availability_set" {
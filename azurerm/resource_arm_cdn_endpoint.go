@@ -134,7 +134,7 @@ func resourceArmCdnEndpoint() *schema.Resource {
 
 func resourceArmCdnEndpointCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	cdnEndpointsClient := client.cdnEndpointsClient
+	cdnEndpointsClient := client.cdnEndpointsClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM CDN EndPoint creation.")
 
@@ -146,7 +146,6 @@ func resourceArmCdnEndpointCreate(d *schema.ResourceData, meta interface{}) erro
 	https_allowed := d.Get("is_https_allowed").(bool)
 	compression_enabled := d.Get("is_compression_enabled").(bool)
 	caching_behaviour := d.Get("querystring_caching_behaviour").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	properties := cdn.EndpointProperties{
 		IsHTTPAllowed:              &http_allowed,
@@ -187,12 +186,15 @@ func resourceArmCdnEndpointCreate(d *schema.ResourceData, meta interface{}) erro
 	cdnEndpoint := cdn.Endpoint{
 		Location:           &location,
 		EndpointProperties: &properties,
-		Tags:               expandTags(tags),
+		Tags:               expandTagsForResource(d, meta),
 	}
 
-	_, error := cdnEndpointsClient.Create(resGroup, profileName, name, cdnEndpoint, make(<-chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := cdnEndpointsClient.Create(resGroup, profileName, name, cdnEndpoint, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Cdn/profiles/endpoints", profileName, name)
 		return err
 	}
 
@@ -210,18 +212,15 @@ func resourceArmCdnEndpointCreate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceArmCdnEndpointRead(d *schema.ResourceData, meta interface{}) error {
-	cdnEndpointsClient := meta.(*ArmClient).cdnEndpointsClient
+	cdnEndpointsClient := meta.(*ArmClient).cdnEndpointsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Cdn/profiles/endpoints")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["endpoints"]
-	profileName := id.Path["profiles"]
-	if profileName == "" {
-		profileName = id.Path["Profiles"]
-	}
+	name := names[1]
+	profileName := names[0]
 	log.Printf("[INFO] Trying to find the AzureRM CDN Endpoint %s (Profile: %s, RG: %s)", name, profileName, resGroup)
 	resp, err := cdnEndpointsClient.Get(resGroup, profileName, name)
 	if err != nil {
@@ -252,13 +251,13 @@ func resourceArmCdnEndpointRead(d *schema.ResourceData, meta interface{}) error
 	}
 	d.Set("origin", flattenAzureRMCdnEndpointOrigin(resp.EndpointProperties.Origins))
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmCdnEndpointUpdate(d *schema.ResourceData, meta interface{}) error {
-	cdnEndpointsClient := meta.(*ArmClient).cdnEndpointsClient
+	cdnEndpointsClient := meta.(*ArmClient).cdnEndpointsClient()
 
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
@@ -267,7 +266,6 @@ func resourceArmCdnEndpointUpdate(d *schema.ResourceData, meta interface{}) erro
 	https_allowed := d.Get("is_https_allowed").(bool)
 	compression_enabled := d.Get("is_compression_enabled").(bool)
 	caching_behaviour := d.Get("querystring_caching_behaviour").(string)
-	newTags := d.Get("tags").(map[string]interface{})
 
 	properties := cdn.EndpointPropertiesUpdateParameters{
 		IsHTTPAllowed:              &http_allowed,
@@ -298,11 +296,13 @@ func resourceArmCdnEndpointUpdate(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	updateProps := cdn.EndpointUpdateParameters{
-		Tags: expandTags(newTags),
+		Tags: expandTagsForResource(d, meta),
 		EndpointPropertiesUpdateParameters: &properties,
 	}
 
-	_, error := cdnEndpointsClient.Update(resGroup, profileName, name, updateProps, make(<-chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	_, error := cdnEndpointsClient.Update(resGroup, profileName, name, updateProps, ctx.Done())
 	err := <-error
 	if err != nil {
 		return fmt.Errorf("Error issuing Azure ARM update request to update CDN Endpoint %q: %s", name, err)
@@ -312,20 +312,19 @@ func resourceArmCdnEndpointUpdate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceArmCdnEndpointDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).cdnEndpointsClient
+	client := meta.(*ArmClient).cdnEndpointsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Cdn/profiles/endpoints")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	profileName := id.Path["profiles"]
-	if profileName == "" {
-		profileName = id.Path["Profiles"]
-	}
-	name := id.Path["endpoints"]
+	profileName := names[0]
+	name := names[1]
 
-	accResp, error := client.Delete(resGroup, profileName, name, make(<-chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	accResp, error := client.Delete(resGroup, profileName, name, ctx.Done())
 	resp := <-accResp
 	err = <-error
 	if err != nil {
@@ -49,7 +49,7 @@ func resourceArmCdnProfile() *schema.Resource {
 
 func resourceArmCdnProfileCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	cdnProfilesClient := client.cdnProfilesClient
+	cdnProfilesClient := client.cdnProfilesClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM CDN Profile creation.")
 
@@ -57,19 +57,21 @@ func resourceArmCdnProfileCreate(d *schema.ResourceData, meta interface{}) error
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
 	sku := d.Get("sku").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	cdnProfile := cdn.Profile{
 		Location: &location,
-		Tags:     expandTags(tags),
+		Tags:     expandTagsForResource(d, meta),
 		Sku: &cdn.Sku{
 			Name: cdn.SkuName(sku),
 		},
 	}
 
-	_, error := cdnProfilesClient.Create(resGroup, name, cdnProfile, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := cdnProfilesClient.Create(resGroup, name, cdnProfile, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Cdn/profiles", name)
 		return err
 	}
 
@@ -87,14 +89,14 @@ func resourceArmCdnProfileCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceArmCdnProfileRead(d *schema.ResourceData, meta interface{}) error {
-	cdnProfilesClient := meta.(*ArmClient).cdnProfilesClient
+	cdnProfilesClient := meta.(*ArmClient).cdnProfilesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Cdn/profiles")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["profiles"]
+	name := names[0]
 
 	resp, err := cdnProfilesClient.Get(resGroup, name)
 	if err != nil {
@@ -113,13 +115,13 @@ func resourceArmCdnProfileRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("sku", string(resp.Sku.Name))
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmCdnProfileUpdate(d *schema.ResourceData, meta interface{}) error {
-	cdnProfilesClient := meta.(*ArmClient).cdnProfilesClient
+	cdnProfilesClient := meta.(*ArmClient).cdnProfilesClient()
 
 	if !d.HasChange("tags") {
 		return nil
@@ -127,13 +129,14 @@ func resourceArmCdnProfileUpdate(d *schema.ResourceData, meta interface{}) error
 
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	newTags := d.Get("tags").(map[string]interface{})
 
 	props := cdn.ProfileUpdateParameters{
-		Tags: expandTags(newTags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
-	_, error := cdnProfilesClient.Update(resGroup, name, props, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	_, error := cdnProfilesClient.Update(resGroup, name, props, ctx.Done())
 	err := <-error
 	if err != nil {
 		return fmt.Errorf("Error issuing Azure ARM update request to update CDN Profile %q: %s", name, err)
@@ -143,16 +146,18 @@ func resourceArmCdnProfileUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceArmCdnProfileDelete(d *schema.ResourceData, meta interface{}) error {
-	cdnProfilesClient := meta.(*ArmClient).cdnProfilesClient
+	cdnProfilesClient := meta.(*ArmClient).cdnProfilesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Cdn/profiles")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["profiles"]
+	name := names[0]
 
-	_, error := cdnProfilesClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := cdnProfilesClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 	// TODO: check the status code
 
@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -24,7 +23,7 @@ func testSweepCDNProfiles(region string) error {
 		return err
 	}
 
-	client := (*armClient).cdnProfilesClient
+	client := (*armClient).cdnProfilesClient()
 
 	log.Printf("Retrieving the CDN Profiles..")
 	results, err := client.List()
@@ -37,13 +36,13 @@ func testSweepCDNProfiles(region string) error {
 			continue
 		}
 
-		resourceId, err := parseAzureResourceID(*profile.ID)
+		resourceId, names, err := parseAzureResourceIDOfType(*profile.ID, "Microsoft.Cdn/profiles")
 		if err != nil {
 			return err
 		}
 
 		resourceGroup := resourceId.ResourceGroup
-		name := resourceId.Path["profiles"]
+		name := names[0]
 
 		log.Printf("Deleting CDN Profile '%s' in Resource Group '%s'", name, resourceGroup)
 		_, error := client.Delete(resourceGroup, name, make(chan struct{}))
@@ -97,7 +96,7 @@ func TestResourceAzureRMCdnProfileSKU_validation(t *testing.T) {
 }
 
 func TestAccAzureRMCdnProfile_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMCdnProfile_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -117,7 +116,7 @@ func TestAccAzureRMCdnProfile_basic(t *testing.T) {
 
 func TestAccAzureRMCdnProfile_withTags(t *testing.T) {
 	resourceName := "azurerm_cdn_profile.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMCdnProfile_withTags(ri, location)
 	postConfig := testAccAzureRMCdnProfile_withTagsUpdate(ri, location)
@@ -150,7 +149,7 @@ func TestAccAzureRMCdnProfile_withTags(t *testing.T) {
 }
 
 func TestAccAzureRMCdnProfile_NonStandardCasing(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMCdnProfileNonStandardCasing(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -187,7 +186,7 @@ func testCheckAzureRMCdnProfileExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for cdn profile: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).cdnProfilesClient
+		conn := testAccProvider.Meta().(*ArmClient).cdnProfilesClient()
 
 		resp, err := conn.Get(resourceGroup, name)
 		if err != nil {
@@ -203,7 +202,7 @@ func testCheckAzureRMCdnProfileExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMCdnProfileDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).cdnProfilesClient
+	conn := testAccProvider.Meta().(*ArmClient).cdnProfilesClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_cdn_profile" {
@@ -101,7 +101,7 @@ func resourceArmContainerRegistry() *schema.Resource {
 }
 
 func resourceArmContainerRegistryCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).containerRegistryClient
+	client := meta.(*ArmClient).containerRegistryClient()
 	log.Printf("[INFO] preparing arguments for AzureRM Container Registry creation.")
 
 	resourceGroup := d.Get("resource_group_name").(string)
@@ -110,7 +110,6 @@ func resourceArmContainerRegistryCreate(d *schema.ResourceData, meta interface{}
 	sku := d.Get("sku").(string)
 
 	adminUserEnabled := d.Get("admin_enabled").(bool)
-	tags := d.Get("tags").(map[string]interface{})
 
 	parameters := containerregistry.RegistryCreateParameters{
 		Location: &location,
@@ -121,7 +120,7 @@ func resourceArmContainerRegistryCreate(d *schema.ResourceData, meta interface{}
 		RegistryPropertiesCreateParameters: &containerregistry.RegistryPropertiesCreateParameters{
 			AdminUserEnabled: &adminUserEnabled,
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
 	accounts := d.Get("storage_account").(*schema.Set).List()
@@ -133,9 +132,12 @@ func resourceArmContainerRegistryCreate(d *schema.ResourceData, meta interface{}
 		AccessKey: azure.String(storageAccountAccessKey),
 	}
 
-	_, error := client.Create(resourceGroup, name, parameters, make(<-chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := client.Create(resourceGroup, name, parameters, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resourceGroup, "Microsoft.ContainerRegistry/registries", name)
 		return err
 	}
 
@@ -154,7 +156,7 @@ func resourceArmContainerRegistryCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceArmContainerRegistryUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).containerRegistryClient
+	client := meta.(*ArmClient).containerRegistryClient()
 	log.Printf("[INFO] preparing arguments for AzureRM Container Registry update.")
 
 	resourceGroup := d.Get("resource_group_name").(string)
@@ -166,7 +168,6 @@ func resourceArmContainerRegistryUpdate(d *schema.ResourceData, meta interface{}
 	storageAccountAccessKey := account["access_key"].(string)
 
 	adminUserEnabled := d.Get("admin_enabled").(bool)
-	tags := d.Get("tags").(map[string]interface{})
 
 	parameters := containerregistry.RegistryUpdateParameters{
 		RegistryPropertiesUpdateParameters: &containerregistry.RegistryPropertiesUpdateParameters{
@@ -176,7 +177,7 @@ func resourceArmContainerRegistryUpdate(d *schema.ResourceData, meta interface{}
 				AccessKey: azure.String(storageAccountAccessKey),
 			},
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
 	_, err := client.Update(resourceGroup, name, parameters)
@@ -199,14 +200,14 @@ func resourceArmContainerRegistryUpdate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceArmContainerRegistryRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).containerRegistryClient
+	client := meta.(*ArmClient).containerRegistryClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ContainerRegistry/registries")
 	if err != nil {
 		return err
 	}
 	resourceGroup := id.ResourceGroup
-	name := id.Path["registries"]
+	name := names[0]
 
 	resp, err := client.Get(resourceGroup, name)
 	if err != nil {
@@ -248,20 +249,20 @@ func resourceArmContainerRegistryRead(d *schema.ResourceData, meta interface{})
 		d.Set("admin_password", "")
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmContainerRegistryDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).containerRegistryClient
+	client := meta.(*ArmClient).containerRegistryClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ContainerRegistry/registries")
 	if err != nil {
 		return err
 	}
 	resourceGroup := id.ResourceGroup
-	name := id.Path["registries"]
+	name := names[0]
 
 	resp, err := client.Delete(resourceGroup, name)
 
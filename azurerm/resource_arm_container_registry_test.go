@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -67,8 +66,8 @@ func TestAccAzureRMContainerRegistryName_validation(t *testing.T) {
 }
 
 func TestAccAzureRMContainerRegistry_basic(t *testing.T) {
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	config := testAccAzureRMContainerRegistry_basic(ri, rs, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -87,8 +86,8 @@ func TestAccAzureRMContainerRegistry_basic(t *testing.T) {
 }
 
 func TestAccAzureRMContainerRegistry_complete(t *testing.T) {
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	config := testAccAzureRMContainerRegistry_complete(ri, rs, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -107,8 +106,8 @@ func TestAccAzureRMContainerRegistry_complete(t *testing.T) {
 }
 
 func TestAccAzureRMContainerRegistry_update(t *testing.T) {
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	location := testLocation()
 	config := testAccAzureRMContainerRegistry_complete(ri, rs, location)
 	updatedConfig := testAccAzureRMContainerRegistry_completeUpdated(ri, rs, location)
@@ -135,7 +134,7 @@ func TestAccAzureRMContainerRegistry_update(t *testing.T) {
 }
 
 func testCheckAzureRMContainerRegistryDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).containerRegistryClient
+	conn := testAccProvider.Meta().(*ArmClient).containerRegistryClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_container_registry" {
@@ -173,7 +172,7 @@ func testCheckAzureRMContainerRegistryExists(name string) resource.TestCheckFunc
 			return fmt.Errorf("Bad: no resource group found in state for Container Registry: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).containerRegistryClient
+		conn := testAccProvider.Meta().(*ArmClient).containerRegistryClient()
 
 		resp, err := conn.Get(resourceGroup, name)
 		if err != nil {
@@ -187,7 +187,7 @@ func resourceArmContainerService() *schema.Resource {
 
 func resourceArmContainerServiceCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	containerServiceClient := client.containerServicesClient
+	containerServiceClient := client.containerServicesClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM Container Service creation.")
 
@@ -202,8 +202,6 @@ func resourceArmContainerServiceCreate(d *schema.ResourceData, meta interface{})
 	agentProfiles := expandAzureRmContainerServiceAgentProfiles(d)
 	diagnosticsProfile := expandAzureRmContainerServiceDiagnostics(d)
 
-	tags := d.Get("tags").(map[string]interface{})
-
 	parameters := containerservice.ContainerService{
 		Name:     &name,
 		Location: &location,
@@ -216,7 +214,7 @@ func resourceArmContainerServiceCreate(d *schema.ResourceData, meta interface{})
 			AgentPoolProfiles:  &agentProfiles,
 			DiagnosticsProfile: &diagnosticsProfile,
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
 	servicePrincipalProfile := expandAzureRmContainerServiceServicePrincipal(d)
@@ -224,9 +222,12 @@ func resourceArmContainerServiceCreate(d *schema.ResourceData, meta interface{})
 		parameters.ServicePrincipalProfile = servicePrincipalProfile
 	}
 
-	_, error := containerServiceClient.CreateOrUpdate(resGroup, name, parameters, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := containerServiceClient.CreateOrUpdate(resGroup, name, parameters, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.ContainerService/containerServices", name)
 		return err
 	}
 
@@ -239,32 +240,32 @@ func resourceArmContainerServiceCreate(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("Cannot read Container Service %s (resource group %s) ID", name, resGroup)
 	}
 
+	d.SetId(*read.ID)
+
 	log.Printf("[DEBUG] Waiting for Container Service (%s) to become available", d.Get("name"))
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"Updating", "Creating"},
 		Target:     []string{"Succeeded"},
 		Refresh:    containerServiceStateRefreshFunc(client, resGroup, name),
-		Timeout:    30 * time.Minute,
+		Timeout:    createOrUpdateTimeout(d),
 		MinTimeout: 15 * time.Second,
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := meta.(*ArmClient).waitForState(stateConf); err != nil {
 		return fmt.Errorf("Error waiting for Container Service (%s) to become available: %s", d.Get("name"), err)
 	}
 
-	d.SetId(*read.ID)
-
 	return resourceArmContainerServiceRead(d, meta)
 }
 
 func resourceArmContainerServiceRead(d *schema.ResourceData, meta interface{}) error {
-	containerServiceClient := meta.(*ArmClient).containerServicesClient
+	containerServiceClient := meta.(*ArmClient).containerServicesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ContainerService/containerServices")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["containerServices"]
+	name := names[0]
 
 	resp, err := containerServiceClient.Get(resGroup, name)
 	if err != nil {
@@ -301,23 +302,25 @@ func resourceArmContainerServiceRead(d *schema.ResourceData, meta interface{}) e
 		d.Set("diagnostics_profile", diagnosticProfile)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmContainerServiceDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	containerServiceClient := client.containerServicesClient
+	containerServiceClient := client.containerServicesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ContainerService/containerServices")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["containerServices"]
+	name := names[0]
 
-	delResp, error := containerServiceClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	delResp, error := containerServiceClient.Delete(resGroup, name, ctx.Done())
 	resp := <-delResp
 	err = <-error
 	if err != nil {
@@ -533,7 +536,7 @@ func expandAzureRmContainerServiceAgentProfiles(d *schema.ResourceData) []contai
 
 func containerServiceStateRefreshFunc(client *ArmClient, resourceGroupName string, containerServiceName string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		res, err := client.containerServicesClient.Get(resourceGroupName, containerServiceName)
+		res, err := client.containerServicesClient().Get(resourceGroupName, containerServiceName)
 		if err != nil {
 			return nil, "", fmt.Errorf("Error issuing read request in containerServiceStateRefreshFunc to Azure ARM for Container Service '%s' (RG: '%s'): %s", containerServiceName, resourceGroupName, err)
 		}
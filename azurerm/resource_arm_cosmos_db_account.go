@@ -145,7 +145,7 @@ func resourceArmCosmosDBAccount() *schema.Resource {
 }
 
 func resourceArmCosmosDBAccountCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).cosmosDBClient
+	client := meta.(*ArmClient).cosmosDBClient()
 	log.Printf("[INFO] preparing arguments for AzureRM Cosmos DB Account creation.")
 
 	name := d.Get("name").(string)
@@ -159,7 +159,6 @@ func resourceArmCosmosDBAccountCreateUpdate(d *schema.ResourceData, meta interfa
 	if err != nil {
 		return err
 	}
-	tags := d.Get("tags").(map[string]interface{})
 
 	parameters := cosmosdb.DatabaseAccountCreateUpdateParameters{
 		Location: &location,
@@ -169,12 +168,15 @@ func resourceArmCosmosDBAccountCreateUpdate(d *schema.ResourceData, meta interfa
 			Locations:                &failoverPolicies,
 			IPRangeFilter:            &ipRangeFilter,
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
-	_, error := client.CreateOrUpdate(resGroup, name, parameters, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	_, error := client.CreateOrUpdate(resGroup, name, parameters, ctx.Done())
 	err = <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.DocumentDB/databaseAccounts", name)
 		return err
 	}
 
@@ -193,13 +195,13 @@ func resourceArmCosmosDBAccountCreateUpdate(d *schema.ResourceData, meta interfa
 }
 
 func resourceArmCosmosDBAccountRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).cosmosDBClient
-	id, err := parseAzureResourceID(d.Id())
+	client := meta.(*ArmClient).cosmosDBClient()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.DocumentDB/databaseAccounts")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["databaseAccounts"]
+	name := names[0]
 
 	resp, err := client.Get(resGroup, name)
 	if err != nil {
@@ -235,22 +237,24 @@ func resourceArmCosmosDBAccountRead(d *schema.ResourceData, meta interface{}) er
 		d.Set("secondary_readonly_master_key", readonlyKeys.SecondaryReadonlyMasterKey)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmCosmosDBAccountDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).cosmosDBClient
+	client := meta.(*ArmClient).cosmosDBClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.DocumentDB/databaseAccounts")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["databaseAccounts"]
+	name := names[0]
 
-	deleteResp, error := client.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	deleteResp, error := client.Delete(resGroup, name, ctx.Done())
 	resp := <-deleteResp
 	err = <-error
 
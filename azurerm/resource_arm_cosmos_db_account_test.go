@@ -5,13 +5,12 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMCosmosDBAccountName_validation(t *testing.T) {
-	str := acctest.RandString(50)
+	str := testRandString(t, 50)
 	cases := []struct {
 		Value    string
 		ErrCount int
@@ -45,7 +44,7 @@ func TestAccAzureRMCosmosDBAccountName_validation(t *testing.T) {
 
 func TestAccAzureRMCosmosDBAccount_boundedStaleness(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMCosmosDBAccount_boundedStaleness(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -65,7 +64,7 @@ func TestAccAzureRMCosmosDBAccount_boundedStaleness(t *testing.T) {
 
 func TestAccAzureRMCosmosDBAccount_boundedStalenessComplete(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMCosmosDBAccount_boundedStalenessComplete(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -84,7 +83,7 @@ func TestAccAzureRMCosmosDBAccount_boundedStalenessComplete(t *testing.T) {
 }
 
 func TestAccAzureRMCosmosDBAccount_eventualConsistency(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMCosmosDBAccount_eventualConsistency(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -103,7 +102,7 @@ func TestAccAzureRMCosmosDBAccount_eventualConsistency(t *testing.T) {
 }
 
 func TestAccAzureRMCosmosDBAccount_session(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMCosmosDBAccount_session(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -122,7 +121,7 @@ func TestAccAzureRMCosmosDBAccount_session(t *testing.T) {
 }
 
 func TestAccAzureRMCosmosDBAccount_strong(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMCosmosDBAccount_strong(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -142,7 +141,7 @@ func TestAccAzureRMCosmosDBAccount_strong(t *testing.T) {
 
 func TestAccAzureRMCosmosDBAccount_geoReplicated(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMCosmosDBAccount_geoReplicated(ri, testLocation(), testAltLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -161,7 +160,7 @@ func TestAccAzureRMCosmosDBAccount_geoReplicated(t *testing.T) {
 }
 
 func testCheckAzureRMCosmosDBAccountDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).cosmosDBClient
+	conn := testAccProvider.Meta().(*ArmClient).cosmosDBClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_cosmos_db" {
@@ -199,7 +198,7 @@ func testCheckAzureRMCosmosDBAccountExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for CosmosDB Account: '%s'", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).cosmosDBClient
+		conn := testAccProvider.Meta().(*ArmClient).cosmosDBClient()
 
 		resp, err := conn.Get(resourceGroup, name)
 		if err != nil {
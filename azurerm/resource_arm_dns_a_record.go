@@ -54,13 +54,12 @@ func resourceArmDnsARecord() *schema.Resource {
 }
 
 func resourceArmDnsARecordCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
 	zoneName := d.Get("zone_name").(string)
 	ttl := int64(d.Get("ttl").(int))
-	tags := d.Get("tags").(map[string]interface{})
 
 	records, err := expandAzureRmDnsARecords(d)
 	if err != nil {
@@ -70,7 +69,7 @@ func resourceArmDnsARecordCreateOrUpdate(d *schema.ResourceData, meta interface{
 	parameters := dns.RecordSet{
 		Name: &name,
 		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata: expandTags(tags),
+			Metadata: expandTagsForResource(d, meta),
 			TTL:      &ttl,
 			ARecords: &records,
 		},
@@ -93,16 +92,16 @@ func resourceArmDnsARecordCreateOrUpdate(d *schema.ResourceData, meta interface{
 }
 
 func resourceArmDnsARecordRead(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "A")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["A"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, err := dnsClient.Get(resGroup, zoneName, name, dns.A)
 	if err != nil {
@@ -121,22 +120,22 @@ func resourceArmDnsARecordRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("records", flattenAzureRmDnsARecords(resp.ARecords)); err != nil {
 		return err
 	}
-	flattenAndSetTags(d, resp.Metadata)
+	flattenAndSetTags(d, resp.Metadata, meta)
 
 	return nil
 }
 
 func resourceArmDnsARecordDelete(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "A")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["A"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, error := dnsClient.Delete(resGroup, zoneName, name, dns.A, "")
 	if resp.StatusCode != http.StatusOK {
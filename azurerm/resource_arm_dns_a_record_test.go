@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/dns"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMDnsARecord_basic(t *testing.T) {
 	resourceName := "azurerm_dns_a_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsARecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMDnsARecord_basic(t *testing.T) {
 
 func TestAccAzureRMDnsARecord_updateRecords(t *testing.T) {
 	resourceName := "azurerm_dns_a_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsARecord_basic(ri, location)
 	postConfig := testAccAzureRMDnsARecord_updateRecords(ri, location)
@@ -63,7 +62,7 @@ func TestAccAzureRMDnsARecord_updateRecords(t *testing.T) {
 
 func TestAccAzureRMDnsARecord_withTags(t *testing.T) {
 	resourceName := "azurerm_dns_a_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsARecord_withTags(ri, location)
 	postConfig := testAccAzureRMDnsARecord_withTagsUpdate(ri, location)
@@ -106,7 +105,7 @@ func testCheckAzureRMDnsARecordExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for DNS A record: %s", aName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).dnsClient
+		conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 		resp, err := conn.Get(resourceGroup, zoneName, aName, dns.A)
 		if err != nil {
 			return fmt.Errorf("Bad: Get A RecordSet: %+v", err)
@@ -121,7 +120,7 @@ func testCheckAzureRMDnsARecordExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMDnsARecordDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).dnsClient
+	conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_dns_a_record" {
@@ -54,13 +54,12 @@ func resourceArmDnsAAAARecord() *schema.Resource {
 }
 
 func resourceArmDnsAaaaRecordCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).dnsClient
+	client := meta.(*ArmClient).dnsClient()
 
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
 	zoneName := d.Get("zone_name").(string)
 	ttl := int64(d.Get("ttl").(int))
-	tags := d.Get("tags").(map[string]interface{})
 
 	records, err := expandAzureRmDnsAaaaRecords(d)
 	if err != nil {
@@ -70,7 +69,7 @@ func resourceArmDnsAaaaRecordCreateOrUpdate(d *schema.ResourceData, meta interfa
 	parameters := dns.RecordSet{
 		Name: &name,
 		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:    expandTags(tags),
+			Metadata:    expandTagsForResource(d, meta),
 			TTL:         &ttl,
 			AaaaRecords: &records,
 		},
@@ -93,16 +92,16 @@ func resourceArmDnsAaaaRecordCreateOrUpdate(d *schema.ResourceData, meta interfa
 }
 
 func resourceArmDnsAaaaRecordRead(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "AAAA")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["AAAA"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, err := dnsClient.Get(resGroup, zoneName, name, dns.AAAA)
 	if err != nil {
@@ -121,22 +120,22 @@ func resourceArmDnsAaaaRecordRead(d *schema.ResourceData, meta interface{}) erro
 	if err := d.Set("records", flattenAzureRmDnsAaaaRecords(resp.AaaaRecords)); err != nil {
 		return err
 	}
-	flattenAndSetTags(d, resp.Metadata)
+	flattenAndSetTags(d, resp.Metadata, meta)
 
 	return nil
 }
 
 func resourceArmDnsAaaaRecordDelete(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "AAAA")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["AAAA"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, error := dnsClient.Delete(resGroup, zoneName, name, dns.AAAA, "")
 	if resp.StatusCode != http.StatusOK {
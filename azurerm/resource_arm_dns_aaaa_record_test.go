@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/dns"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMDnsAAAARecord_basic(t *testing.T) {
 	resourceName := "azurerm_dns_aaaa_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsAAAARecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMDnsAAAARecord_basic(t *testing.T) {
 
 func TestAccAzureRMDnsAAAARecord_updateRecords(t *testing.T) {
 	resourceName := "azurerm_dns_aaaa_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsAAAARecord_basic(ri, location)
 	postConfig := testAccAzureRMDnsAAAARecord_updateRecords(ri, location)
@@ -63,7 +62,7 @@ func TestAccAzureRMDnsAAAARecord_updateRecords(t *testing.T) {
 
 func TestAccAzureRMDnsAAAARecord_withTags(t *testing.T) {
 	resourceName := "azurerm_dns_aaaa_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsAAAARecord_withTags(ri, location)
 	postConfig := testAccAzureRMDnsAAAARecord_withTagsUpdate(ri, location)
@@ -106,7 +105,7 @@ func testCheckAzureRMDnsAaaaRecordExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for DNS AAAA record: %s", aaaaName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).dnsClient
+		conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 		resp, err := conn.Get(resourceGroup, zoneName, aaaaName, dns.AAAA)
 		if err != nil {
 			return fmt.Errorf("Bad: Get AAAA RecordSet: %+v", err)
@@ -121,7 +120,7 @@ func testCheckAzureRMDnsAaaaRecordExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMDnsAaaaRecordDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).dnsClient
+	conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_dns_aaaa_record" {
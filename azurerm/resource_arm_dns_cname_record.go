@@ -60,19 +60,18 @@ func resourceArmDnsCNameRecord() *schema.Resource {
 }
 
 func resourceArmDnsCNameRecordCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
 	zoneName := d.Get("zone_name").(string)
 	ttl := int64(d.Get("ttl").(int))
 	record := d.Get("record").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	parameters := dns.RecordSet{
 		Name: &name,
 		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata: expandTags(tags),
+			Metadata: expandTagsForResource(d, meta),
 			TTL:      &ttl,
 			CnameRecord: &dns.CnameRecord{
 				Cname: &record,
@@ -97,16 +96,16 @@ func resourceArmDnsCNameRecordCreateOrUpdate(d *schema.ResourceData, meta interf
 }
 
 func resourceArmDnsCNameRecordRead(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "CNAME")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["CNAME"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, err := dnsClient.Get(resGroup, zoneName, name, dns.CNAME)
 	if err != nil {
@@ -128,22 +127,22 @@ func resourceArmDnsCNameRecordRead(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	flattenAndSetTags(d, resp.Metadata)
+	flattenAndSetTags(d, resp.Metadata, meta)
 
 	return nil
 }
 
 func resourceArmDnsCNameRecordDelete(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "CNAME")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["CNAME"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, error := dnsClient.Delete(resGroup, zoneName, name, dns.CNAME, "")
 	if resp.StatusCode != http.StatusOK {
@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/dns"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMDnsCNameRecord_basic(t *testing.T) {
 	resourceName := "azurerm_dns_cname_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsCNameRecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMDnsCNameRecord_basic(t *testing.T) {
 
 func TestAccAzureRMDnsCNameRecord_subdomain(t *testing.T) {
 	resourceName := "azurerm_dns_cname_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsCNameRecord_subdomain(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -54,7 +53,7 @@ func TestAccAzureRMDnsCNameRecord_subdomain(t *testing.T) {
 
 func TestAccAzureRMDnsCNameRecord_updateRecords(t *testing.T) {
 	resourceName := "azurerm_dns_cname_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsCNameRecord_basic(ri, location)
 	postConfig := testAccAzureRMDnsCNameRecord_updateRecords(ri, location)
@@ -82,7 +81,7 @@ func TestAccAzureRMDnsCNameRecord_updateRecords(t *testing.T) {
 
 func TestAccAzureRMDnsCNameRecord_withTags(t *testing.T) {
 	resourceName := "azurerm_dns_cname_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsCNameRecord_withTags(ri, location)
 	postConfig := testAccAzureRMDnsCNameRecord_withTagsUpdate(ri, location)
@@ -125,7 +124,7 @@ func testCheckAzureRMDnsCNameRecordExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for DNS CNAME record: %s", cnameName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).dnsClient
+		conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 		resp, err := conn.Get(resourceGroup, zoneName, cnameName, dns.CNAME)
 		if err != nil {
 			return fmt.Errorf("Bad: Get CNAME RecordSet: %v", err)
@@ -140,7 +139,7 @@ func testCheckAzureRMDnsCNameRecordExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMDnsCNameRecordDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).dnsClient
+	conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_dns_cname_record" {
@@ -70,13 +70,12 @@ func resourceArmDnsMxRecord() *schema.Resource {
 }
 
 func resourceArmDnsMxRecordCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).dnsClient
+	client := meta.(*ArmClient).dnsClient()
 
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
 	zoneName := d.Get("zone_name").(string)
 	ttl := int64(d.Get("ttl").(int))
-	tags := d.Get("tags").(map[string]interface{})
 	records, err := expandAzureRmDnsMxRecords(d)
 	if err != nil {
 		return err
@@ -85,7 +84,7 @@ func resourceArmDnsMxRecordCreateOrUpdate(d *schema.ResourceData, meta interface
 	parameters := dns.RecordSet{
 		Name: &name,
 		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:  expandTags(tags),
+			Metadata:  expandTagsForResource(d, meta),
 			TTL:       &ttl,
 			MxRecords: &records,
 		},
@@ -108,16 +107,16 @@ func resourceArmDnsMxRecordCreateOrUpdate(d *schema.ResourceData, meta interface
 }
 
 func resourceArmDnsMxRecordRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).dnsClient
+	client := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "MX")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["MX"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, err := client.Get(resGroup, zoneName, name, dns.MX)
 	if err != nil {
@@ -136,22 +135,22 @@ func resourceArmDnsMxRecordRead(d *schema.ResourceData, meta interface{}) error
 	if err := d.Set("record", flattenAzureRmDnsMxRecords(resp.MxRecords)); err != nil {
 		return err
 	}
-	flattenAndSetTags(d, resp.Metadata)
+	flattenAndSetTags(d, resp.Metadata, meta)
 
 	return nil
 }
 
 func resourceArmDnsMxRecordDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).dnsClient
+	client := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "MX")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["MX"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, error := client.Delete(resGroup, zoneName, name, dns.MX, "")
 	if resp.StatusCode != http.StatusOK {
@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/dns"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMDnsMxRecord_basic(t *testing.T) {
 	resourceName := "azurerm_dns_mx_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsMxRecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMDnsMxRecord_basic(t *testing.T) {
 
 func TestAccAzureRMDnsMxRecord_updateRecords(t *testing.T) {
 	resourceName := "azurerm_dns_mx_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsMxRecord_basic(ri, location)
 	postConfig := testAccAzureRMDnsMxRecord_updateRecords(ri, location)
@@ -63,7 +62,7 @@ func TestAccAzureRMDnsMxRecord_updateRecords(t *testing.T) {
 
 func TestAccAzureRMDnsMxRecord_withTags(t *testing.T) {
 	resourceName := "azurerm_dns_mx_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsMxRecord_withTags(ri, location)
 	postConfig := testAccAzureRMDnsMxRecord_withTagsUpdate(ri, location)
@@ -106,7 +105,7 @@ func testCheckAzureRMDnsMxRecordExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for DNS MX record: %s", mxName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).dnsClient
+		conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 		resp, err := conn.Get(resourceGroup, zoneName, mxName, dns.MX)
 		if err != nil {
 			return fmt.Errorf("Bad: Get MX RecordSet: %+v", err)
@@ -121,7 +120,7 @@ func testCheckAzureRMDnsMxRecordExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMDnsMxRecordDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).dnsClient
+	conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_dns_mx_record" {
@@ -60,13 +60,12 @@ func resourceArmDnsNsRecord() *schema.Resource {
 }
 
 func resourceArmDnsNsRecordCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
 	zoneName := d.Get("zone_name").(string)
 	ttl := int64(d.Get("ttl").(int))
-	tags := d.Get("tags").(map[string]interface{})
 	records, err := expandAzureRmDnsNsRecords(d)
 	if err != nil {
 		return err
@@ -75,7 +74,7 @@ func resourceArmDnsNsRecordCreateOrUpdate(d *schema.ResourceData, meta interface
 	parameters := dns.RecordSet{
 		Name: &name,
 		RecordSetProperties: &dns.RecordSetProperties{
-			Metadata:  expandTags(tags),
+			Metadata:  expandTagsForResource(d, meta),
 			TTL:       &ttl,
 			NsRecords: &records,
 		},
@@ -98,16 +97,16 @@ func resourceArmDnsNsRecordCreateOrUpdate(d *schema.ResourceData, meta interface
 }
 
 func resourceArmDnsNsRecordRead(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "NS")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["NS"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, err := dnsClient.Get(resGroup, zoneName, name, dns.NS)
 	if err != nil {
@@ -127,22 +126,22 @@ func resourceArmDnsNsRecordRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	flattenAndSetTags(d, resp.Metadata)
+	flattenAndSetTags(d, resp.Metadata, meta)
 
 	return nil
 }
 
 func resourceArmDnsNsRecordDelete(d *schema.ResourceData, meta interface{}) error {
-	dnsClient := meta.(*ArmClient).dnsClient
+	dnsClient := meta.(*ArmClient).dnsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseDnsRecordID(d.Id(), "NS")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["NS"]
-	zoneName := id.Path["dnszones"]
+	name := id.Name
+	zoneName := id.ZoneName
 
 	resp, error := dnsClient.Delete(resGroup, zoneName, name, dns.NS, "")
 	if resp.StatusCode != http.StatusOK {
@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/dns"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMDnsNsRecord_basic(t *testing.T) {
 	resourceName := "azurerm_dns_ns_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsNsRecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMDnsNsRecord_basic(t *testing.T) {
 
 func TestAccAzureRMDnsNsRecord_updateRecords(t *testing.T) {
 	resourceName := "azurerm_dns_ns_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsNsRecord_basic(ri, location)
 	postConfig := testAccAzureRMDnsNsRecord_updateRecords(ri, location)
@@ -63,7 +62,7 @@ func TestAccAzureRMDnsNsRecord_updateRecords(t *testing.T) {
 
 func TestAccAzureRMDnsNsRecord_withTags(t *testing.T) {
 	resourceName := "azurerm_dns_ns_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsNsRecord_withTags(ri, location)
 	postConfig := testAccAzureRMDnsNsRecord_withTagsUpdate(ri, location)
@@ -106,7 +105,7 @@ func testCheckAzureRMDnsNsRecordExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for DNS NS record: %s", nsName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).dnsClient
+		conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 		resp, err := conn.Get(resourceGroup, zoneName, nsName, dns.NS)
 		if err != nil {
 			return fmt.Errorf("Bad: Get DNS NS Record: %+v", err)
@@ -121,7 +120,7 @@ func testCheckAzureRMDnsNsRecordExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMDnsNsRecordDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).dnsClient
+	conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_dns_ns_record" {
@@ -6,13 +6,12 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/dns"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMDnsPtrRecord_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsPtrRecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -31,7 +30,7 @@ func TestAccAzureRMDnsPtrRecord_basic(t *testing.T) {
 }
 
 func TestAccAzureRMDnsPtrRecord_updateRecords(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsPtrRecord_basic(ri, location)
 	postConfig := testAccAzureRMDnsPtrRecord_updateRecords(ri, location)
@@ -61,7 +60,7 @@ func TestAccAzureRMDnsPtrRecord_updateRecords(t *testing.T) {
 }
 
 func TestAccAzureRMDnsPtrRecord_withTags(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMDnsPtrRecord_withTags(ri, location)
 	postConfig := testAccAzureRMDnsPtrRecord_withTagsUpdate(ri, location)
@@ -106,7 +105,7 @@ func testCheckAzureRMDnsPtrRecordExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for DNS PTR record: %s", ptrName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).dnsClient
+		conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 		resp, err := conn.Get(resourceGroup, zoneName, ptrName, dns.PTR)
 		if err != nil {
 			return fmt.Errorf("Bad: Get PTR RecordSet: %+v", err)
@@ -121,7 +120,7 @@ func testCheckAzureRMDnsPtrRecordExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMDnsPtrRecordDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).dnsClient
+	conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_dns_ptr_record" {
@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/dns"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMDnsTxtRecord_basic(t *testing.T) {
 	resourceName := "azurerm_dns_txt_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMDnsTxtRecord_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMDnsTxtRecord_basic(t *testing.T) {
 
 func TestAccAzureRMDnsTxtRecord_updateRecords(t *testing.T) {
 	resourceName := "azurerm_dns_txt_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	preConfig := testAccAzureRMDnsTxtRecord_basic(ri, testLocation())
 	postConfig := testAccAzureRMDnsTxtRecord_updateRecords(ri, testLocation())
 
@@ -62,7 +61,7 @@ func TestAccAzureRMDnsTxtRecord_updateRecords(t *testing.T) {
 
 func TestAccAzureRMDnsTxtRecord_withTags(t *testing.T) {
 	resourceName := "azurerm_dns_txt_record.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	preConfig := testAccAzureRMDnsTxtRecord_withTags(ri, testLocation())
 	postConfig := testAccAzureRMDnsTxtRecord_withTagsUpdate(ri, testLocation())
 
@@ -104,7 +103,7 @@ func testCheckAzureRMDnsTxtRecordExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for DNS TXT record: %s", txtName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).dnsClient
+		conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 		resp, err := conn.Get(resourceGroup, zoneName, txtName, dns.TXT)
 		if err != nil {
 			return fmt.Errorf("Bad: Get TXT RecordSet: %+v", err)
@@ -119,7 +118,7 @@ func testCheckAzureRMDnsTxtRecordExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMDnsTxtRecordDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).dnsClient
+	conn := testAccProvider.Meta().(*ArmClient).dnsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_dns_txt_record" {
@@ -54,17 +54,15 @@ func resourceArmDnsZone() *schema.Resource {
 }
 
 func resourceArmDnsZoneCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).zonesClient
+	client := meta.(*ArmClient).zonesClient()
 
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
 	location := "global"
 
-	tags := d.Get("tags").(map[string]interface{})
-
 	parameters := dns.Zone{
 		Location: &location,
-		Tags:     expandTags(tags),
+		Tags:     expandTagsForResource(d, meta),
 	}
 
 	etag := ""
@@ -84,15 +82,15 @@ func resourceArmDnsZoneCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceArmDnsZoneRead(d *schema.ResourceData, meta interface{}) error {
-	zonesClient := meta.(*ArmClient).zonesClient
+	zonesClient := meta.(*ArmClient).zonesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/dnszones")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["dnszones"]
+	name := names[0]
 
 	resp, err := zonesClient.Get(resGroup, name)
 	if err != nil {
@@ -116,24 +114,26 @@ func resourceArmDnsZoneRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmDnsZoneDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).zonesClient
+	client := meta.(*ArmClient).zonesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/dnszones")
 	if err != nil {
 		return err
 	}
 
 	resGroup := id.ResourceGroup
-	name := id.Path["dnszones"]
+	name := names[0]
 
 	etag := ""
-	_, error := client.Delete(resGroup, name, etag, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := client.Delete(resGroup, name, etag, ctx.Done())
 	err = <-error
 
 	if err != nil {
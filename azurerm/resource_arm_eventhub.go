@@ -64,7 +64,7 @@ func resourceArmEventHub() *schema.Resource {
 
 func resourceArmEventHubCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	eventhubClient := client.eventHubClient
+	eventhubClient := client.eventHubClient()
 	log.Printf("[INFO] preparing arguments for Azure ARM EventHub creation.")
 
 	name := d.Get("name").(string)
@@ -102,15 +102,15 @@ func resourceArmEventHubCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceArmEventHubRead(d *schema.ResourceData, meta interface{}) error {
-	eventhubClient := meta.(*ArmClient).eventHubClient
+	eventhubClient := meta.(*ArmClient).eventHubClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.EventHub/namespaces/eventhubs")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	name := id.Path["eventhubs"]
+	namespaceName := names[0]
+	name := names[1]
 
 	resp, err := eventhubClient.Get(resGroup, namespaceName, name)
 	if err != nil {
@@ -134,15 +134,15 @@ func resourceArmEventHubRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceArmEventHubDelete(d *schema.ResourceData, meta interface{}) error {
-	eventhubClient := meta.(*ArmClient).eventHubClient
+	eventhubClient := meta.(*ArmClient).eventHubClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.EventHub/namespaces/eventhubs")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	name := id.Path["eventhubs"]
+	namespaceName := names[0]
+	name := names[1]
 
 	resp, err := eventhubClient.Delete(resGroup, namespaceName, name)
 
@@ -88,7 +88,7 @@ func resourceArmEventHubAuthorizationRule() *schema.Resource {
 }
 
 func resourceArmEventHubAuthorizationRuleCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).eventHubClient
+	client := meta.(*ArmClient).eventHubClient()
 	log.Printf("[INFO] preparing arguments for AzureRM EventHub Authorization Rule creation.")
 
 	name := d.Get("name").(string)
@@ -130,16 +130,16 @@ func resourceArmEventHubAuthorizationRuleCreateUpdate(d *schema.ResourceData, me
 }
 
 func resourceArmEventHubAuthorizationRuleRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).eventHubClient
+	client := meta.(*ArmClient).eventHubClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.EventHub/namespaces/eventhubs/authorizationRules")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	eventHubName := id.Path["eventhubs"]
-	name := id.Path["authorizationRules"]
+	namespaceName := names[0]
+	eventHubName := names[1]
+	name := names[2]
 
 	resp, err := client.GetAuthorizationRule(resGroup, namespaceName, eventHubName, name)
 	if err != nil {
@@ -172,16 +172,16 @@ func resourceArmEventHubAuthorizationRuleRead(d *schema.ResourceData, meta inter
 }
 
 func resourceArmEventHubAuthorizationRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	eventhubClient := meta.(*ArmClient).eventHubClient
+	eventhubClient := meta.(*ArmClient).eventHubClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.EventHub/namespaces/eventhubs/authorizationRules")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	eventHubName := id.Path["eventhubs"]
-	name := id.Path["authorizationRules"]
+	namespaceName := names[0]
+	eventHubName := names[1]
+	name := names[2]
 
 	resp, err := eventhubClient.DeleteAuthorizationRule(resGroup, namespaceName, eventHubName, name)
 
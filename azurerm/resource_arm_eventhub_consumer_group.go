@@ -56,7 +56,7 @@ func resourceArmEventHubConsumerGroup() *schema.Resource {
 
 func resourceArmEventHubConsumerGroupCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	eventhubClient := client.eventHubConsumerGroupClient
+	eventhubClient := client.eventHubConsumerGroupClient()
 	log.Printf("[INFO] preparing arguments for AzureRM EventHub Consumer Group creation.")
 
 	name := d.Get("name").(string)
@@ -95,16 +95,16 @@ func resourceArmEventHubConsumerGroupCreateUpdate(d *schema.ResourceData, meta i
 }
 
 func resourceArmEventHubConsumerGroupRead(d *schema.ResourceData, meta interface{}) error {
-	eventhubClient := meta.(*ArmClient).eventHubConsumerGroupClient
+	eventhubClient := meta.(*ArmClient).eventHubConsumerGroupClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.EventHub/namespaces/eventhubs/consumergroups")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	eventHubName := id.Path["eventhubs"]
-	name := id.Path["consumergroups"]
+	namespaceName := names[0]
+	eventHubName := names[1]
+	name := names[2]
 
 	resp, err := eventhubClient.Get(resGroup, namespaceName, eventHubName, name)
 	if err != nil {
@@ -126,16 +126,16 @@ func resourceArmEventHubConsumerGroupRead(d *schema.ResourceData, meta interface
 }
 
 func resourceArmEventHubConsumerGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	eventhubClient := meta.(*ArmClient).eventHubConsumerGroupClient
+	eventhubClient := meta.(*ArmClient).eventHubConsumerGroupClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.EventHub/namespaces/eventhubs/consumergroups")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	eventHubName := id.Path["eventhubs"]
-	name := id.Path["consumergroups"]
+	namespaceName := names[0]
+	eventHubName := names[1]
+	name := names[2]
 
 	resp, err := eventhubClient.Delete(resGroup, namespaceName, eventHubName, name)
 
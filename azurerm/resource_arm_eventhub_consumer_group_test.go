@@ -5,14 +5,13 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMEventHubConsumerGroup_basic(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHubConsumerGroup_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -32,7 +31,7 @@ func TestAccAzureRMEventHubConsumerGroup_basic(t *testing.T) {
 
 func TestAccAzureRMEventHubConsumerGroup_complete(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHubConsumerGroup_complete(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -51,7 +50,7 @@ func TestAccAzureRMEventHubConsumerGroup_complete(t *testing.T) {
 }
 
 func testCheckAzureRMEventHubConsumerGroupDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).eventHubConsumerGroupClient
+	conn := testAccProvider.Meta().(*ArmClient).eventHubConsumerGroupClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_eventhub_consumer_group" {
@@ -91,7 +90,7 @@ func testCheckAzureRMEventHubConsumerGroupExists(name string) resource.TestCheck
 			return fmt.Errorf("Bad: no resource group found in state for Event Hub Consumer Group: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).eventHubConsumerGroupClient
+		conn := testAccProvider.Meta().(*ArmClient).eventHubConsumerGroupClient()
 
 		namespaceName := rs.Primary.Attributes["namespace_name"]
 		eventHubName := rs.Primary.Attributes["eventhub_name"]
@@ -81,7 +81,7 @@ func resourceArmEventHubNamespace() *schema.Resource {
 
 func resourceArmEventHubNamespaceCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	namespaceClient := client.eventHubNamespacesClient
+	namespaceClient := client.eventHubNamespacesClient()
 	log.Printf("[INFO] preparing arguments for Azure ARM EventHub Namespace creation.")
 
 	name := d.Get("name").(string)
@@ -89,7 +89,6 @@ func resourceArmEventHubNamespaceCreate(d *schema.ResourceData, meta interface{}
 	resGroup := d.Get("resource_group_name").(string)
 	sku := d.Get("sku").(string)
 	capacity := int32(d.Get("capacity").(int))
-	tags := d.Get("tags").(map[string]interface{})
 
 	parameters := eventhub.NamespaceCreateOrUpdateParameters{
 		Location: &location,
@@ -98,12 +97,15 @@ func resourceArmEventHubNamespaceCreate(d *schema.ResourceData, meta interface{}
 			Tier:     eventhub.SkuTier(sku),
 			Capacity: &capacity,
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
-	_, error := namespaceClient.CreateOrUpdate(resGroup, name, parameters, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := namespaceClient.CreateOrUpdate(resGroup, name, parameters, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.EventHub/namespaces", name)
 		return err
 	}
 
@@ -122,14 +124,14 @@ func resourceArmEventHubNamespaceCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceArmEventHubNamespaceRead(d *schema.ResourceData, meta interface{}) error {
-	namespaceClient := meta.(*ArmClient).eventHubNamespacesClient
+	namespaceClient := meta.(*ArmClient).eventHubNamespacesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.EventHub/namespaces")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["namespaces"]
+	name := names[0]
 
 	resp, err := namespaceClient.Get(resGroup, name)
 	if err != nil {
@@ -156,22 +158,24 @@ func resourceArmEventHubNamespaceRead(d *schema.ResourceData, meta interface{})
 		d.Set("default_secondary_key", keys.SecondaryKey)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmEventHubNamespaceDelete(d *schema.ResourceData, meta interface{}) error {
-	namespaceClient := meta.(*ArmClient).eventHubNamespacesClient
+	namespaceClient := meta.(*ArmClient).eventHubNamespacesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.EventHub/namespaces")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["namespaces"]
+	name := names[0]
 
-	deleteResp, error := namespaceClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	deleteResp, error := namespaceClient.Delete(resGroup, name, ctx.Done())
 	resp := <-deleteResp
 	err = <-error
 
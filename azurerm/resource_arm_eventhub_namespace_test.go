@@ -6,7 +6,6 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -81,7 +80,7 @@ func TestAccAzureRMEventHubNamespaceSku_validation(t *testing.T) {
 
 func TestAccAzureRMEventHubNamespace_basic(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHubNamespace_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -101,7 +100,7 @@ func TestAccAzureRMEventHubNamespace_basic(t *testing.T) {
 
 func TestAccAzureRMEventHubNamespace_standard(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHubNamespace_standard(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -121,7 +120,7 @@ func TestAccAzureRMEventHubNamespace_standard(t *testing.T) {
 
 func TestAccAzureRMEventHubNamespace_readDefaultKeys(t *testing.T) {
 	resourceName := "azurerm_eventhub_namespace.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHubNamespace_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -145,7 +144,7 @@ func TestAccAzureRMEventHubNamespace_readDefaultKeys(t *testing.T) {
 
 func TestAccAzureRMEventHubNamespace_NonStandardCasing(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHubNamespaceNonStandardCasing(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -169,7 +168,7 @@ func TestAccAzureRMEventHubNamespace_NonStandardCasing(t *testing.T) {
 }
 
 func testCheckAzureRMEventHubNamespaceDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).eventHubNamespacesClient
+	conn := testAccProvider.Meta().(*ArmClient).eventHubNamespacesClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_eventhub_namespace" {
@@ -207,7 +206,7 @@ func testCheckAzureRMEventHubNamespaceExists(name string) resource.TestCheckFunc
 			return fmt.Errorf("Bad: no resource group found in state for Event Hub Namespace: %s", namespaceName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).eventHubNamespacesClient
+		conn := testAccProvider.Meta().(*ArmClient).eventHubNamespacesClient()
 
 		resp, err := conn.Get(resourceGroup, namespaceName)
 		if err != nil {
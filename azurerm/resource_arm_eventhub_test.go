@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -96,7 +95,7 @@ func TestAccAzureRMEventHubMessageRetentionCount_validation(t *testing.T) {
 
 func TestAccAzureRMEventHub_basic(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHub_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -116,7 +115,7 @@ func TestAccAzureRMEventHub_basic(t *testing.T) {
 
 func TestAccAzureRMEventHub_standard(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMEventHub_standard(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -135,7 +134,7 @@ func TestAccAzureRMEventHub_standard(t *testing.T) {
 }
 
 func testCheckAzureRMEventHubDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).eventHubClient
+	conn := testAccProvider.Meta().(*ArmClient).eventHubClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_eventhub" {
@@ -175,7 +174,7 @@ func testCheckAzureRMEventHubExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for Event Hub: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).eventHubClient
+		conn := testAccProvider.Meta().(*ArmClient).eventHubClient()
 
 		resp, err := conn.Get(resourceGroup, namespaceName, name)
 		if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"log"
 	"strings"
+	"time"
 
 	"fmt"
 
@@ -24,6 +25,13 @@ func resourceArmExpressRouteCircuit() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -112,7 +120,7 @@ func resourceArmExpressRouteCircuit() *schema.Resource {
 
 func resourceArmExpressRouteCircuitCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	ercClient := client.expressRouteCircuitClient
+	ercClient := client.expressRouteCircuitClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM ExpressRouteCircuit creation.")
 
@@ -124,8 +132,7 @@ func resourceArmExpressRouteCircuitCreateOrUpdate(d *schema.ResourceData, meta i
 	bandwidthInMbps := int32(d.Get("bandwidth_in_mbps").(int))
 	sku := expandExpressRouteCircuitSku(d)
 	allowRdfeOps := d.Get("allow_classic_operations").(bool)
-	tags := d.Get("tags").(map[string]interface{})
-	expandedTags := expandTags(tags)
+	expandedTags := expandTagsForResource(d, meta)
 
 	erc := network.ExpressRouteCircuit{
 		Name:     &name,
@@ -142,9 +149,12 @@ func resourceArmExpressRouteCircuitCreateOrUpdate(d *schema.ResourceData, meta i
 		Tags: expandedTags,
 	}
 
-	_, error := ercClient.CreateOrUpdate(resGroup, name, erc, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	_, error := ercClient.CreateOrUpdate(resGroup, name, erc, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/expressRouteCircuits", name)
 		return errwrap.Wrapf("Error Creating/Updating ExpressRouteCircuit {{err}}", err)
 	}
 
@@ -191,20 +201,22 @@ func resourceArmExpressRouteCircuitRead(d *schema.ResourceData, meta interface{}
 	d.Set("service_key", erc.ServiceKey)
 	d.Set("allow_classic_operations", erc.AllowClassicOperations)
 
-	flattenAndSetTags(d, erc.Tags)
+	flattenAndSetTags(d, erc.Tags, meta)
 
 	return nil
 }
 
 func resourceArmExpressRouteCircuitDelete(d *schema.ResourceData, meta interface{}) error {
-	ercClient := meta.(*ArmClient).expressRouteCircuitClient
+	ercClient := meta.(*ArmClient).expressRouteCircuitClient()
 
 	resGroup, name, err := extractResourceGroupAndErcName(d.Id())
 	if err != nil {
 		return errwrap.Wrapf("Error Parsing Azure Resource ID {{err}}", err)
 	}
 
-	_, error := ercClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := ercClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 	return err
 }
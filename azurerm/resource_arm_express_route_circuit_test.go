@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMExpressRouteCircuit_basic(t *testing.T) {
 	var erc network.ExpressRouteCircuit
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -43,7 +42,7 @@ func testCheckAzureRMExpressRouteCircuitExists(name string, erc *network.Express
 			return fmt.Errorf("Bad: no resource group found in state for Express Route Circuit: %s", expressRouteCircuitName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).expressRouteCircuitClient
+		conn := testAccProvider.Meta().(*ArmClient).expressRouteCircuitClient()
 
 		resp, err := conn.Get(resourceGroup, expressRouteCircuitName)
 		if err != nil {
@@ -61,7 +60,7 @@ func testCheckAzureRMExpressRouteCircuitExists(name string, erc *network.Express
 }
 
 func testCheckAzureRMExpressRouteCircuitDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).expressRouteCircuitClient
+	conn := testAccProvider.Meta().(*ArmClient).expressRouteCircuitClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_express_route_circuit" {
@@ -35,8 +35,9 @@ func resourceArmImage() *schema.Resource {
 			},
 
 			"source_virtual_machine_id": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Compute/virtualMachines"),
 			},
 
 			"os_disk": {
@@ -68,9 +69,10 @@ func resourceArmImage() *schema.Resource {
 						},
 
 						"managed_disk_id": {
-							Type:     schema.TypeString,
-							Computed: true,
-							Optional: true,
+							Type:         schema.TypeString,
+							Computed:     true,
+							Optional:     true,
+							ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Compute/disks"),
 						},
 
 						"blob_uri": {
@@ -113,9 +115,10 @@ func resourceArmImage() *schema.Resource {
 						},
 
 						"managed_disk_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ForceNew: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							ForceNew:     true,
+							ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Compute/disks"),
 						},
 
 						"blob_uri": {
@@ -152,15 +155,14 @@ func resourceArmImage() *schema.Resource {
 
 func resourceArmImageCreateUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	imageClient := client.imageClient
+	imageClient := client.imageClient()
 
 	log.Printf("[INFO] preparing arguments for AzureRM Image creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
-	expandedTags := expandTags(tags)
+	expandedTags := expandTagsForResource(d, meta)
 	properties := compute.ImageProperties{}
 
 	osDisk, err := expandAzureRmImageOsDisk(d)
@@ -210,9 +212,12 @@ func resourceArmImageCreateUpdate(d *schema.ResourceData, meta interface{}) erro
 		ImageProperties: &properties,
 	}
 
-	_, imageErr := imageClient.CreateOrUpdate(resGroup, name, createImage, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	_, imageErr := imageClient.CreateOrUpdate(resGroup, name, createImage, ctx.Done())
 	err = <-imageErr
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Compute/images", name)
 		return err
 	}
 
@@ -230,14 +235,14 @@ func resourceArmImageCreateUpdate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceArmImageRead(d *schema.ResourceData, meta interface{}) error {
-	imageClient := meta.(*ArmClient).imageClient
+	imageClient := meta.(*ArmClient).imageClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/images")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["images"]
+	name := names[0]
 
 	resp, err := imageClient.Get(resGroup, name, "")
 	if err != nil {
@@ -267,22 +272,24 @@ func resourceArmImageRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmImageDelete(d *schema.ResourceData, meta interface{}) error {
-	imageClient := meta.(*ArmClient).imageClient
+	imageClient := meta.(*ArmClient).imageClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/images")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["images"]
+	name := names[0]
 
-	_, deleteErr := imageClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, deleteErr := imageClient.Delete(resGroup, name, ctx.Done())
 	err = <-deleteErr
 	if err != nil {
 		return err
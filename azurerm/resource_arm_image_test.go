@@ -8,14 +8,13 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"golang.org/x/crypto/ssh"
 )
 
 func TestAccAzureRMImage_standaloneImage(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	resourceGroup := fmt.Sprintf("acctestRG-%d", ri)
 	userName := "testadmin"
 	password := "Password1234!"
@@ -50,7 +49,7 @@ func TestAccAzureRMImage_standaloneImage(t *testing.T) {
 }
 
 func TestAccAzureRMImage_customImageVMFromVHD(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	resourceGroup := fmt.Sprintf("acctestRG-%d", ri)
 	userName := "testadmin"
 	password := "Password1234!"
@@ -85,7 +84,7 @@ func TestAccAzureRMImage_customImageVMFromVHD(t *testing.T) {
 }
 
 func TestAccAzureRMImage_customImageVMFromVM(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	resourceGroup := fmt.Sprintf("acctestRG-%d", ri)
 	userName := "testadmin"
 	password := "Password1234!"
@@ -120,7 +119,7 @@ func TestAccAzureRMImage_customImageVMFromVM(t *testing.T) {
 }
 
 func TestAccAzureRMImageVMSS_customImageVMSSFromVHD(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	resourceGroup := fmt.Sprintf("acctestRG-%d", ri)
 	userName := "testadmin"
 	password := "Password1234!"
@@ -157,7 +156,7 @@ func TestAccAzureRMImageVMSS_customImageVMSSFromVHD(t *testing.T) {
 func testGeneralizeVMImage(resourceGroup string, vmName string, userName string, password string, hostName string, port string, location string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		armClient := testAccProvider.Meta().(*ArmClient)
-		vmClient := armClient.vmClient
+		vmClient := armClient.vmClient()
 
 		normalizedLocation := azureRMNormalizeLocation(location)
 		suffix := armClient.environment.ResourceManagerVMDNSSuffix
@@ -232,7 +231,7 @@ func testCheckAzureRMImageExists(name string, shouldExist bool) resource.TestChe
 			return fmt.Errorf("Bad: no resource group found in state for image: %s", dName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).imageClient
+		conn := testAccProvider.Meta().(*ArmClient).imageClient()
 
 		resp, err := conn.Get(resourceGroup, dName, "")
 		if err != nil {
@@ -254,7 +253,7 @@ func testCheckAzureVMExists(sourceVM string, shouldExist bool) resource.TestChec
 	return func(s *terraform.State) error {
 		log.Printf("[INFO] testing MANAGED IMAGE VM EXISTS - BEGIN.")
 
-		vmClient := testAccProvider.Meta().(*ArmClient).vmClient
+		vmClient := testAccProvider.Meta().(*ArmClient).vmClient()
 		vmRs, vmOk := s.RootModule().Resources[sourceVM]
 		if !vmOk {
 			return fmt.Errorf("VM Not found: %s", sourceVM)
@@ -288,7 +287,7 @@ func testCheckAzureVMSSExists(sourceVMSS string, shouldExist bool) resource.Test
 	return func(s *terraform.State) error {
 		log.Printf("[INFO] testing MANAGED IMAGE VMSS EXISTS - BEGIN.")
 
-		vmssClient := testAccProvider.Meta().(*ArmClient).vmScaleSetClient
+		vmssClient := testAccProvider.Meta().(*ArmClient).vmScaleSetClient()
 		vmRs, vmOk := s.RootModule().Resources[sourceVMSS]
 		if !vmOk {
 			return fmt.Errorf("VMSS Not found: %s", sourceVMSS)
@@ -319,7 +318,7 @@ func testCheckAzureVMSSExists(sourceVMSS string, shouldExist bool) resource.Test
 }
 
 func testCheckAzureRMImageDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).diskClient
+	conn := testAccProvider.Meta().(*ArmClient).diskClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_image" {
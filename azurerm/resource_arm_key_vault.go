@@ -148,7 +148,7 @@ func resourceArmKeyVault() *schema.Resource {
 }
 
 func resourceArmKeyVaultCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).keyVaultClient
+	client := meta.(*ArmClient).keyVaultClient()
 	log.Printf("[INFO] preparing arguments for Azure ARM KeyVault creation.")
 
 	name := d.Get("name").(string)
@@ -158,7 +158,6 @@ func resourceArmKeyVaultCreate(d *schema.ResourceData, meta interface{}) error {
 	enabledForDeployment := d.Get("enabled_for_deployment").(bool)
 	enabledForDiskEncryption := d.Get("enabled_for_disk_encryption").(bool)
 	enabledForTemplateDeployment := d.Get("enabled_for_template_deployment").(bool)
-	tags := d.Get("tags").(map[string]interface{})
 
 	parameters := keyvault.VaultCreateOrUpdateParameters{
 		Location: &location,
@@ -170,7 +169,7 @@ func resourceArmKeyVaultCreate(d *schema.ResourceData, meta interface{}) error {
 			EnabledForDiskEncryption:     &enabledForDiskEncryption,
 			EnabledForTemplateDeployment: &enabledForTemplateDeployment,
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
 	_, err := client.CreateOrUpdate(resGroup, name, parameters)
@@ -192,14 +191,14 @@ func resourceArmKeyVaultCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceArmKeyVaultRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).keyVaultClient
+	client := meta.(*ArmClient).keyVaultClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.KeyVault/vaults")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["vaults"]
+	name := names[0]
 
 	resp, err := client.Get(resGroup, name)
 	if err != nil {
@@ -221,20 +220,20 @@ func resourceArmKeyVaultRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("access_policy", flattenKeyVaultAccessPolicies(resp.Properties.AccessPolicies))
 	d.Set("vault_uri", resp.Properties.VaultURI)
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmKeyVaultDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).keyVaultClient
+	client := meta.(*ArmClient).keyVaultClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.KeyVault/vaults")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["vaults"]
+	name := names[0]
 
 	_, err = client.Delete(resGroup, name)
 
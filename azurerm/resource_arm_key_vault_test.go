@@ -5,13 +5,12 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMKeyVault_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMKeyVault_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -30,7 +29,7 @@ func TestAccAzureRMKeyVault_basic(t *testing.T) {
 }
 
 func TestAccAzureRMKeyVault_update(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	resourceName := "azurerm_key_vault.test"
 	preConfig := testAccAzureRMKeyVault_basic(ri, testLocation())
 	postConfig := testAccAzureRMKeyVault_update(ri, testLocation())
@@ -65,7 +64,7 @@ func TestAccAzureRMKeyVault_update(t *testing.T) {
 }
 
 func testCheckAzureRMKeyVaultDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*ArmClient).keyVaultClient
+	client := testAccProvider.Meta().(*ArmClient).keyVaultClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_key_vault" {
@@ -105,7 +104,7 @@ func testCheckAzureRMKeyVaultExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for vault: %s", vaultName)
 		}
 
-		client := testAccProvider.Meta().(*ArmClient).keyVaultClient
+		client := testAccProvider.Meta().(*ArmClient).keyVaultClient()
 
 		resp, err := client.Get(resourceGroup, vaultName)
 		if err != nil {
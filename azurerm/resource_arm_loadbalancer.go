@@ -3,7 +3,6 @@ package azurerm
 import (
 	"fmt"
 	"log"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/hashicorp/errwrap"
@@ -49,9 +48,10 @@ func resourceArmLoadBalancer() *schema.Resource {
 						},
 
 						"subnet_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/virtualNetworks/subnets"),
 						},
 
 						"private_ip_address": {
@@ -61,9 +61,10 @@ func resourceArmLoadBalancer() *schema.Resource {
 						},
 
 						"public_ip_address_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/publicIPAddresses"),
 						},
 
 						"private_ip_address_allocation": {
@@ -104,15 +105,14 @@ func resourceArmLoadBalancer() *schema.Resource {
 
 func resourceArmLoadBalancerCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	loadBalancerClient := client.loadBalancerClient
+	loadBalancerClient := client.loadBalancerClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM LoadBalancer creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
-	expandedTags := expandTags(tags)
+	expandedTags := expandTagsForResource(d, meta)
 
 	properties := network.LoadBalancerPropertiesFormat{}
 
@@ -127,9 +127,12 @@ func resourceArmLoadBalancerCreate(d *schema.ResourceData, meta interface{}) err
 		LoadBalancerPropertiesFormat: &properties,
 	}
 
-	_, error := loadBalancerClient.CreateOrUpdate(resGroup, name, loadbalancer, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := loadBalancerClient.CreateOrUpdate(resGroup, name, loadbalancer, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/loadBalancers", name)
 		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
 	}
 
@@ -148,9 +151,9 @@ func resourceArmLoadBalancerCreate(d *schema.ResourceData, meta interface{}) err
 		Pending: []string{"Accepted", "Updating"},
 		Target:  []string{"Succeeded"},
 		Refresh: loadbalancerStateRefreshFunc(client, resGroup, name),
-		Timeout: 10 * time.Minute,
+		Timeout: createOrUpdateTimeout(d),
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := meta.(*ArmClient).waitForState(stateConf); err != nil {
 		return fmt.Errorf("Error waiting for LoadBalancer (%s) to become available: %s", name, err)
 	}
 
@@ -191,22 +194,24 @@ func resourecArmLoadBalancerRead(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	flattenAndSetTags(d, loadBalancer.Tags)
+	flattenAndSetTags(d, loadBalancer.Tags, meta)
 
 	return nil
 }
 
 func resourceArmLoadBalancerDelete(d *schema.ResourceData, meta interface{}) error {
-	loadBalancerClient := meta.(*ArmClient).loadBalancerClient
+	loadBalancerClient := meta.(*ArmClient).loadBalancerClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/loadBalancers")
 	if err != nil {
 		return errwrap.Wrapf("Error Parsing Azure Resource ID {{err}}", err)
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["loadBalancers"]
+	name := names[0]
 
-	_, error := loadBalancerClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := loadBalancerClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 	if err != nil {
 		return errwrap.Wrapf("Error Deleting LoadBalancer {{err}}", err)
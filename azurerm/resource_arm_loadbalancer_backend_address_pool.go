@@ -3,7 +3,6 @@ package azurerm
 import (
 	"fmt"
 	"log"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/hashicorp/errwrap"
@@ -37,9 +36,10 @@ func resourceArmLoadBalancerBackendAddressPool() *schema.Resource {
 			},
 
 			"loadbalancer_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateResourceIDOfType("Microsoft.Network/loadBalancers"),
 			},
 
 			"backend_ip_configurations": {
@@ -61,49 +61,37 @@ func resourceArmLoadBalancerBackendAddressPool() *schema.Resource {
 
 func resourceArmLoadBalancerBackendAddressPoolCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
 
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
-
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
-	}
-	if !exists {
-		d.SetId("")
-		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", d.Get("name").(string))
-		return nil
-	}
-
-	backendAddressPools := append(*loadBalancer.LoadBalancerPropertiesFormat.BackendAddressPools, expandAzureRmLoadBalancerBackendAddressPools(d))
-	existingPool, existingPoolIndex, exists := findLoadBalancerBackEndAddressPoolByName(loadBalancer, d.Get("name").(string))
-	if exists {
-		if d.Get("name").(string) == *existingPool.Name {
-			// this pool is being updated/reapplied remove old copy from the slice
-			backendAddressPools = append(backendAddressPools[:existingPoolIndex], backendAddressPools[existingPoolIndex+1:]...)
-		}
-	}
-
-	loadBalancer.LoadBalancerPropertiesFormat.BackendAddressPools = &backendAddressPools
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
+	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
 	if err != nil {
 		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
 	}
 
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
-	}
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	read, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("Backend Address Pool %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		backendAddressPools := append(*loadBalancer.LoadBalancerPropertiesFormat.BackendAddressPools, expandAzureRmLoadBalancerBackendAddressPools(d))
+		existingPool, existingPoolIndex, exists := findLoadBalancerBackEndAddressPoolByName(loadBalancer, name)
+		if exists {
+			if name == *existingPool.Name {
+				// this pool is being updated/reapplied remove old copy from the slice
+				backendAddressPools = append(backendAddressPools[:existingPoolIndex], backendAddressPools[existingPoolIndex+1:]...)
+			}
+		}
 
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
+		loadBalancer.LoadBalancerPropertiesFormat.BackendAddressPools = &backendAddressPools
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/loadBalancers/backendAddressPools", loadBalancerName, name)
+		return err
 	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", name)
+		return nil
 	}
 
 	var pool_id string
@@ -124,9 +112,9 @@ func resourceArmLoadBalancerBackendAddressPoolCreate(d *schema.ResourceData, met
 		Pending: []string{"Accepted", "Updating"},
 		Target:  []string{"Succeeded"},
 		Refresh: loadbalancerStateRefreshFunc(client, resGroup, loadBalancerName),
-		Timeout: 10 * time.Minute,
+		Timeout: createOrUpdateTimeout(d),
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := meta.(*ArmClient).waitForState(stateConf); err != nil {
 		return fmt.Errorf("Error waiting for LoadBalancer (%s) to become available: %s", loadBalancerName, err)
 	}
 
@@ -134,11 +122,11 @@ func resourceArmLoadBalancerBackendAddressPoolCreate(d *schema.ResourceData, met
 }
 
 func resourceArmLoadBalancerBackendAddressPoolRead(d *schema.ResourceData, meta interface{}) error {
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseLoadBalancerChildID(d.Id(), "backendAddressPools")
 	if err != nil {
 		return err
 	}
-	name := id.Path["backendAddressPools"]
+	name := id.Name
 
 	loadBalancer, exists, err := retrieveLoadBalancerById(d.Get("loadbalancer_id").(string), meta)
 	if err != nil {
@@ -181,48 +169,27 @@ func resourceArmLoadBalancerBackendAddressPoolRead(d *schema.ResourceData, meta
 }
 
 func resourceArmLoadBalancerBackendAddressPoolDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
-
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
 
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("Backend Address Pool %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		_, index, exists := findLoadBalancerBackEndAddressPoolByName(loadBalancer, name)
+		if !exists {
+			return false, nil
+		}
+
+		oldBackEndPools := *loadBalancer.LoadBalancerPropertiesFormat.BackendAddressPools
+		newBackEndPools := append(oldBackEndPools[:index], oldBackEndPools[index+1:]...)
+		loadBalancer.LoadBalancerPropertiesFormat.BackendAddressPools = &newBackEndPools
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
+		return err
 	}
 	if !exists {
 		d.SetId("")
-		return nil
-	}
-
-	_, index, exists := findLoadBalancerBackEndAddressPoolByName(loadBalancer, d.Get("name").(string))
-	if !exists {
-		return nil
-	}
-
-	oldBackEndPools := *loadBalancer.LoadBalancerPropertiesFormat.BackendAddressPools
-	newBackEndPools := append(oldBackEndPools[:index], oldBackEndPools[index+1:]...)
-	loadBalancer.LoadBalancerPropertiesFormat.BackendAddressPools = &newBackEndPools
-
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
-	}
-
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
-	}
-
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
-	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
 	}
 
 	return nil
@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMLoadBalancerBackEndAddressPool_basic(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	addressPoolName := fmt.Sprintf("%d-address-pool", ri)
 
 	subscriptionID := os.Getenv("ARM_SUBSCRIPTION_ID")
@@ -41,7 +40,7 @@ func TestAccAzureRMLoadBalancerBackEndAddressPool_basic(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerBackEndAddressPool_removal(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	addressPoolName := fmt.Sprintf("%d-address-pool", ri)
 
 	resource.Test(t, resource.TestCase{
@@ -62,7 +61,7 @@ func TestAccAzureRMLoadBalancerBackEndAddressPool_removal(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerBackEndAddressPool_reapply(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	addressPoolName := fmt.Sprintf("%d-address-pool", ri)
 
 	deleteAddressPoolState := func(s *terraform.State) error {
@@ -96,7 +95,7 @@ func TestAccAzureRMLoadBalancerBackEndAddressPool_reapply(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerBackEndAddressPool_disappears(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	addressPoolName := fmt.Sprintf("%d-address-pool", ri)
 
 	resource.Test(t, resource.TestCase{
@@ -141,7 +140,7 @@ func testCheckAzureRMLoadBalancerBackEndAddressPoolNotExists(addressPoolName str
 
 func testCheckAzureRMLoadBalancerBackEndAddressPoolDisappears(addressPoolName string, lb *network.LoadBalancer) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient
+		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient()
 
 		_, i, exists := findLoadBalancerBackEndAddressPoolByName(lb, addressPoolName)
 		if !exists {
@@ -3,7 +3,6 @@ package azurerm
 import (
 	"fmt"
 	"log"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/hashicorp/errwrap"
@@ -38,9 +37,10 @@ func resourceArmLoadBalancerNatPool() *schema.Resource {
 			},
 
 			"loadbalancer_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateResourceIDOfType("Microsoft.Network/loadBalancers"),
 			},
 
 			"protocol": {
@@ -80,55 +80,43 @@ func resourceArmLoadBalancerNatPool() *schema.Resource {
 
 func resourceArmLoadBalancerNatPoolCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
 
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
-
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
-	}
-	if !exists {
-		d.SetId("")
-		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", d.Get("name").(string))
-		return nil
-	}
-
-	newNatPool, err := expandAzureRmLoadBalancerNatPool(d, loadBalancer)
-	if err != nil {
-		return errwrap.Wrapf("Error Expanding NAT Pool {{err}}", err)
+		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
 	}
 
-	natPools := append(*loadBalancer.LoadBalancerPropertiesFormat.InboundNatPools, *newNatPool)
-
-	existingNatPool, existingNatPoolIndex, exists := findLoadBalancerNatPoolByName(loadBalancer, d.Get("name").(string))
-	if exists {
-		if d.Get("name").(string) == *existingNatPool.Name {
-			// this probe is being updated/reapplied remove old copy from the slice
-			natPools = append(natPools[:existingNatPoolIndex], natPools[existingNatPoolIndex+1:]...)
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	read, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("NAT Pool %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		newNatPool, err := expandAzureRmLoadBalancerNatPool(d, loadBalancer)
+		if err != nil {
+			return false, errwrap.Wrapf("Error Expanding NAT Pool {{err}}", err)
 		}
-	}
 
-	loadBalancer.LoadBalancerPropertiesFormat.InboundNatPools = &natPools
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
-	}
+		natPools := append(*loadBalancer.LoadBalancerPropertiesFormat.InboundNatPools, *newNatPool)
 
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
-	}
+		existingNatPool, existingNatPoolIndex, exists := findLoadBalancerNatPoolByName(loadBalancer, name)
+		if exists {
+			if name == *existingNatPool.Name {
+				// this probe is being updated/reapplied remove old copy from the slice
+				natPools = append(natPools[:existingNatPoolIndex], natPools[existingNatPoolIndex+1:]...)
+			}
+		}
 
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
+		loadBalancer.LoadBalancerPropertiesFormat.InboundNatPools = &natPools
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/loadBalancers/inboundNatPools", loadBalancerName, name)
+		return err
 	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", name)
+		return nil
 	}
 
 	var natPool_id string
@@ -149,9 +137,9 @@ func resourceArmLoadBalancerNatPoolCreate(d *schema.ResourceData, meta interface
 		Pending: []string{"Accepted", "Updating"},
 		Target:  []string{"Succeeded"},
 		Refresh: loadbalancerStateRefreshFunc(client, resGroup, loadBalancerName),
-		Timeout: 10 * time.Minute,
+		Timeout: createOrUpdateTimeout(d),
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := meta.(*ArmClient).waitForState(stateConf); err != nil {
 		return fmt.Errorf("Error waiting for LoadBalancer (%s) to become available: %s", loadBalancerName, err)
 	}
 
@@ -159,11 +147,11 @@ func resourceArmLoadBalancerNatPoolCreate(d *schema.ResourceData, meta interface
 }
 
 func resourceArmLoadBalancerNatPoolRead(d *schema.ResourceData, meta interface{}) error {
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseLoadBalancerChildID(d.Id(), "inboundNatPools")
 	if err != nil {
 		return err
 	}
-	name := id.Path["inboundNatPools"]
+	name := id.Name
 
 	loadBalancer, exists, err := retrieveLoadBalancerById(d.Get("loadbalancer_id").(string), meta)
 	if err != nil {
@@ -190,12 +178,12 @@ func resourceArmLoadBalancerNatPoolRead(d *schema.ResourceData, meta interface{}
 	d.Set("backend_port", config.InboundNatPoolPropertiesFormat.BackendPort)
 
 	if config.InboundNatPoolPropertiesFormat.FrontendIPConfiguration != nil {
-		fipID, err := parseAzureResourceID(*config.InboundNatPoolPropertiesFormat.FrontendIPConfiguration.ID)
+		_, names, err := parseAzureResourceIDOfType(*config.InboundNatPoolPropertiesFormat.FrontendIPConfiguration.ID, "Microsoft.Network/loadBalancers/frontendIPConfigurations")
 		if err != nil {
 			return err
 		}
 
-		d.Set("frontend_ip_configuration_name", fipID.Path["frontendIPConfigurations"])
+		d.Set("frontend_ip_configuration_name", names[1])
 		d.Set("frontend_ip_configuration_id", config.InboundNatPoolPropertiesFormat.FrontendIPConfiguration.ID)
 	}
 
@@ -203,48 +191,27 @@ func resourceArmLoadBalancerNatPoolRead(d *schema.ResourceData, meta interface{}
 }
 
 func resourceArmLoadBalancerNatPoolDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
-
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
 
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("NAT Pool %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		_, index, exists := findLoadBalancerNatPoolByName(loadBalancer, name)
+		if !exists {
+			return false, nil
+		}
+
+		oldNatPools := *loadBalancer.LoadBalancerPropertiesFormat.InboundNatPools
+		newNatPools := append(oldNatPools[:index], oldNatPools[index+1:]...)
+		loadBalancer.LoadBalancerPropertiesFormat.InboundNatPools = &newNatPools
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
+		return err
 	}
 	if !exists {
 		d.SetId("")
-		return nil
-	}
-
-	_, index, exists := findLoadBalancerNatPoolByName(loadBalancer, d.Get("name").(string))
-	if !exists {
-		return nil
-	}
-
-	oldNatPools := *loadBalancer.LoadBalancerPropertiesFormat.InboundNatPools
-	newNatPools := append(oldNatPools[:index], oldNatPools[index+1:]...)
-	loadBalancer.LoadBalancerPropertiesFormat.InboundNatPools = &newNatPools
-
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
-	}
-
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
-	}
-
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
-	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
 	}
 
 	return nil
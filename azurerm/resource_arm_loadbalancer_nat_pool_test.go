@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMLoadBalancerNatPool_basic(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natPoolName := fmt.Sprintf("NatPool-%d", ri)
 
 	subscriptionID := os.Getenv("ARM_SUBSCRIPTION_ID")
@@ -41,7 +40,7 @@ func TestAccAzureRMLoadBalancerNatPool_basic(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerNatPool_removal(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natPoolName := fmt.Sprintf("NatPool-%d", ri)
 
 	resource.Test(t, resource.TestCase{
@@ -69,9 +68,9 @@ func TestAccAzureRMLoadBalancerNatPool_removal(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerNatPool_update(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natPoolName := fmt.Sprintf("NatPool-%d", ri)
-	natPool2Name := fmt.Sprintf("NatPool-%d", acctest.RandInt())
+	natPool2Name := fmt.Sprintf("NatPool-%d", testRandInt(t))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -102,7 +101,7 @@ func TestAccAzureRMLoadBalancerNatPool_update(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerNatPool_reapply(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natPoolName := fmt.Sprintf("NatPool-%d", ri)
 
 	deleteNatPoolState := func(s *terraform.State) error {
@@ -136,7 +135,7 @@ func TestAccAzureRMLoadBalancerNatPool_reapply(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerNatPool_disappears(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natPoolName := fmt.Sprintf("NatPool-%d", ri)
 
 	resource.Test(t, resource.TestCase{
@@ -181,7 +180,7 @@ func testCheckAzureRMLoadBalancerNatPoolNotExists(natPoolName string, lb *networ
 
 func testCheckAzureRMLoadBalancerNatPoolDisappears(natPoolName string, lb *network.LoadBalancer) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient
+		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient()
 
 		_, i, exists := findLoadBalancerNatPoolByName(lb, natPoolName)
 		if !exists {
@@ -3,7 +3,6 @@ package azurerm
 import (
 	"fmt"
 	"log"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/hashicorp/errwrap"
@@ -38,9 +37,10 @@ func resourceArmLoadBalancerNatRule() *schema.Resource {
 			},
 
 			"loadbalancer_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateResourceIDOfType("Microsoft.Network/loadBalancers"),
 			},
 
 			"protocol": {
@@ -80,55 +80,43 @@ func resourceArmLoadBalancerNatRule() *schema.Resource {
 
 func resourceArmLoadBalancerNatRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
 
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
-
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
-	}
-	if !exists {
-		d.SetId("")
-		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", d.Get("name").(string))
-		return nil
-	}
-
-	newNatRule, err := expandAzureRmLoadBalancerNatRule(d, loadBalancer)
-	if err != nil {
-		return errwrap.Wrapf("Error Expanding NAT Rule {{err}}", err)
+		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
 	}
 
-	natRules := append(*loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules, *newNatRule)
-
-	existingNatRule, existingNatRuleIndex, exists := findLoadBalancerNatRuleByName(loadBalancer, d.Get("name").(string))
-	if exists {
-		if d.Get("name").(string) == *existingNatRule.Name {
-			// this probe is being updated/reapplied remove old copy from the slice
-			natRules = append(natRules[:existingNatRuleIndex], natRules[existingNatRuleIndex+1:]...)
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	read, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("NAT Rule %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		newNatRule, err := expandAzureRmLoadBalancerNatRule(d, loadBalancer)
+		if err != nil {
+			return false, errwrap.Wrapf("Error Expanding NAT Rule {{err}}", err)
 		}
-	}
 
-	loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules = &natRules
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
-	}
+		natRules := append(*loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules, *newNatRule)
 
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating / Updating LoadBalancer {{err}}", err)
-	}
+		existingNatRule, existingNatRuleIndex, exists := findLoadBalancerNatRuleByName(loadBalancer, name)
+		if exists {
+			if name == *existingNatRule.Name {
+				// this probe is being updated/reapplied remove old copy from the slice
+				natRules = append(natRules[:existingNatRuleIndex], natRules[existingNatRuleIndex+1:]...)
+			}
+		}
 
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
+		loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules = &natRules
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/loadBalancers/inboundNatRules", loadBalancerName, name)
+		return err
 	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", name)
+		return nil
 	}
 
 	var natRule_id string
@@ -149,9 +137,9 @@ func resourceArmLoadBalancerNatRuleCreate(d *schema.ResourceData, meta interface
 		Pending: []string{"Accepted", "Updating"},
 		Target:  []string{"Succeeded"},
 		Refresh: loadbalancerStateRefreshFunc(client, resGroup, loadBalancerName),
-		Timeout: 10 * time.Minute,
+		Timeout: createOrUpdateTimeout(d),
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := meta.(*ArmClient).waitForState(stateConf); err != nil {
 		return fmt.Errorf("Error waiting for LoadBalancer (%s) to become available: %s", loadBalancerName, err)
 	}
 
@@ -159,11 +147,11 @@ func resourceArmLoadBalancerNatRuleCreate(d *schema.ResourceData, meta interface
 }
 
 func resourceArmLoadBalancerNatRuleRead(d *schema.ResourceData, meta interface{}) error {
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseLoadBalancerChildID(d.Id(), "inboundNatRules")
 	if err != nil {
 		return err
 	}
-	name := id.Path["inboundNatRules"]
+	name := id.Name
 
 	loadBalancer, exists, err := retrieveLoadBalancerById(d.Get("loadbalancer_id").(string), meta)
 	if err != nil {
@@ -189,12 +177,12 @@ func resourceArmLoadBalancerNatRuleRead(d *schema.ResourceData, meta interface{}
 	d.Set("backend_port", config.InboundNatRulePropertiesFormat.BackendPort)
 
 	if config.InboundNatRulePropertiesFormat.FrontendIPConfiguration != nil {
-		fipID, err := parseAzureResourceID(*config.InboundNatRulePropertiesFormat.FrontendIPConfiguration.ID)
+		_, names, err := parseAzureResourceIDOfType(*config.InboundNatRulePropertiesFormat.FrontendIPConfiguration.ID, "Microsoft.Network/loadBalancers/frontendIPConfigurations")
 		if err != nil {
 			return err
 		}
 
-		d.Set("frontend_ip_configuration_name", fipID.Path["frontendIPConfigurations"])
+		d.Set("frontend_ip_configuration_name", names[1])
 		d.Set("frontend_ip_configuration_id", config.InboundNatRulePropertiesFormat.FrontendIPConfiguration.ID)
 	}
 
@@ -206,48 +194,27 @@ func resourceArmLoadBalancerNatRuleRead(d *schema.ResourceData, meta interface{}
 }
 
 func resourceArmLoadBalancerNatRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
-
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
 
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("NAT Rule %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		_, index, exists := findLoadBalancerNatRuleByName(loadBalancer, name)
+		if !exists {
+			return false, nil
+		}
+
+		oldNatRules := *loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules
+		newNatRules := append(oldNatRules[:index], oldNatRules[index+1:]...)
+		loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules = &newNatRules
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
+		return err
 	}
 	if !exists {
 		d.SetId("")
-		return nil
-	}
-
-	_, index, exists := findLoadBalancerNatRuleByName(loadBalancer, d.Get("name").(string))
-	if !exists {
-		return nil
-	}
-
-	oldNatRules := *loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules
-	newNatRules := append(oldNatRules[:index], oldNatRules[index+1:]...)
-	loadBalancer.LoadBalancerPropertiesFormat.InboundNatRules = &newNatRules
-
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
-	}
-
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
-	}
-
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
-	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
 	}
 
 	return nil
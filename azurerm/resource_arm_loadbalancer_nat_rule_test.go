@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMLoadBalancerNatRule_basic(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natRuleName := fmt.Sprintf("NatRule-%d", ri)
 
 	subscriptionID := os.Getenv("ARM_SUBSCRIPTION_ID")
@@ -41,7 +40,7 @@ func TestAccAzureRMLoadBalancerNatRule_basic(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerNatRule_removal(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natRuleName := fmt.Sprintf("NatRule-%d", ri)
 
 	resource.Test(t, resource.TestCase{
@@ -69,9 +68,9 @@ func TestAccAzureRMLoadBalancerNatRule_removal(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerNatRule_update(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natRuleName := fmt.Sprintf("NatRule-%d", ri)
-	natRule2Name := fmt.Sprintf("NatRule-%d", acctest.RandInt())
+	natRule2Name := fmt.Sprintf("NatRule-%d", testRandInt(t))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -104,7 +103,7 @@ func TestAccAzureRMLoadBalancerNatRule_update(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerNatRule_reapply(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natRuleName := fmt.Sprintf("NatRule-%d", ri)
 
 	deleteNatRuleState := func(s *terraform.State) error {
@@ -138,7 +137,7 @@ func TestAccAzureRMLoadBalancerNatRule_reapply(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerNatRule_disappears(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	natRuleName := fmt.Sprintf("NatRule-%d", ri)
 
 	resource.Test(t, resource.TestCase{
@@ -183,7 +182,7 @@ func testCheckAzureRMLoadBalancerNatRuleNotExists(natRuleName string, lb *networ
 
 func testCheckAzureRMLoadBalancerNatRuleDisappears(natRuleName string, lb *network.LoadBalancer) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient
+		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient()
 
 		_, i, exists := findLoadBalancerNatRuleByName(lb, natRuleName)
 		if !exists {
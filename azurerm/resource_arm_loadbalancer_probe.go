@@ -3,7 +3,6 @@ package azurerm
 import (
 	"fmt"
 	"log"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/hashicorp/errwrap"
@@ -38,9 +37,10 @@ func resourceArmLoadBalancerProbe() *schema.Resource {
 			},
 
 			"loadbalancer_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateResourceIDOfType("Microsoft.Network/loadBalancers"),
 			},
 
 			"protocol": {
@@ -85,55 +85,43 @@ func resourceArmLoadBalancerProbe() *schema.Resource {
 
 func resourceArmLoadBalancerProbeCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
 
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
-
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
-	}
-	if !exists {
-		d.SetId("")
-		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", d.Get("name").(string))
-		return nil
-	}
-
-	newProbe, err := expandAzureRmLoadBalancerProbe(d, loadBalancer)
-	if err != nil {
-		return errwrap.Wrapf("Error Expanding Probe {{err}}", err)
+		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
 	}
 
-	probes := append(*loadBalancer.LoadBalancerPropertiesFormat.Probes, *newProbe)
-
-	existingProbe, existingProbeIndex, exists := findLoadBalancerProbeByName(loadBalancer, d.Get("name").(string))
-	if exists {
-		if d.Get("name").(string) == *existingProbe.Name {
-			// this probe is being updated/reapplied remove old copy from the slice
-			probes = append(probes[:existingProbeIndex], probes[existingProbeIndex+1:]...)
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	read, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("Probe %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		newProbe, err := expandAzureRmLoadBalancerProbe(d, loadBalancer)
+		if err != nil {
+			return false, errwrap.Wrapf("Error Expanding Probe {{err}}", err)
 		}
-	}
 
-	loadBalancer.LoadBalancerPropertiesFormat.Probes = &probes
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
-	}
+		probes := append(*loadBalancer.LoadBalancerPropertiesFormat.Probes, *newProbe)
 
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
-	}
+		existingProbe, existingProbeIndex, exists := findLoadBalancerProbeByName(loadBalancer, name)
+		if exists {
+			if name == *existingProbe.Name {
+				// this probe is being updated/reapplied remove old copy from the slice
+				probes = append(probes[:existingProbeIndex], probes[existingProbeIndex+1:]...)
+			}
+		}
 
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
+		loadBalancer.LoadBalancerPropertiesFormat.Probes = &probes
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/loadBalancers/probes", loadBalancerName, name)
+		return err
 	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", name)
+		return nil
 	}
 
 	var createdProbe_id string
@@ -154,9 +142,9 @@ func resourceArmLoadBalancerProbeCreate(d *schema.ResourceData, meta interface{}
 		Pending: []string{"Accepted", "Updating"},
 		Target:  []string{"Succeeded"},
 		Refresh: loadbalancerStateRefreshFunc(client, resGroup, loadBalancerName),
-		Timeout: 10 * time.Minute,
+		Timeout: createOrUpdateTimeout(d),
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := meta.(*ArmClient).waitForState(stateConf); err != nil {
 		return fmt.Errorf("Error waiting for LoadBalancer (%s) to become available: %s", loadBalancerName, err)
 	}
 
@@ -164,11 +152,11 @@ func resourceArmLoadBalancerProbeCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceArmLoadBalancerProbeRead(d *schema.ResourceData, meta interface{}) error {
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseLoadBalancerChildID(d.Id(), "probes")
 	if err != nil {
 		return err
 	}
-	name := id.Path["probes"]
+	name := id.Name
 
 	loadBalancer, exists, err := retrieveLoadBalancerById(d.Get("loadbalancer_id").(string), meta)
 	if err != nil {
@@ -207,48 +195,27 @@ func resourceArmLoadBalancerProbeRead(d *schema.ResourceData, meta interface{})
 }
 
 func resourceArmLoadBalancerProbeDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
-
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
 
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("Probe %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		_, index, exists := findLoadBalancerProbeByName(loadBalancer, name)
+		if !exists {
+			return false, nil
+		}
+
+		oldProbes := *loadBalancer.LoadBalancerPropertiesFormat.Probes
+		newProbes := append(oldProbes[:index], oldProbes[index+1:]...)
+		loadBalancer.LoadBalancerPropertiesFormat.Probes = &newProbes
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
+		return err
 	}
 	if !exists {
 		d.SetId("")
-		return nil
-	}
-
-	_, index, exists := findLoadBalancerProbeByName(loadBalancer, d.Get("name").(string))
-	if !exists {
-		return nil
-	}
-
-	oldProbes := *loadBalancer.LoadBalancerPropertiesFormat.Probes
-	newProbes := append(oldProbes[:index], oldProbes[index+1:]...)
-	loadBalancer.LoadBalancerPropertiesFormat.Probes = &newProbes
-
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
-	}
-
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
-	}
-
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
-	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
 	}
 
 	return nil
@@ -6,14 +6,13 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMLoadBalancerProbe_basic(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	probeName := fmt.Sprintf("probe-%d", ri)
 
 	subscriptionID := os.Getenv("ARM_SUBSCRIPTION_ID")
@@ -41,7 +40,7 @@ func TestAccAzureRMLoadBalancerProbe_basic(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerProbe_removal(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	probeName := fmt.Sprintf("probe-%d", ri)
 	location := testLocation()
 
@@ -70,9 +69,9 @@ func TestAccAzureRMLoadBalancerProbe_removal(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerProbe_update(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	probeName := fmt.Sprintf("probe-%d", ri)
-	probe2Name := fmt.Sprintf("probe-%d", acctest.RandInt())
+	probe2Name := fmt.Sprintf("probe-%d", testRandInt(t))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -103,7 +102,7 @@ func TestAccAzureRMLoadBalancerProbe_update(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerProbe_updateProtocol(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	probeName := fmt.Sprintf("probe-%d", ri)
 
 	resource.Test(t, resource.TestCase{
@@ -133,7 +132,7 @@ func TestAccAzureRMLoadBalancerProbe_updateProtocol(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerProbe_reapply(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	probeName := fmt.Sprintf("probe-%d", ri)
 
 	deleteProbeState := func(s *terraform.State) error {
@@ -167,7 +166,7 @@ func TestAccAzureRMLoadBalancerProbe_reapply(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerProbe_disappears(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	probeName := fmt.Sprintf("probe-%d", ri)
 
 	resource.Test(t, resource.TestCase{
@@ -212,7 +211,7 @@ func testCheckAzureRMLoadBalancerProbeNotExists(natRuleName string, lb *network.
 
 func testCheckAzureRMLoadBalancerProbeDisappears(addressPoolName string, lb *network.LoadBalancer) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient
+		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient()
 
 		_, i, exists := findLoadBalancerProbeByName(lb, addressPoolName)
 		if !exists {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 	"regexp"
-	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/hashicorp/errwrap"
@@ -40,9 +39,10 @@ func resourceArmLoadBalancerRule() *schema.Resource {
 			},
 
 			"loadbalancer_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateResourceIDOfType("Microsoft.Network/loadBalancers"),
 			},
 
 			"frontend_ip_configuration_name": {
@@ -56,9 +56,10 @@ func resourceArmLoadBalancerRule() *schema.Resource {
 			},
 
 			"backend_address_pool_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/loadBalancers/backendAddressPools"),
 			},
 
 			"protocol": {
@@ -79,9 +80,10 @@ func resourceArmLoadBalancerRule() *schema.Resource {
 			},
 
 			"probe_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/loadBalancers/probes"),
 			},
 
 			"enable_floating_ip": {
@@ -107,55 +109,43 @@ func resourceArmLoadBalancerRule() *schema.Resource {
 
 func resourceArmLoadBalancerRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
 
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
-
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(loadBalancerID)
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
-	}
-	if !exists {
-		d.SetId("")
-		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", d.Get("name").(string))
-		return nil
-	}
-
-	newLbRule, err := expandAzureRmLoadBalancerRule(d, loadBalancer)
-	if err != nil {
-		return errwrap.Wrapf("Error Exanding LoadBalancer Rule {{err}}", err)
+		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
 	}
 
-	lbRules := append(*loadBalancer.LoadBalancerPropertiesFormat.LoadBalancingRules, *newLbRule)
-
-	existingRule, existingRuleIndex, exists := findLoadBalancerRuleByName(loadBalancer, d.Get("name").(string))
-	if exists {
-		if d.Get("name").(string) == *existingRule.Name {
-			// this rule is being updated/reapplied remove old copy from the slice
-			lbRules = append(lbRules[:existingRuleIndex], lbRules[existingRuleIndex+1:]...)
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	read, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("Rule %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		newLbRule, err := expandAzureRmLoadBalancerRule(d, loadBalancer)
+		if err != nil {
+			return false, errwrap.Wrapf("Error Exanding LoadBalancer Rule {{err}}", err)
 		}
-	}
 
-	loadBalancer.LoadBalancerPropertiesFormat.LoadBalancingRules = &lbRules
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
-	}
+		lbRules := append(*loadBalancer.LoadBalancerPropertiesFormat.LoadBalancingRules, *newLbRule)
 
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
-	}
+		existingRule, existingRuleIndex, exists := findLoadBalancerRuleByName(loadBalancer, name)
+		if exists {
+			if name == *existingRule.Name {
+				// this rule is being updated/reapplied remove old copy from the slice
+				lbRules = append(lbRules[:existingRuleIndex], lbRules[existingRuleIndex+1:]...)
+			}
+		}
 
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
+		loadBalancer.LoadBalancerPropertiesFormat.LoadBalancingRules = &lbRules
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/loadBalancers/loadBalancingRules", loadBalancerName, name)
+		return err
 	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
+	if !exists {
+		d.SetId("")
+		log.Printf("[INFO] LoadBalancer %q not found. Removing from state", name)
+		return nil
 	}
 
 	var rule_id string
@@ -176,9 +166,9 @@ func resourceArmLoadBalancerRuleCreate(d *schema.ResourceData, meta interface{})
 		Pending: []string{"Accepted", "Updating"},
 		Target:  []string{"Succeeded"},
 		Refresh: loadbalancerStateRefreshFunc(client, resGroup, loadBalancerName),
-		Timeout: 10 * time.Minute,
+		Timeout: createOrUpdateTimeout(d),
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := meta.(*ArmClient).waitForState(stateConf); err != nil {
 		return fmt.Errorf("Error waiting for LoadBalancer (%s) to become available: %s", loadBalancerName, err)
 	}
 
@@ -186,11 +176,11 @@ func resourceArmLoadBalancerRuleCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceArmLoadBalancerRuleRead(d *schema.ResourceData, meta interface{}) error {
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseLoadBalancerChildID(d.Id(), "loadBalancingRules")
 	if err != nil {
 		return err
 	}
-	name := id.Path["loadBalancingRules"]
+	name := id.Name
 
 	loadBalancer, exists, err := retrieveLoadBalancerById(d.Get("loadbalancer_id").(string), meta)
 	if err != nil {
@@ -225,12 +215,12 @@ func resourceArmLoadBalancerRuleRead(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if config.LoadBalancingRulePropertiesFormat.FrontendIPConfiguration != nil {
-		fipID, err := parseAzureResourceID(*config.LoadBalancingRulePropertiesFormat.FrontendIPConfiguration.ID)
+		_, names, err := parseAzureResourceIDOfType(*config.LoadBalancingRulePropertiesFormat.FrontendIPConfiguration.ID, "Microsoft.Network/loadBalancers/frontendIPConfigurations")
 		if err != nil {
 			return err
 		}
 
-		d.Set("frontend_ip_configuration_name", fipID.Path["frontendIPConfigurations"])
+		d.Set("frontend_ip_configuration_name", names[1])
 		d.Set("frontend_ip_configuration_id", config.LoadBalancingRulePropertiesFormat.FrontendIPConfiguration.ID)
 	}
 
@@ -250,48 +240,27 @@ func resourceArmLoadBalancerRuleRead(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceArmLoadBalancerRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	lbClient := client.loadBalancerClient
-
+	name := d.Get("name").(string)
 	loadBalancerID := d.Get("loadbalancer_id").(string)
-	armMutexKV.Lock(loadBalancerID)
-	defer armMutexKV.Unlock(loadBalancerID)
 
-	loadBalancer, exists, err := retrieveLoadBalancerById(loadBalancerID, meta)
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, exists, err := updateLoadBalancer(ctx, meta, loadBalancerID, fmt.Sprintf("Rule %q", name), func(loadBalancer *network.LoadBalancer) (bool, error) {
+		_, index, exists := findLoadBalancerRuleByName(loadBalancer, name)
+		if !exists {
+			return false, nil
+		}
+
+		oldLbRules := *loadBalancer.LoadBalancerPropertiesFormat.LoadBalancingRules
+		newLbRules := append(oldLbRules[:index], oldLbRules[index+1:]...)
+		loadBalancer.LoadBalancerPropertiesFormat.LoadBalancingRules = &newLbRules
+		return true, nil
+	})
 	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer By ID {{err}}", err)
+		return err
 	}
 	if !exists {
 		d.SetId("")
-		return nil
-	}
-
-	_, index, exists := findLoadBalancerRuleByName(loadBalancer, d.Get("name").(string))
-	if !exists {
-		return nil
-	}
-
-	oldLbRules := *loadBalancer.LoadBalancerPropertiesFormat.LoadBalancingRules
-	newLbRules := append(oldLbRules[:index], oldLbRules[index+1:]...)
-	loadBalancer.LoadBalancerPropertiesFormat.LoadBalancingRules = &newLbRules
-
-	resGroup, loadBalancerName, err := resourceGroupAndLBNameFromId(d.Get("loadbalancer_id").(string))
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer Name and Group: {{err}}", err)
-	}
-
-	_, error := lbClient.CreateOrUpdate(resGroup, loadBalancerName, *loadBalancer, make(chan struct{}))
-	err = <-error
-	if err != nil {
-		return errwrap.Wrapf("Error Creating/Updating LoadBalancer {{err}}", err)
-	}
-
-	read, err := lbClient.Get(resGroup, loadBalancerName, "")
-	if err != nil {
-		return errwrap.Wrapf("Error Getting LoadBalancer {{err}}", err)
-	}
-	if read.ID == nil {
-		return fmt.Errorf("Cannot read LoadBalancer %s (resource group %s) ID", loadBalancerName, resGroup)
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package azurerm
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
@@ -29,7 +30,7 @@ func TestResourceAzureRMLoadBalancerRuleNameLabel_validation(t *testing.T) {
 			ErrCount: 1,
 		},
 		{
-			Value:    acctest.RandStringFromCharSet(81, "abcdedfed"),
+			Value:    testRandStringFromCharSet(t, 81, "abcdedfed"),
 			ErrCount: 1,
 		},
 		{
@@ -69,8 +70,8 @@ func TestResourceAzureRMLoadBalancerRuleNameLabel_validation(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerRule_basic(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
-	lbRuleName := fmt.Sprintf("LbRule-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlpha))
+	ri := testRandInt(t)
+	lbRuleName := fmt.Sprintf("LbRule-%s", testRandStringFromCharSet(t, 8, acctest.CharSetAlpha))
 
 	subscriptionID := os.Getenv("ARM_SUBSCRIPTION_ID")
 	lbRule_id := fmt.Sprintf(
@@ -97,8 +98,8 @@ func TestAccAzureRMLoadBalancerRule_basic(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerRule_removal(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
-	lbRuleName := fmt.Sprintf("LbRule-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlpha))
+	ri := testRandInt(t)
+	lbRuleName := fmt.Sprintf("LbRule-%s", testRandStringFromCharSet(t, 8, acctest.CharSetAlpha))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -126,10 +127,10 @@ func TestAccAzureRMLoadBalancerRule_removal(t *testing.T) {
 // https://github.com/hashicorp/terraform/issues/9424
 func TestAccAzureRMLoadBalancerRule_inconsistentReads(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
-	backendPoolName := fmt.Sprintf("LbPool-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlpha))
-	lbRuleName := fmt.Sprintf("LbRule-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlpha))
-	probeName := fmt.Sprintf("LbProbe-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlpha))
+	ri := testRandInt(t)
+	backendPoolName := fmt.Sprintf("LbPool-%s", testRandStringFromCharSet(t, 8, acctest.CharSetAlpha))
+	lbRuleName := fmt.Sprintf("LbRule-%s", testRandStringFromCharSet(t, 8, acctest.CharSetAlpha))
+	probeName := fmt.Sprintf("LbProbe-%s", testRandStringFromCharSet(t, 8, acctest.CharSetAlpha))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -151,9 +152,9 @@ func TestAccAzureRMLoadBalancerRule_inconsistentReads(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerRule_update(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
-	lbRuleName := fmt.Sprintf("LbRule-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlpha))
-	lbRule2Name := fmt.Sprintf("LbRule-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlpha))
+	ri := testRandInt(t)
+	lbRuleName := fmt.Sprintf("LbRule-%s", testRandStringFromCharSet(t, 8, acctest.CharSetAlpha))
+	lbRule2Name := fmt.Sprintf("LbRule-%s", testRandStringFromCharSet(t, 8, acctest.CharSetAlpha))
 
 	subscriptionID := os.Getenv("ARM_SUBSCRIPTION_ID")
 	lbRuleID := fmt.Sprintf(
@@ -199,8 +200,8 @@ func TestAccAzureRMLoadBalancerRule_update(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerRule_reapply(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
-	lbRuleName := fmt.Sprintf("LbRule-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlpha))
+	ri := testRandInt(t)
+	lbRuleName := fmt.Sprintf("LbRule-%s", testRandStringFromCharSet(t, 8, acctest.CharSetAlpha))
 
 	deleteRuleState := func(s *terraform.State) error {
 		return s.Remove("azurerm_lb_rule.test")
@@ -233,8 +234,8 @@ func TestAccAzureRMLoadBalancerRule_reapply(t *testing.T) {
 
 func TestAccAzureRMLoadBalancerRule_disappears(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
-	lbRuleName := fmt.Sprintf("LbRule-%s", acctest.RandStringFromCharSet(8, acctest.CharSetAlpha))
+	ri := testRandInt(t)
+	lbRuleName := fmt.Sprintf("LbRule-%s", testRandStringFromCharSet(t, 8, acctest.CharSetAlpha))
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -278,7 +279,7 @@ func testCheckAzureRMLoadBalancerRuleNotExists(lbRuleName string, lb *network.Lo
 
 func testCheckAzureRMLoadBalancerRuleDisappears(ruleName string, lb *network.LoadBalancer) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient
+		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient()
 
 		_, i, exists := findLoadBalancerRuleByName(lb, ruleName)
 		if !exists {
@@ -525,3 +526,65 @@ resource "azurerm_lb_rule" "test2" {
 }
 `, rInt, location, rInt, rInt, rInt, lbRuleName, rInt, lbRule2Name, rInt)
 }
+
+func TestResourceAzureRMLoadBalancerRule_fakeArm(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+	server.operationPolls = 2
+
+	loadBalancerID := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/loadBalancers/example", fakeArmSubscriptionID)
+	server.put(loadBalancerID, map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"frontendIPConfigurations": []interface{}{
+				map[string]interface{}{"name": "public"},
+			},
+			"loadBalancingRules": []interface{}{},
+		},
+	})
+
+	meta := server.armClient(t)
+	r := resourceArmLoadBalancerRule()
+	state, err := createResource(t, r, map[string]interface{}{
+		"name":                           "http",
+		"resource_group_name":            "example",
+		"loadbalancer_id":                loadBalancerID,
+		"frontend_ip_configuration_name": "public",
+		"protocol":                       "Tcp",
+		"frontend_port":                  80,
+		"backend_port":                   8080,
+	}, meta)
+	if err != nil {
+		t.Fatalf("Error creating the Load Balancer Rule: %s", err)
+	}
+	if expected := loadBalancerID + "/loadBalancingRules/http"; state.ID != expected {
+		t.Fatalf("Expected the ID %q but got %q", expected, state.ID)
+	}
+	if expected := loadBalancerID + "/frontendIPConfigurations/public"; state.Attributes["frontend_ip_configuration_id"] != expected {
+		t.Fatalf("Expected the Frontend IP Configuration ID %q but got %q", expected, state.Attributes["frontend_ip_configuration_id"])
+	}
+	if count := server.requestCount("GET", "/fakeOperations/1"); count != 2 {
+		t.Fatalf("Expected the update of the Load Balancer to be polled twice but got %d", count)
+	}
+
+	if err := destroyResource(r, state, meta); err != nil {
+		t.Fatalf("Error deleting the Load Balancer Rule: %s", err)
+	}
+	loadBalancer, _ := server.get(loadBalancerID)
+	if rules := loadBalancer["properties"].(map[string]interface{})["loadBalancingRules"].([]interface{}); len(rules) != 0 {
+		t.Fatalf("Expected the Load Balancer Rule to be removed but got %+v", rules)
+	}
+
+	// once the Load Balancer's gone, the rule should be removed from the state
+	server.lock.Lock()
+	delete(server.resources, strings.ToLower(loadBalancerID))
+	server.lock.Unlock()
+
+	state, err = r.Refresh(state, meta)
+	if err != nil {
+		t.Fatalf("Error reading the Load Balancer Rule: %s", err)
+	}
+	if state != nil {
+		t.Fatalf("Expected the Load Balancer Rule to be removed from the state but got %q", state.ID)
+	}
+}
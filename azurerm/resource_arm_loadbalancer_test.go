@@ -6,7 +6,6 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -49,7 +48,7 @@ func TestResourceAzureRMLoadBalancerPrivateIpAddressAllocation_validation(t *tes
 
 func TestAccAzureRMLoadBalancer_basic(t *testing.T) {
 	var lb network.LoadBalancer
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -69,7 +68,7 @@ func TestAccAzureRMLoadBalancer_basic(t *testing.T) {
 func TestAccAzureRMLoadBalancer_frontEndConfig(t *testing.T) {
 	var lb network.LoadBalancer
 	resourceName := "azurerm_lb.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 
 	resource.Test(t, resource.TestCase{
@@ -98,7 +97,7 @@ func TestAccAzureRMLoadBalancer_frontEndConfig(t *testing.T) {
 func TestAccAzureRMLoadBalancer_tags(t *testing.T) {
 	var lb network.LoadBalancer
 	resourceName := "azurerm_lb.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 
 	resource.Test(t, resource.TestCase{
@@ -140,7 +139,7 @@ func testCheckAzureRMLoadBalancerExists(name string, lb *network.LoadBalancer) r
 			return fmt.Errorf("Bad: no resource group found in state for loadbalancer: %s", loadbalancerName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient
+		conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient()
 
 		resp, err := conn.Get(resourceGroup, loadbalancerName, "")
 		if err != nil {
@@ -158,7 +157,7 @@ func testCheckAzureRMLoadBalancerExists(name string, lb *network.LoadBalancer) r
 }
 
 func testCheckAzureRMLoadBalancerDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient
+	conn := testAccProvider.Meta().(*ArmClient).loadBalancerClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_lb" {
@@ -50,7 +50,7 @@ func resourceArmLocalNetworkGateway() *schema.Resource {
 }
 
 func resourceArmLocalNetworkGatewayCreate(d *schema.ResourceData, meta interface{}) error {
-	lnetClient := meta.(*ArmClient).localNetConnClient
+	lnetClient := meta.(*ArmClient).localNetConnClient()
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
@@ -74,9 +74,12 @@ func resourceArmLocalNetworkGatewayCreate(d *schema.ResourceData, meta interface
 		},
 	}
 
-	_, error := lnetClient.CreateOrUpdate(resGroup, name, gateway, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := lnetClient.CreateOrUpdate(resGroup, name, gateway, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/localNetworkGateways", name)
 		return fmt.Errorf("Error creating Azure ARM Local Network Gateway '%s': %s", name, err)
 	}
 
@@ -95,13 +98,13 @@ func resourceArmLocalNetworkGatewayCreate(d *schema.ResourceData, meta interface
 
 // resourceArmLocalNetworkGatewayRead goes ahead and reads the state of the corresponding ARM local network gateway.
 func resourceArmLocalNetworkGatewayRead(d *schema.ResourceData, meta interface{}) error {
-	lnetClient := meta.(*ArmClient).localNetConnClient
+	lnetClient := meta.(*ArmClient).localNetConnClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/localNetworkGateways")
 	if err != nil {
 		return err
 	}
-	name := id.Path["localNetworkGateways"]
+	name := names[0]
 	if name == "" {
 		return fmt.Errorf("Cannot find 'localNetworkGateways' in '%s', make sure it is specified in the ID parameter", d.Id())
 	}
@@ -132,16 +135,18 @@ func resourceArmLocalNetworkGatewayRead(d *schema.ResourceData, meta interface{}
 
 // resourceArmLocalNetworkGatewayDelete deletes the specified ARM local network gateway.
 func resourceArmLocalNetworkGatewayDelete(d *schema.ResourceData, meta interface{}) error {
-	lnetClient := meta.(*ArmClient).localNetConnClient
+	lnetClient := meta.(*ArmClient).localNetConnClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/localNetworkGateways")
 	if err != nil {
 		return err
 	}
-	name := id.Path["localNetworkGateways"]
+	name := names[0]
 	resGroup := id.ResourceGroup
 
-	deleteResp, error := lnetClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	deleteResp, error := lnetClient.Delete(resGroup, name, ctx.Done())
 	resp := <-deleteResp
 	err = <-error
 
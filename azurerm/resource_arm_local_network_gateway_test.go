@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -13,7 +12,7 @@ import (
 func TestAccAzureRMLocalNetworkGateway_basic(t *testing.T) {
 	name := "azurerm_local_network_gateway.test"
 
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -33,7 +32,7 @@ func TestAccAzureRMLocalNetworkGateway_basic(t *testing.T) {
 
 func TestAccAzureRMLocalNetworkGateway_disappears(t *testing.T) {
 	name := "azurerm_local_network_gateway.test"
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -66,15 +65,15 @@ func testCheckAzureRMLocalNetworkGatewayExists(name string) resource.TestCheckFu
 		}
 
 		// then, extract the name and the resource group:
-		id, err := parseAzureResourceID(res.Primary.ID)
+		id, names, err := parseAzureResourceIDOfType(res.Primary.ID, "Microsoft.Network/localNetworkGateways")
 		if err != nil {
 			return err
 		}
-		localNetName := id.Path["localNetworkGateways"]
+		localNetName := names[0]
 		resGrp := id.ResourceGroup
 
 		// and finally, check that it exists on Azure:
-		lnetClient := testAccProvider.Meta().(*ArmClient).localNetConnClient
+		lnetClient := testAccProvider.Meta().(*ArmClient).localNetConnClient()
 
 		resp, err := lnetClient.Get(resGrp, localNetName)
 		if err != nil {
@@ -97,15 +96,15 @@ func testCheckAzureRMLocalNetworkGatewayDisappears(name string) resource.TestChe
 		}
 
 		// then, extract the name and the resource group:
-		id, err := parseAzureResourceID(res.Primary.ID)
+		id, names, err := parseAzureResourceIDOfType(res.Primary.ID, "Microsoft.Network/localNetworkGateways")
 		if err != nil {
 			return err
 		}
-		localNetName := id.Path["localNetworkGateways"]
+		localNetName := names[0]
 		resGrp := id.ResourceGroup
 
 		// and finally, check that it exists on Azure:
-		lnetClient := testAccProvider.Meta().(*ArmClient).localNetConnClient
+		lnetClient := testAccProvider.Meta().(*ArmClient).localNetConnClient()
 
 		deleteResp, error := lnetClient.Delete(resGrp, localNetName, make(chan struct{}))
 		resp := <-deleteResp
@@ -127,14 +126,14 @@ func testCheckAzureRMLocalNetworkGatewayDestroy(s *terraform.State) error {
 			continue
 		}
 
-		id, err := parseAzureResourceID(res.Primary.ID)
+		id, names, err := parseAzureResourceIDOfType(res.Primary.ID, "Microsoft.Network/localNetworkGateways")
 		if err != nil {
 			return err
 		}
-		localNetName := id.Path["localNetworkGateways"]
+		localNetName := names[0]
 		resGrp := id.ResourceGroup
 
-		lnetClient := testAccProvider.Meta().(*ArmClient).localNetConnClient
+		lnetClient := testAccProvider.Meta().(*ArmClient).localNetConnClient()
 		resp, err := lnetClient.Get(resGrp, localNetName)
 
 		if err != nil {
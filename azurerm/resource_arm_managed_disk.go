@@ -64,9 +64,10 @@ func resourceArmManagedDisk() *schema.Resource {
 			},
 
 			"source_resource_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateResourceIDOrEmpty,
 			},
 
 			"os_type": {
@@ -100,15 +101,14 @@ func validateDiskSizeGB(v interface{}, k string) (ws []string, errors []error) {
 
 func resourceArmManagedDiskCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	diskClient := client.diskClient
+	diskClient := client.diskClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM Managed Disk creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
-	expandedTags := expandTags(tags)
+	expandedTags := expandTagsForResource(d, meta)
 
 	createDisk := disk.Model{
 		Name:     &name,
@@ -150,9 +150,12 @@ func resourceArmManagedDiskCreate(d *schema.ResourceData, meta interface{}) erro
 
 	createDisk.CreationData = creationData
 
-	_, diskErr := diskClient.CreateOrUpdate(resGroup, name, createDisk, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, diskErr := diskClient.CreateOrUpdate(resGroup, name, createDisk, ctx.Done())
 	err := <-diskErr
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Compute/disks", name)
 		return err
 	}
 
@@ -170,14 +173,14 @@ func resourceArmManagedDiskCreate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceArmManagedDiskRead(d *schema.ResourceData, meta interface{}) error {
-	diskClient := meta.(*ArmClient).diskClient
+	diskClient := meta.(*ArmClient).diskClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/disks")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["disks"]
+	name := names[0]
 
 	resp, err := diskClient.Get(resGroup, name)
 	if err != nil {
@@ -200,22 +203,24 @@ func resourceArmManagedDiskRead(d *schema.ResourceData, meta interface{}) error
 		flattenAzureRmManagedDiskCreationData(d, resp.CreationData)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmManagedDiskDelete(d *schema.ResourceData, meta interface{}) error {
-	diskClient := meta.(*ArmClient).diskClient
+	diskClient := meta.(*ArmClient).diskClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/disks")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["disks"]
+	name := names[0]
 
-	_, error := diskClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := diskClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 	if err != nil {
 		return err
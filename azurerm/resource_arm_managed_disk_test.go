@@ -7,14 +7,13 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/arm/compute"
 	"github.com/Azure/azure-sdk-for-go/arm/disk"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMManagedDisk_empty(t *testing.T) {
 	var d disk.Model
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMManagedDisk_empty(ri, testLocation())
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -34,7 +33,7 @@ func TestAccAzureRMManagedDisk_empty(t *testing.T) {
 func TestAccAzureRMManagedDisk_import(t *testing.T) {
 	var d disk.Model
 	var vm compute.VirtualMachine
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	vmConfig := testAccAzureRMVirtualMachine_basicLinuxMachine(ri, location)
 	config := testAccAzureRMManagedDisk_import(ri, location)
@@ -65,7 +64,7 @@ func TestAccAzureRMManagedDisk_import(t *testing.T) {
 
 func TestAccAzureRMManagedDisk_copy(t *testing.T) {
 	var d disk.Model
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMManagedDisk_copy(ri, testLocation())
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -86,7 +85,7 @@ func TestAccAzureRMManagedDisk_update(t *testing.T) {
 	var d disk.Model
 
 	resourceName := "azurerm_managed_disk.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	preConfig := testAccAzureRMManagedDisk_empty(ri, testLocation())
 	postConfig := testAccAzureRMManagedDisk_empty_updated(ri, testLocation())
 	resource.Test(t, resource.TestCase{
@@ -121,7 +120,7 @@ func TestAccAzureRMManagedDisk_update(t *testing.T) {
 
 func TestAccAzureRMManagedDisk_NonStandardCasing(t *testing.T) {
 	var d disk.Model
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMManagedDiskNonStandardCasing(ri, testLocation())
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -156,7 +155,7 @@ func testCheckAzureRMManagedDiskExists(name string, d *disk.Model, shouldExist b
 			return fmt.Errorf("Bad: no resource group found in state for disk: %s", dName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).diskClient
+		conn := testAccProvider.Meta().(*ArmClient).diskClient()
 
 		resp, err := conn.Get(resourceGroup, dName)
 		if err != nil {
@@ -177,7 +176,7 @@ func testCheckAzureRMManagedDiskExists(name string, d *disk.Model, shouldExist b
 }
 
 func testCheckAzureRMManagedDiskDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).diskClient
+	conn := testAccProvider.Meta().(*ArmClient).diskClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_managed_disk" {
@@ -214,7 +213,7 @@ func testDeleteAzureRMVirtualMachine(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for virtual machine: %s", vmName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).vmClient
+		conn := testAccProvider.Meta().(*ArmClient).vmClient()
 
 		_, error := conn.Delete(resourceGroup, vmName, make(chan struct{}))
 		err := <-error
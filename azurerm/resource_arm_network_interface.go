@@ -37,9 +37,10 @@ func resourceArmNetworkInterface() *schema.Resource {
 			},
 
 			"network_security_group_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/networkSecurityGroups"),
 			},
 
 			"mac_address": {
@@ -54,9 +55,10 @@ func resourceArmNetworkInterface() *schema.Resource {
 			},
 
 			"virtual_machine_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Compute/virtualMachines"),
 			},
 
 			"ip_configuration": {
@@ -70,8 +72,9 @@ func resourceArmNetworkInterface() *schema.Resource {
 						},
 
 						"subnet_id": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateResourceIDOfType("Microsoft.Network/virtualNetworks/subnets"),
 						},
 
 						"private_ip_address": {
@@ -89,25 +92,32 @@ func resourceArmNetworkInterface() *schema.Resource {
 						},
 
 						"public_ip_address_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/publicIPAddresses"),
 						},
 
 						"load_balancer_backend_address_pools_ids": {
 							Type:     schema.TypeSet,
 							Optional: true,
 							Computed: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-							Set:      schema.HashString,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateResourceIDOfType("Microsoft.Network/loadBalancers/backendAddressPools"),
+							},
+							Set: schema.HashString,
 						},
 
 						"load_balancer_inbound_nat_rules_ids": {
 							Type:     schema.TypeSet,
 							Optional: true,
 							Computed: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
-							Set:      schema.HashString,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateResourceIDOfType("Microsoft.Network/loadBalancers/inboundNatRules"),
+							},
+							Set: schema.HashString,
 						},
 					},
 				},
@@ -155,7 +165,7 @@ func resourceArmNetworkInterface() *schema.Resource {
 
 func resourceArmNetworkInterfaceCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	ifaceClient := client.ifaceClient
+	ifaceClient := client.ifaceClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM Network Interface creation.")
 
@@ -163,25 +173,16 @@ func resourceArmNetworkInterfaceCreate(d *schema.ResourceData, meta interface{})
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
 	enableIpForwarding := d.Get("enable_ip_forwarding").(bool)
-	tags := d.Get("tags").(map[string]interface{})
 
 	properties := network.InterfacePropertiesFormat{
 		EnableIPForwarding: &enableIpForwarding,
 	}
 
-	if v, ok := d.GetOk("network_security_group_id"); ok {
-		nsgId := v.(string)
+	nsgId := d.Get("network_security_group_id").(string)
+	if nsgId != "" {
 		properties.NetworkSecurityGroup = &network.SecurityGroup{
 			ID: &nsgId,
 		}
-
-		networkSecurityGroupName, err := parseNetworkSecurityGroupName(nsgId)
-		if err != nil {
-			return err
-		}
-
-		azureRMLockByName(networkSecurityGroupName, networkSecurityGroupResourceName)
-		defer azureRMUnlockByName(networkSecurityGroupName, networkSecurityGroupResourceName)
 	}
 
 	dns, hasDns := d.GetOk("dns_servers")
@@ -207,16 +208,13 @@ func resourceArmNetworkInterfaceCreate(d *schema.ResourceData, meta interface{})
 		properties.DNSSettings = &ifaceDnsSettings
 	}
 
-	ipConfigs, subnetnToLock, vnnToLock, sgErr := expandAzureRmNetworkInterfaceIpConfigurations(d)
+	ipConfigs, idsToLock, sgErr := expandAzureRmNetworkInterfaceIpConfigurations(d)
 	if sgErr != nil {
 		return fmt.Errorf("Error Building list of Network Interface IP Configurations: %s", sgErr)
 	}
 
-	azureRMLockMultipleByName(subnetnToLock, subnetResourceName)
-	defer azureRMUnlockMultipleByName(subnetnToLock, subnetResourceName)
-
-	azureRMLockMultipleByName(vnnToLock, virtualNetworkResourceName)
-	defer azureRMUnlockMultipleByName(vnnToLock, virtualNetworkResourceName)
+	unlock := lockResourceIDs(append(idsToLock, nsgId)...)
+	defer unlock()
 
 	if len(ipConfigs) > 0 {
 		properties.IPConfigurations = &ipConfigs
@@ -226,12 +224,15 @@ func resourceArmNetworkInterfaceCreate(d *schema.ResourceData, meta interface{})
 		Name:                      &name,
 		Location:                  &location,
 		InterfacePropertiesFormat: &properties,
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
-	_, error := ifaceClient.CreateOrUpdate(resGroup, name, iface, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := ifaceClient.CreateOrUpdate(resGroup, name, iface, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/networkInterfaces", name)
 		return err
 	}
 
@@ -249,14 +250,14 @@ func resourceArmNetworkInterfaceCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceArmNetworkInterfaceRead(d *schema.ResourceData, meta interface{}) error {
-	ifaceClient := meta.(*ArmClient).ifaceClient
+	ifaceClient := meta.(*ArmClient).ifaceClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/networkInterfaces")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["networkInterfaces"]
+	name := names[0]
 
 	resp, err := ifaceClient.Get(resGroup, name, "")
 	if err != nil {
@@ -330,58 +331,32 @@ func resourceArmNetworkInterfaceRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("dns_servers", dnsServers)
 	d.Set("enable_ip_forwarding", resp.EnableIPForwarding)
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmNetworkInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
-	ifaceClient := meta.(*ArmClient).ifaceClient
+	ifaceClient := meta.(*ArmClient).ifaceClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/networkInterfaces")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["networkInterfaces"]
-
-	if v, ok := d.GetOk("network_security_group_id"); ok {
-		networkSecurityGroupId := v.(string)
-		networkSecurityGroupName, err := parseNetworkSecurityGroupName(networkSecurityGroupId)
-		if err != nil {
-			return err
-		}
+	name := names[0]
 
-		azureRMLockByName(networkSecurityGroupName, networkSecurityGroupResourceName)
-		defer azureRMUnlockByName(networkSecurityGroupName, networkSecurityGroupResourceName)
+	idsToLock := []string{d.Get("network_security_group_id").(string)}
+	for _, configRaw := range d.Get("ip_configuration").(*schema.Set).List() {
+		idsToLock = append(idsToLock, networkInterfaceIpConfigurationIdsToLock(configRaw.(map[string]interface{}))...)
 	}
 
-	configs := d.Get("ip_configuration").(*schema.Set).List()
-	subnetNamesToLock := make([]string, 0)
-	virtualNetworkNamesToLock := make([]string, 0)
+	unlock := lockResourceIDs(idsToLock...)
+	defer unlock()
 
-	for _, configRaw := range configs {
-		data := configRaw.(map[string]interface{})
-
-		subnet_id := data["subnet_id"].(string)
-		subnetId, err := parseAzureResourceID(subnet_id)
-		if err != nil {
-			return err
-		}
-		subnetName := subnetId.Path["subnets"]
-		subnetNamesToLock = append(subnetNamesToLock, subnetName)
-
-		virtualNetworkName := subnetId.Path["virtualNetworks"]
-		virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, virtualNetworkName)
-	}
-
-	azureRMLockMultipleByName(&subnetNamesToLock, subnetResourceName)
-	defer azureRMUnlockMultipleByName(&subnetNamesToLock, subnetResourceName)
-
-	azureRMLockMultipleByName(&virtualNetworkNamesToLock, virtualNetworkResourceName)
-	defer azureRMUnlockMultipleByName(&virtualNetworkNamesToLock, virtualNetworkResourceName)
-
-	_, error := ifaceClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := ifaceClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 
 	return err
@@ -465,11 +440,12 @@ func flattenNetworkInterfaceIPConfigurations(ipConfigs *[]network.InterfaceIPCon
 	return result
 }
 
-func expandAzureRmNetworkInterfaceIpConfigurations(d *schema.ResourceData) ([]network.InterfaceIPConfiguration, *[]string, *[]string, error) {
+// expandAzureRmNetworkInterfaceIpConfigurations returns the IP Configurations, along with the
+// IDs of the Virtual Networks of their Subnets (which are locked while the NIC is modified).
+func expandAzureRmNetworkInterfaceIpConfigurations(d *schema.ResourceData) ([]network.InterfaceIPConfiguration, []string, error) {
 	configs := d.Get("ip_configuration").(*schema.Set).List()
 	ipConfigs := make([]network.InterfaceIPConfiguration, 0, len(configs))
-	subnetNamesToLock := make([]string, 0)
-	virtualNetworkNamesToLock := make([]string, 0)
+	idsToLock := make([]string, 0)
 
 	for _, configRaw := range configs {
 		data := configRaw.(map[string]interface{})
@@ -484,7 +460,7 @@ func expandAzureRmNetworkInterfaceIpConfigurations(d *schema.ResourceData) ([]ne
 		case "static":
 			allocationMethod = network.Static
 		default:
-			return []network.InterfaceIPConfiguration{}, nil, nil, fmt.Errorf(
+			return []network.InterfaceIPConfiguration{}, nil, fmt.Errorf(
 				"valid values for private_ip_allocation_method are 'dynamic' and 'static' - got '%s'",
 				private_ip_allocation_method)
 		}
@@ -496,14 +472,10 @@ func expandAzureRmNetworkInterfaceIpConfigurations(d *schema.ResourceData) ([]ne
 			PrivateIPAllocationMethod: allocationMethod,
 		}
 
-		subnetId, err := parseAzureResourceID(subnet_id)
-		if err != nil {
-			return []network.InterfaceIPConfiguration{}, nil, nil, err
+		if _, err := parseSubnetID(subnet_id); err != nil {
+			return []network.InterfaceIPConfiguration{}, nil, err
 		}
-		subnetName := subnetId.Path["subnets"]
-		virtualNetworkName := subnetId.Path["virtualNetworks"]
-		subnetNamesToLock = append(subnetNamesToLock, subnetName)
-		virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, virtualNetworkName)
+		idsToLock = append(idsToLock, networkInterfaceIpConfigurationIdsToLock(data)...)
 
 		if v := data["private_ip_address"].(string); v != "" {
 			properties.PrivateIPAddress = &v
@@ -554,5 +526,22 @@ func expandAzureRmNetworkInterfaceIpConfigurations(d *schema.ResourceData) ([]ne
 		ipConfigs = append(ipConfigs, ipConfig)
 	}
 
-	return ipConfigs, &subnetNamesToLock, &virtualNetworkNamesToLock, nil
+	return ipConfigs, idsToLock, nil
+}
+
+// networkInterfaceIpConfigurationIdsToLock returns the IDs of the Virtual Network and Load
+// Balancers which are modified along with the IP Configuration, since the Subnet and the
+// Backend Address Pools and Inbound NAT Rules it references are updated to reference it.
+func networkInterfaceIpConfigurationIdsToLock(data map[string]interface{}) []string {
+	ids := []string{parentResourceID(data["subnet_id"].(string))}
+
+	for _, key := range []string{"load_balancer_backend_address_pools_ids", "load_balancer_inbound_nat_rules_ids"} {
+		if v, ok := data[key]; ok {
+			for _, id := range v.(*schema.Set).List() {
+				ids = append(ids, parentResourceID(id.(string)))
+			}
+		}
+	}
+
+	return ids
 }
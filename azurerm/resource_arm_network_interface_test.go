@@ -5,13 +5,12 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMNetworkInterface_basic(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -28,7 +27,7 @@ func TestAccAzureRMNetworkInterface_basic(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkInterface_disappears(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -47,7 +46,7 @@ func TestAccAzureRMNetworkInterface_disappears(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkInterface_enableIPForwarding(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -66,7 +65,7 @@ func TestAccAzureRMNetworkInterface_enableIPForwarding(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkInterface_multipleLoadBalancers(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -84,7 +83,7 @@ func TestAccAzureRMNetworkInterface_multipleLoadBalancers(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkInterface_withTags(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -117,7 +116,7 @@ func TestAccAzureRMNetworkInterface_withTags(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkInterface_bug7986(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -148,7 +147,7 @@ func testCheckAzureRMNetworkInterfaceExists(name string) resource.TestCheckFunc
 			return fmt.Errorf("Bad: no resource group found in state for availability set: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).ifaceClient
+		conn := testAccProvider.Meta().(*ArmClient).ifaceClient()
 
 		resp, err := conn.Get(resourceGroup, name, "")
 		if err != nil {
@@ -177,7 +176,7 @@ func testCheckAzureRMNetworkInterfaceDisappears(name string) resource.TestCheckF
 			return fmt.Errorf("Bad: no resource group found in state for availability set: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).ifaceClient
+		conn := testAccProvider.Meta().(*ArmClient).ifaceClient()
 
 		_, error := conn.Delete(resourceGroup, name, make(chan struct{}))
 		err := <-error
@@ -190,7 +189,7 @@ func testCheckAzureRMNetworkInterfaceDisappears(name string) resource.TestCheckF
 }
 
 func testCheckAzureRMNetworkInterfaceDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).ifaceClient
+	conn := testAccProvider.Meta().(*ArmClient).ifaceClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_network_interface" {
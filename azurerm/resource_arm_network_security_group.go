@@ -12,8 +12,6 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
-var networkSecurityGroupResourceName = "azurerm_network_security_group"
-
 func resourceArmNetworkSecurityGroup() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmNetworkSecurityGroupCreate,
@@ -126,20 +124,19 @@ func resourceArmNetworkSecurityGroup() *schema.Resource {
 
 func resourceArmNetworkSecurityGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	secClient := client.secGroupClient
+	secClient := client.secGroupClient()
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	sgRules, sgErr := expandAzureRmSecurityRules(d)
 	if sgErr != nil {
 		return fmt.Errorf("Error Building list of Network Security Group Rules: %s", sgErr)
 	}
 
-	azureRMLockByName(name, networkSecurityGroupResourceName)
-	defer azureRMUnlockByName(name, networkSecurityGroupResourceName)
+	unlock := lockResourceIDs(networkResourceID(client.subscriptionId, resGroup, "networkSecurityGroups", name))
+	defer unlock()
 
 	sg := network.SecurityGroup{
 		Name:     &name,
@@ -147,12 +144,15 @@ func resourceArmNetworkSecurityGroupCreate(d *schema.ResourceData, meta interfac
 		SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
 			SecurityRules: &sgRules,
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
-	_, error := secClient.CreateOrUpdate(resGroup, name, sg, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := secClient.CreateOrUpdate(resGroup, name, sg, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/networkSecurityGroups", name)
 		return err
 	}
 
@@ -164,32 +164,32 @@ func resourceArmNetworkSecurityGroupCreate(d *schema.ResourceData, meta interfac
 		return fmt.Errorf("Cannot read Virtual Network %s (resource group %s) ID", name, resGroup)
 	}
 
+	d.SetId(*read.ID)
+
 	log.Printf("[DEBUG] Waiting for NSG (%s) to become available", d.Get("name"))
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"Updating", "Creating"},
 		Target:     []string{"Succeeded"},
 		Refresh:    networkSecurityGroupStateRefreshFunc(client, resGroup, name),
-		Timeout:    30 * time.Minute,
+		Timeout:    createOrUpdateTimeout(d),
 		MinTimeout: 15 * time.Second,
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := meta.(*ArmClient).waitForState(stateConf); err != nil {
 		return fmt.Errorf("Error waiting for NSG (%s) to become available: %s", d.Get("name"), err)
 	}
 
-	d.SetId(*read.ID)
-
 	return resourceArmNetworkSecurityGroupRead(d, meta)
 }
 
 func resourceArmNetworkSecurityGroupRead(d *schema.ResourceData, meta interface{}) error {
-	secGroupClient := meta.(*ArmClient).secGroupClient
+	secGroupClient := meta.(*ArmClient).secGroupClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/networkSecurityGroups")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["networkSecurityGroups"]
+	name := names[0]
 
 	resp, err := secGroupClient.Get(resGroup, name, "")
 	if err != nil {
@@ -207,22 +207,27 @@ func resourceArmNetworkSecurityGroupRead(d *schema.ResourceData, meta interface{
 	d.Set("resource_group_name", resGroup)
 	d.Set("name", resp.Name)
 	d.Set("location", azureRMNormalizeLocation(*resp.Location))
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmNetworkSecurityGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	secGroupClient := meta.(*ArmClient).secGroupClient
+	secGroupClient := meta.(*ArmClient).secGroupClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/networkSecurityGroups")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["networkSecurityGroups"]
+	name := names[0]
+
+	unlock := lockResourceIDs(d.Id())
+	defer unlock()
 
-	_, error := secGroupClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := secGroupClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 
 	return err
@@ -308,7 +313,7 @@ func expandAzureRmSecurityRules(d *schema.ResourceData) ([]network.SecurityRule,
 
 func networkSecurityGroupStateRefreshFunc(client *ArmClient, resourceGroupName string, sgName string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		res, err := client.secGroupClient.Get(resourceGroupName, sgName, "")
+		res, err := client.secGroupClient().Get(resourceGroupName, sgName, "")
 		if err != nil {
 			return nil, "", fmt.Errorf("Error issuing read request in networkSecurityGroupStateRefreshFunc to Azure ARM for NSG '%s' (RG: '%s'): %s", sgName, resourceGroupName, err)
 		}
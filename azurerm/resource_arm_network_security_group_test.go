@@ -5,13 +5,12 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMNetworkSecurityGroup_basic(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -28,7 +27,7 @@ func TestAccAzureRMNetworkSecurityGroup_basic(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkSecurityGroup_disappears(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -47,7 +46,7 @@ func TestAccAzureRMNetworkSecurityGroup_disappears(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkSecurityGroup_withTags(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -81,7 +80,7 @@ func TestAccAzureRMNetworkSecurityGroup_withTags(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkSecurityGroup_addingExtraRules(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -122,7 +121,7 @@ func testCheckAzureRMNetworkSecurityGroupExists(name string) resource.TestCheckF
 			return fmt.Errorf("Bad: no resource group found in state for network security group: %s", sgName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).secGroupClient
+		conn := testAccProvider.Meta().(*ArmClient).secGroupClient()
 
 		resp, err := conn.Get(resourceGroup, sgName, "")
 		if err != nil {
@@ -151,7 +150,7 @@ func testCheckAzureRMNetworkSecurityGroupDisappears(name string) resource.TestCh
 			return fmt.Errorf("Bad: no resource group found in state for network security group: %s", sgName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).secGroupClient
+		conn := testAccProvider.Meta().(*ArmClient).secGroupClient()
 
 		_, error := conn.Delete(resourceGroup, sgName, make(chan struct{}))
 		err := <-error
@@ -164,7 +163,7 @@ func testCheckAzureRMNetworkSecurityGroupDisappears(name string) resource.TestCh
 }
 
 func testCheckAzureRMNetworkSecurityGroupDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).secGroupClient
+	conn := testAccProvider.Meta().(*ArmClient).secGroupClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_network_security_group" {
@@ -105,7 +105,7 @@ func resourceArmNetworkSecurityRule() *schema.Resource {
 
 func resourceArmNetworkSecurityRuleCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	secClient := client.secRuleClient
+	secClient := client.secRuleClient()
 
 	name := d.Get("name").(string)
 	nsgName := d.Get("network_security_group_name").(string)
@@ -120,8 +120,8 @@ func resourceArmNetworkSecurityRuleCreate(d *schema.ResourceData, meta interface
 	direction := d.Get("direction").(string)
 	protocol := d.Get("protocol").(string)
 
-	azureRMLockByName(nsgName, networkSecurityGroupResourceName)
-	defer azureRMUnlockByName(nsgName, networkSecurityGroupResourceName)
+	unlock := lockResourceIDs(networkResourceID(client.subscriptionId, resGroup, "networkSecurityGroups", nsgName))
+	defer unlock()
 
 	properties := network.SecurityRulePropertiesFormat{
 		SourcePortRange:          &source_port_range,
@@ -144,9 +144,12 @@ func resourceArmNetworkSecurityRuleCreate(d *schema.ResourceData, meta interface
 		SecurityRulePropertiesFormat: &properties,
 	}
 
-	_, error := secClient.CreateOrUpdate(resGroup, nsgName, name, sgr, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := secClient.CreateOrUpdate(resGroup, nsgName, name, sgr, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/networkSecurityGroups/securityRules", nsgName, name)
 		return err
 	}
 
@@ -164,15 +167,15 @@ func resourceArmNetworkSecurityRuleCreate(d *schema.ResourceData, meta interface
 }
 
 func resourceArmNetworkSecurityRuleRead(d *schema.ResourceData, meta interface{}) error {
-	secRuleClient := meta.(*ArmClient).secRuleClient
+	secRuleClient := meta.(*ArmClient).secRuleClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/networkSecurityGroups/securityRules")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	networkSGName := id.Path["networkSecurityGroups"]
-	sgRuleName := id.Path["securityRules"]
+	networkSGName := names[0]
+	sgRuleName := names[1]
 
 	resp, err := secRuleClient.Get(resGroup, networkSGName, sgRuleName)
 	if err != nil {
@@ -200,20 +203,22 @@ func resourceArmNetworkSecurityRuleRead(d *schema.ResourceData, meta interface{}
 
 func resourceArmNetworkSecurityRuleDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	secRuleClient := client.secRuleClient
+	secRuleClient := client.secRuleClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/networkSecurityGroups/securityRules")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	nsgName := id.Path["networkSecurityGroups"]
-	sgRuleName := id.Path["securityRules"]
+	nsgName := names[0]
+	sgRuleName := names[1]
 
-	azureRMLockByName(nsgName, networkSecurityGroupResourceName)
-	defer azureRMUnlockByName(nsgName, networkSecurityGroupResourceName)
+	unlock := lockResourceIDs(parentResourceID(d.Id()))
+	defer unlock()
 
-	_, error := secRuleClient.Delete(resGroup, nsgName, sgRuleName, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := secRuleClient.Delete(resGroup, nsgName, sgRuleName, ctx.Done())
 	err = <-error
 
 	return err
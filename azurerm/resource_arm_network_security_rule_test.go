@@ -5,13 +5,12 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMNetworkSecurityRule_basic(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
@@ -28,7 +27,7 @@ func TestAccAzureRMNetworkSecurityRule_basic(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkSecurityRule_disappears(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -48,7 +47,7 @@ func TestAccAzureRMNetworkSecurityRule_disappears(t *testing.T) {
 }
 
 func TestAccAzureRMNetworkSecurityRule_addingRules(t *testing.T) {
-	rInt := acctest.RandInt()
+	rInt := testRandInt(t)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -87,7 +86,7 @@ func testCheckAzureRMNetworkSecurityRuleExists(name string) resource.TestCheckFu
 			return fmt.Errorf("Bad: no resource group found in state for network security rule: %s", sgName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).secRuleClient
+		conn := testAccProvider.Meta().(*ArmClient).secRuleClient()
 
 		resp, err := conn.Get(resourceGroup, sgName, sgrName)
 		if err != nil {
@@ -117,7 +116,7 @@ func testCheckAzureRMNetworkSecurityRuleDisappears(name string) resource.TestChe
 			return fmt.Errorf("Bad: no resource group found in state for network security rule: %s", sgName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).secRuleClient
+		conn := testAccProvider.Meta().(*ArmClient).secRuleClient()
 
 		_, error := conn.Delete(resourceGroup, sgName, sgrName, make(chan struct{}))
 		err := <-error
@@ -130,7 +129,7 @@ func testCheckAzureRMNetworkSecurityRuleDisappears(name string) resource.TestChe
 }
 
 func testCheckAzureRMNetworkSecurityRuleDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).secRuleClient
+	conn := testAccProvider.Meta().(*ArmClient).secRuleClient()
 
 	for _, rs := range s.RootModule().Resources {
 
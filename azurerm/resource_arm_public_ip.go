@@ -84,14 +84,13 @@ func resourceArmPublicIp() *schema.Resource {
 
 func resourceArmPublicIpCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	publicIPClient := client.publicIPClient
+	publicIPClient := client.publicIPClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM Public IP creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	properties := network.PublicIPAddressPropertiesFormat{
 		PublicIPAllocationMethod: network.IPAllocationMethod(d.Get("public_ip_address_allocation").(string)),
@@ -126,12 +125,15 @@ func resourceArmPublicIpCreate(d *schema.ResourceData, meta interface{}) error {
 		Name:                            &name,
 		Location:                        &location,
 		PublicIPAddressPropertiesFormat: &properties,
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
-	_, error := publicIPClient.CreateOrUpdate(resGroup, name, publicIp, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := publicIPClient.CreateOrUpdate(resGroup, name, publicIp, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/publicIPAddresses", name)
 		return err
 	}
 
@@ -149,14 +151,14 @@ func resourceArmPublicIpCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceArmPublicIpRead(d *schema.ResourceData, meta interface{}) error {
-	publicIPClient := meta.(*ArmClient).publicIPClient
+	publicIPClient := meta.(*ArmClient).publicIPClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/publicIPAddresses")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["publicIPAddresses"]
+	name := names[0]
 
 	resp, err := publicIPClient.Get(resGroup, name, "")
 	if err != nil {
@@ -180,22 +182,24 @@ func resourceArmPublicIpRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("ip_address", resp.PublicIPAddressPropertiesFormat.IPAddress)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmPublicIpDelete(d *schema.ResourceData, meta interface{}) error {
-	publicIPClient := meta.(*ArmClient).publicIPClient
+	publicIPClient := meta.(*ArmClient).publicIPClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/publicIPAddresses")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["publicIPAddresses"]
+	name := names[0]
 
-	_, error := publicIPClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := publicIPClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 
 	return err
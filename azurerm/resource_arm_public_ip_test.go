@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -64,7 +63,7 @@ func TestResourceAzureRMPublicIpDomainNameLabel_validation(t *testing.T) {
 			ErrCount: 1,
 		},
 		{
-			Value:    acctest.RandString(80),
+			Value:    testRandString(t, 80),
 			ErrCount: 1,
 		},
 	}
@@ -80,7 +79,7 @@ func TestResourceAzureRMPublicIpDomainNameLabel_validation(t *testing.T) {
 
 func TestAccAzureRMPublicIpStatic_basic(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMPublicIPStatic_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -100,7 +99,7 @@ func TestAccAzureRMPublicIpStatic_basic(t *testing.T) {
 
 func TestAccAzureRMPublicIpStatic_disappears(t *testing.T) {
 	resourceName := "azurerm_public_ip.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMPublicIPStatic_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -122,7 +121,7 @@ func TestAccAzureRMPublicIpStatic_disappears(t *testing.T) {
 
 func TestAccAzureRMPublicIpStatic_idleTimeout(t *testing.T) {
 	resourceName := "azurerm_public_ip.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMPublicIPStatic_idleTimeout(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -143,7 +142,7 @@ func TestAccAzureRMPublicIpStatic_idleTimeout(t *testing.T) {
 
 func TestAccAzureRMPublicIpStatic_withTags(t *testing.T) {
 	resourceName := "azurerm_public_ip.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMPublicIPStatic_withTags(ri, location)
 	postConfig := testAccAzureRMPublicIPStatic_withTagsUpdate(ri, location)
@@ -176,7 +175,7 @@ func TestAccAzureRMPublicIpStatic_withTags(t *testing.T) {
 
 func TestAccAzureRMPublicIpStatic_update(t *testing.T) {
 	resourceName := "azurerm_public_ip.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMPublicIPStatic_basic(ri, location)
 	postConfig := testAccAzureRMPublicIPStatic_update(ri, location)
@@ -204,7 +203,7 @@ func TestAccAzureRMPublicIpStatic_update(t *testing.T) {
 }
 
 func TestAccAzureRMPublicIpDynamic_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMPublicIPDynamic_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -236,7 +235,7 @@ func testCheckAzureRMPublicIpExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for public ip: %s", availSetName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).publicIPClient
+		conn := testAccProvider.Meta().(*ArmClient).publicIPClient()
 
 		resp, err := conn.Get(resourceGroup, availSetName, "")
 		if err != nil {
@@ -265,7 +264,7 @@ func testCheckAzureRMPublicIpDisappears(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for public ip: %s", publicIpName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).publicIPClient
+		conn := testAccProvider.Meta().(*ArmClient).publicIPClient()
 
 		_, error := conn.Delete(resourceGroup, publicIpName, make(chan struct{}))
 		err := <-error
@@ -278,7 +277,7 @@ func testCheckAzureRMPublicIpDisappears(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMPublicIpDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).publicIPClient
+	conn := testAccProvider.Meta().(*ArmClient).publicIPClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_public_ip" {
@@ -6,8 +6,8 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/jen20/riviera/azure"
 )
 
 func resourceArmResourceGroup() *schema.Resource {
@@ -55,129 +55,103 @@ func validateArmResourceGroupName(v interface{}, k string) (ws []string, es []er
 }
 
 func resourceArmResourceGroupUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+	client := meta.(*ArmClient).resourceGroupClient()
 
 	if !d.HasChange("tags") {
 		return nil
 	}
 
 	name := d.Get("name").(string)
-	newTags := d.Get("tags").(map[string]interface{})
 
-	updateRequest := rivieraClient.NewRequestForURI(d.Id())
-	updateRequest.Command = &azure.UpdateResourceGroup{
-		Name: name,
-		Tags: *expandTags(newTags),
+	parameters := resources.Group{
+		Tags: expandTagsForResource(d, meta),
 	}
-
-	updateResponse, err := updateRequest.Execute()
+	_, err := client.Patch(name, parameters)
 	if err != nil {
 		return fmt.Errorf("Error updating resource group: %s", err)
 	}
-	if !updateResponse.IsSuccessful() {
-		return fmt.Errorf("Error updating resource group: %s", updateResponse.Error)
-	}
 
 	return resourceArmResourceGroupRead(d, meta)
 }
 
 func resourceArmResourceGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+	client := meta.(*ArmClient).resourceGroupClient()
 
-	createRequest := rivieraClient.NewRequest()
-	createRequest.Command = &azure.CreateResourceGroup{
-		Name:     d.Get("name").(string),
-		Location: d.Get("location").(string),
-		Tags:     *expandTags(d.Get("tags").(map[string]interface{})),
+	name := d.Get("name").(string)
+	location := d.Get("location").(string)
+	parameters := resources.Group{
+		Location: &location,
+		Tags:     expandTagsForResource(d, meta),
 	}
-
-	createResponse, err := createRequest.Execute()
+	resp, err := client.CreateOrUpdate(name, parameters)
 	if err != nil {
 		return fmt.Errorf("Error creating resource group: %s", err)
 	}
-	if !createResponse.IsSuccessful() {
-		return fmt.Errorf("Error creating resource group: %s", createResponse.Error)
+	if resp.ID == nil {
+		return fmt.Errorf("Cannot read Resource Group %q ID", name)
 	}
 
-	resp := createResponse.Parsed.(*azure.CreateResourceGroupResponse)
 	d.SetId(*resp.ID)
 
-	// TODO(jen20): Decide whether we need this or not and migrate to use @stack72's work if so
-	// log.Printf("[DEBUG] Waiting for Resource Group (%s) to become available", name)
-	// stateConf := &resource.StateChangeConf{
-	// 	Pending: []string{"Accepted"},
-	// 	Target:  []string{"Succeeded"},
-	// 	Refresh: resourceGroupStateRefreshFunc(client, name),
-	// 	Timeout: 10 * time.Minute,
-	// }
-	// if _, err := stateConf.WaitForState(); err != nil {
-	// 	return fmt.Errorf("Error waiting for Resource Group (%s) to become available: %s", name, err)
-	// }
-
 	return resourceArmResourceGroupRead(d, meta)
 }
 
 func resourceArmResourceGroupRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+	client := meta.(*ArmClient).resourceGroupClient()
 
-	readRequest := rivieraClient.NewRequestForURI(d.Id())
-	readRequest.Command = &azure.GetResourceGroup{}
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
 
-	readResponse, err := readRequest.Execute()
+	resp, err := client.Get(id.ResourceGroup)
 	if err != nil {
+		if responseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Error reading resource group %q - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("Error reading resource group: %s", err)
 	}
-	if !readResponse.IsSuccessful() {
-		log.Printf("[INFO] Error reading resource group %q - removing from state", d.Id())
-		d.SetId("")
-		return fmt.Errorf("Error reading resource group: %s", readResponse.Error)
-	}
-
-	resp := readResponse.Parsed.(*azure.GetResourceGroupResponse)
 
 	d.Set("name", resp.Name)
 	d.Set("location", azureRMNormalizeLocation(*resp.Location))
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmResourceGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+	client := meta.(*ArmClient).resourceGroupClient()
 
-	readRequest := rivieraClient.NewRequestForURI(d.Id())
-	readRequest.Command = &azure.GetResourceGroup{}
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return false, err
+	}
 
-	readResponse, err := readRequest.Execute()
+	resp, err := client.CheckExistence(id.ResourceGroup)
 	if err != nil {
 		return false, fmt.Errorf("Error reading resource group: %s", err)
 	}
-	if readResponse.IsSuccessful() {
-		return true, nil
-	}
 
-	return false, nil
+	return !responseWasNotFound(resp), nil
 }
 
 func resourceArmResourceGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+	client := meta.(*ArmClient).resourceGroupClient()
 
-	deleteRequest := rivieraClient.NewRequestForURI(d.Id())
-	deleteRequest.Command = &azure.DeleteResourceGroup{}
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
 
-	deleteResponse, err := deleteRequest.Execute()
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := client.Delete(id.ResourceGroup, ctx.Done())
+	err = <-error
 	if err != nil {
 		return fmt.Errorf("Error deleting resource group: %s", err)
 	}
-	if !deleteResponse.IsSuccessful() {
-		return fmt.Errorf("Error deleting resource group: %s", deleteResponse.Error)
-	}
 
 	return nil
-
 }
@@ -3,15 +3,16 @@ package azurerm
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMResourceGroup_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMResourceGroup_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -31,7 +32,7 @@ func TestAccAzureRMResourceGroup_basic(t *testing.T) {
 
 func TestAccAzureRMResourceGroup_disappears(t *testing.T) {
 	resourceName := "azurerm_resource_group.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMResourceGroup_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -53,7 +54,7 @@ func TestAccAzureRMResourceGroup_disappears(t *testing.T) {
 
 func TestAccAzureRMResourceGroup_withTags(t *testing.T) {
 	resourceName := "azurerm_resource_group.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMResourceGroup_withTags(ri, location)
 	postConfig := testAccAzureRMResourceGroup_withTagsUpdated(ri, location)
@@ -95,7 +96,7 @@ func testCheckAzureRMResourceGroupExists(name string) resource.TestCheckFunc {
 		resourceGroup := rs.Primary.Attributes["name"]
 
 		// Ensure resource group exists in API
-		conn := testAccProvider.Meta().(*ArmClient).resourceGroupClient
+		conn := testAccProvider.Meta().(*ArmClient).resourceGroupClient()
 
 		resp, err := conn.Get(resourceGroup)
 		if err != nil {
@@ -121,7 +122,7 @@ func testCheckAzureRMResourceGroupDisappears(name string) resource.TestCheckFunc
 		resourceGroup := rs.Primary.Attributes["name"]
 
 		// Ensure resource group exists in API
-		conn := testAccProvider.Meta().(*ArmClient).resourceGroupClient
+		conn := testAccProvider.Meta().(*ArmClient).resourceGroupClient()
 
 		_, error := conn.Delete(resourceGroup, make(chan struct{}))
 		err := <-error
@@ -134,7 +135,7 @@ func testCheckAzureRMResourceGroupDisappears(name string) resource.TestCheckFunc
 }
 
 func testCheckAzureRMResourceGroupDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).resourceGroupClient
+	conn := testAccProvider.Meta().(*ArmClient).resourceGroupClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_resource_group" {
@@ -191,3 +192,45 @@ resource "azurerm_resource_group" "test" {
 }
 `, rInt, location)
 }
+
+func TestResourceAzureRMResourceGroup_fakeArm(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	meta := server.armClient(t)
+	r := Provider().(*schema.Provider).ResourcesMap["azurerm_resource_group"]
+	state, err := createResource(t, r, map[string]interface{}{
+		"name":     "example",
+		"location": "West Europe",
+		"tags": map[string]interface{}{
+			"environment": "Production",
+		},
+	}, meta)
+	if err != nil {
+		t.Fatalf("Error creating the Resource Group: %s", err)
+	}
+	if expected := fmt.Sprintf("/subscriptions/%s/resourceGroups/example", fakeArmSubscriptionID); !strings.EqualFold(state.ID, expected) {
+		t.Fatalf("Expected the ID %q but got %q", expected, state.ID)
+	}
+	if state.Attributes["location"] != "westeurope" || state.Attributes["tags.environment"] != "Production" {
+		t.Fatalf("Expected the Resource Group to be read back but got %+v", state.Attributes)
+	}
+
+	exists, err := resourceArmResourceGroupExists(r.Data(state), meta)
+	if err != nil || !exists {
+		t.Fatalf("Expected the Resource Group to exist but got %t: %v", exists, err)
+	}
+
+	server.operationPolls = 2
+	if err := destroyResource(r, state, meta); err != nil {
+		t.Fatalf("Error deleting the Resource Group: %s", err)
+	}
+	if count := server.requestCount("GET", "/fakeOperations/1"); count != 2 {
+		t.Fatalf("Expected the deletion to be polled twice but got %d", count)
+	}
+
+	exists, err = resourceArmResourceGroupExists(r.Data(state), meta)
+	if err != nil || exists {
+		t.Fatalf("Expected the Resource Group to have been deleted but got %t: %v", exists, err)
+	}
+}
@@ -62,7 +62,7 @@ func resourceArmRoute() *schema.Resource {
 
 func resourceArmRouteCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	routesClient := client.routesClient
+	routesClient := client.routesClient()
 
 	name := d.Get("name").(string)
 	rtName := d.Get("route_table_name").(string)
@@ -71,8 +71,8 @@ func resourceArmRouteCreate(d *schema.ResourceData, meta interface{}) error {
 	addressPrefix := d.Get("address_prefix").(string)
 	nextHopType := d.Get("next_hop_type").(string)
 
-	azureRMLockByName(rtName, routeTableResourceName)
-	defer azureRMUnlockByName(rtName, routeTableResourceName)
+	unlock := lockResourceIDs(networkResourceID(client.subscriptionId, resGroup, "routeTables", rtName))
+	defer unlock()
 
 	properties := network.RoutePropertiesFormat{
 		AddressPrefix: &addressPrefix,
@@ -89,9 +89,12 @@ func resourceArmRouteCreate(d *schema.ResourceData, meta interface{}) error {
 		RoutePropertiesFormat: &properties,
 	}
 
-	_, error := routesClient.CreateOrUpdate(resGroup, rtName, name, route, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := routesClient.CreateOrUpdate(resGroup, rtName, name, route, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/routeTables/routes", rtName, name)
 		return err
 	}
 
@@ -108,15 +111,15 @@ func resourceArmRouteCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceArmRouteRead(d *schema.ResourceData, meta interface{}) error {
-	routesClient := meta.(*ArmClient).routesClient
+	routesClient := meta.(*ArmClient).routesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/routeTables/routes")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	rtName := id.Path["routeTables"]
-	routeName := id.Path["routes"]
+	rtName := names[0]
+	routeName := names[1]
 
 	resp, err := routesClient.Get(resGroup, rtName, routeName)
 	if err != nil {
@@ -142,20 +145,22 @@ func resourceArmRouteRead(d *schema.ResourceData, meta interface{}) error {
 
 func resourceArmRouteDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	routesClient := client.routesClient
+	routesClient := client.routesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/routeTables/routes")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	rtName := id.Path["routeTables"]
-	routeName := id.Path["routes"]
+	rtName := names[0]
+	routeName := names[1]
 
-	azureRMLockByName(rtName, routeTableResourceName)
-	defer azureRMUnlockByName(rtName, routeTableResourceName)
+	unlock := lockResourceIDs(parentResourceID(d.Id()))
+	defer unlock()
 
-	_, error := routesClient.Delete(resGroup, rtName, routeName, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := routesClient.Delete(resGroup, rtName, routeName, ctx.Done())
 	err = <-error
 
 	return err
@@ -11,8 +11,6 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
-var routeTableResourceName = "azurerm_route_table"
-
 func resourceArmRouteTable() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmRouteTableCreate,
@@ -84,19 +82,18 @@ func resourceArmRouteTable() *schema.Resource {
 
 func resourceArmRouteTableCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	routeTablesClient := client.routeTablesClient
+	routeTablesClient := client.routeTablesClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM Route Table creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	routeSet := network.RouteTable{
 		Name:     &name,
 		Location: &location,
-		Tags:     expandTags(tags),
+		Tags:     expandTagsForResource(d, meta),
 	}
 
 	if _, ok := d.GetOk("route"); ok {
@@ -112,9 +109,15 @@ func resourceArmRouteTableCreate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	_, error := routeTablesClient.CreateOrUpdate(resGroup, name, routeSet, make(chan struct{}))
+	unlock := lockResourceIDs(networkResourceID(client.subscriptionId, resGroup, "routeTables", name))
+	defer unlock()
+
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := routeTablesClient.CreateOrUpdate(resGroup, name, routeSet, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/routeTables", name)
 		return err
 	}
 
@@ -132,14 +135,14 @@ func resourceArmRouteTableCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceArmRouteTableRead(d *schema.ResourceData, meta interface{}) error {
-	routeTablesClient := meta.(*ArmClient).routeTablesClient
+	routeTablesClient := meta.(*ArmClient).routeTablesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/routeTables")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["routeTables"]
+	name := names[0]
 
 	resp, err := routeTablesClient.Get(resGroup, name, "")
 	if err != nil {
@@ -167,22 +170,27 @@ func resourceArmRouteTableRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	d.Set("subnets", subnets)
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmRouteTableDelete(d *schema.ResourceData, meta interface{}) error {
-	routeTablesClient := meta.(*ArmClient).routeTablesClient
+	routeTablesClient := meta.(*ArmClient).routeTablesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/routeTables")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["routeTables"]
+	name := names[0]
+
+	unlock := lockResourceIDs(d.Id())
+	defer unlock()
 
-	_, error := routeTablesClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := routeTablesClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 
 	return err
@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -60,7 +59,7 @@ func TestResourceAzureRMRouteTableNextHopType_validation(t *testing.T) {
 
 func TestAccAzureRMRouteTable_basic(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMRouteTable_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -80,7 +79,7 @@ func TestAccAzureRMRouteTable_basic(t *testing.T) {
 
 func TestAccAzureRMRouteTable_disappears(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMRouteTable_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -102,7 +101,7 @@ func TestAccAzureRMRouteTable_disappears(t *testing.T) {
 
 func TestAccAzureRMRouteTable_withTags(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	preConfig := testAccAzureRMRouteTable_withTags(ri, testLocation())
 	postConfig := testAccAzureRMRouteTable_withTagsUpdate(ri, testLocation())
 
@@ -139,7 +138,7 @@ func TestAccAzureRMRouteTable_withTags(t *testing.T) {
 
 func TestAccAzureRMRouteTable_multipleRoutes(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	preConfig := testAccAzureRMRouteTable_basic(ri, testLocation())
 	postConfig := testAccAzureRMRouteTable_multipleRoutes(ri, testLocation())
 
@@ -182,7 +181,7 @@ func testCheckAzureRMRouteTableExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for route table: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).routeTablesClient
+		conn := testAccProvider.Meta().(*ArmClient).routeTablesClient()
 
 		resp, err := conn.Get(resourceGroup, name, "")
 		if err != nil {
@@ -211,7 +210,7 @@ func testCheckAzureRMRouteTableDisappears(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for route table: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).routeTablesClient
+		conn := testAccProvider.Meta().(*ArmClient).routeTablesClient()
 
 		_, error := conn.Delete(resourceGroup, name, make(chan struct{}))
 		err := <-error
@@ -224,7 +223,7 @@ func testCheckAzureRMRouteTableDisappears(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMRouteTableDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).routeTablesClient
+	conn := testAccProvider.Meta().(*ArmClient).routeTablesClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_route_table" {
@@ -5,14 +5,13 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMRoute_basic(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMRoute_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -32,7 +31,7 @@ func TestAccAzureRMRoute_basic(t *testing.T) {
 
 func TestAccAzureRMRoute_disappears(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMRoute_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -54,7 +53,7 @@ func TestAccAzureRMRoute_disappears(t *testing.T) {
 
 func TestAccAzureRMRoute_multipleRoutes(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMRoute_basic(ri, location)
 	postConfig := testAccAzureRMRoute_multipleRoutes(ri, location)
@@ -96,7 +95,7 @@ func testCheckAzureRMRouteExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for route: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).routesClient
+		conn := testAccProvider.Meta().(*ArmClient).routesClient()
 
 		resp, err := conn.Get(resourceGroup, rtName, name)
 		if err != nil {
@@ -126,7 +125,7 @@ func testCheckAzureRMRouteDisappears(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for route: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).routesClient
+		conn := testAccProvider.Meta().(*ArmClient).routesClient()
 
 		_, error := conn.Delete(resourceGroup, rtName, name, make(chan struct{}))
 		err := <-error
@@ -139,7 +138,7 @@ func testCheckAzureRMRouteDisappears(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMRouteDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).routesClient
+	conn := testAccProvider.Meta().(*ArmClient).routesClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_route" {
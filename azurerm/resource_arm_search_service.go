@@ -5,9 +5,7 @@ import (
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/jen20/riviera/search"
 )
 
 func resourceArmSearchService() *schema.Resource {
@@ -20,6 +18,13 @@ func resourceArmSearchService() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -58,134 +63,114 @@ func resourceArmSearchService() *schema.Resource {
 }
 
 func resourceArmSearchServiceCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
-
-	tags := d.Get("tags").(map[string]interface{})
-	expandedTags := expandTags(tags)
-
-	command := &search.CreateOrUpdateSearchService{
-		Name:              d.Get("name").(string),
-		Location:          d.Get("location").(string),
-		ResourceGroupName: d.Get("resource_group_name").(string),
-		Tags:              *expandedTags,
-		Sku: search.Sku{
-			Name: d.Get("sku").(string),
-		},
-	}
+	client := meta.(*ArmClient).searchServicesClient()
+
+	name := d.Get("name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	location := d.Get("location").(string)
+
+	properties := searchServiceProperties{}
 
 	if v, ok := d.GetOk("replica_count"); ok {
-		replica_count := v.(int)
-		command.ReplicaCount = &replica_count
+		replicaCount := int32(v.(int))
+		properties.ReplicaCount = &replicaCount
 	}
 
 	if v, ok := d.GetOk("partition_count"); ok {
-		partition_count := v.(int)
-		command.PartitionCount = &partition_count
+		partitionCount := int32(v.(int))
+		properties.PartitionCount = &partitionCount
 	}
 
-	createRequest := rivieraClient.NewRequest()
-	createRequest.Command = command
-
-	createResponse, err := createRequest.Execute()
-	if err != nil {
-		return fmt.Errorf("Error creating Search Service: %+v", err)
-	}
-	if !createResponse.IsSuccessful() {
-		return fmt.Errorf("Error creating Search Service: %+v", createResponse.Error)
+	parameters := searchService{
+		Location: &location,
+		Tags:     expandTagsForResource(d, meta),
+		Sku: &searchServiceSku{
+			Name: d.Get("sku").(string),
+		},
+		Properties: &properties,
 	}
 
-	getSearchServiceCommand := &search.GetSearchService{
-		Name:              d.Get("name").(string),
-		ResourceGroupName: d.Get("resource_group_name").(string),
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, err := client.CreateOrUpdate(resGroup, name, parameters, ctx.Done())
+	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Search/searchServices", name)
+		return fmt.Errorf("Error creating Search Service %q (Resource Group %q): %+v", name, resGroup, err)
 	}
 
-	readRequest := rivieraClient.NewRequest()
-	readRequest.Command = getSearchServiceCommand
-
-	readResponse, err := readRequest.Execute()
+	read, err := client.Get(resGroup, name)
 	if err != nil {
-		return fmt.Errorf("Error reading Search Service: %+v", err)
-	}
-	if !readResponse.IsSuccessful() {
-		return fmt.Errorf("Error reading Search Service: %+v", readResponse.Error)
+		return fmt.Errorf("Error reading Search Service %q (Resource Group %q): %+v", name, resGroup, err)
 	}
-	resp := readResponse.Parsed.(*search.GetSearchServiceResponse)
-
-	log.Printf("[DEBUG] Waiting for Search Service (%s) to become available", d.Get("name"))
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"provisioning"},
-		Target:     []string{"succeeded"},
-		Refresh:    azureStateRefreshFunc(*resp.ID, client, getSearchServiceCommand),
-		Timeout:    60 * time.Minute,
-		MinTimeout: 15 * time.Second,
-	}
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("Error waiting for Search Service (%s) to become available: %+v", d.Get("name"), err)
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read Search Service %q (Resource Group %q) ID", name, resGroup)
 	}
 
-	d.SetId(*resp.ID)
+	d.SetId(*read.ID)
 
 	return resourceArmSearchServiceRead(d, meta)
 }
 
 func resourceArmSearchServiceRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+	client := meta.(*ArmClient).searchServicesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Search/searchServices")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["searchServices"]
-
-	readRequest := rivieraClient.NewRequestForURI(d.Id())
-	readRequest.Command = &search.GetSearchService{}
+	name := names[0]
 
-	readResponse, err := readRequest.Execute()
+	resp, err := client.Get(resGroup, name)
 	if err != nil {
-		return fmt.Errorf("Error reading Search Service: %+v", err)
+		if responseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Error reading Search Service %q - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading Search Service %q (Resource Group %q): %+v", name, resGroup, err)
 	}
-	if !readResponse.IsSuccessful() {
-		log.Printf("[INFO] Error reading Search Service %q - removing from state", d.Id())
-		d.SetId("")
-		return fmt.Errorf("Error reading Search Service: %+v", readResponse.Error)
-	}
-
-	resp := readResponse.Parsed.(*search.GetSearchServiceResponse)
 
 	d.Set("name", name)
 	d.Set("resource_group_name", resGroup)
-	d.Set("location", azureRMNormalizeLocation(resp.Location))
-	d.Set("sku", string(resp.Sku.Name))
-
-	if resp.PartitionCount != nil {
-		d.Set("partition_count", resp.PartitionCount)
+	if resp.Location != nil {
+		d.Set("location", azureRMNormalizeLocation(*resp.Location))
 	}
+	if resp.Sku != nil {
+		d.Set("sku", resp.Sku.Name)
+	}
+
+	if props := resp.Properties; props != nil {
+		if props.PartitionCount != nil {
+			d.Set("partition_count", int(*props.PartitionCount))
+		}
 
-	if resp.ReplicaCount != nil {
-		d.Set("replica_count", resp.ReplicaCount)
+		if props.ReplicaCount != nil {
+			d.Set("replica_count", int(*props.ReplicaCount))
+		}
 	}
 
-	flattenAndSetTags(d, &resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmSearchServiceDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
-
-	deleteRequest := rivieraClient.NewRequestForURI(d.Id())
-	deleteRequest.Command = &search.DeleteSearchService{}
+	client := meta.(*ArmClient).searchServicesClient()
 
-	deleteResponse, err := deleteRequest.Execute()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Search/searchServices")
 	if err != nil {
-		return fmt.Errorf("Error deleting Search Service: %+v", err)
+		return err
 	}
-	if !deleteResponse.IsSuccessful() {
-		return fmt.Errorf("Error deleting Search Service: %+v", deleteResponse.Error)
+
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	resp, err := client.Delete(id.ResourceGroup, names[0], ctx.Done())
+	if err != nil {
+		if responseWasNotFound(resp) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting Search Service %q (Resource Group %q): %+v", names[0], id.ResourceGroup, err)
 	}
 
 	return nil
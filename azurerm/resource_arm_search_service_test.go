@@ -2,17 +2,16 @@ package azurerm
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/jen20/riviera/search"
 )
 
 func TestAccAzureRMSearchService_basic(t *testing.T) {
 	resourceName := "azurerm_search_service.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMSearchService_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -33,7 +32,7 @@ func TestAccAzureRMSearchService_basic(t *testing.T) {
 
 func TestAccAzureRMSearchService_updateReplicaCountAndTags(t *testing.T) {
 	resourceName := "azurerm_search_service.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMSearchService_basic(ri, location)
 	postConfig := testAccAzureRMSearchService_updated(ri, location)
@@ -72,17 +71,18 @@ func testCheckAzureRMSearchServiceExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Not found: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).rivieraClient
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
-		readRequest := conn.NewRequestForURI(rs.Primary.ID)
-		readRequest.Command = &search.GetSearchService{}
+		conn := testAccProvider.Meta().(*ArmClient).searchServicesClient()
 
-		readResponse, err := readRequest.Execute()
+		resp, err := conn.Get(resourceGroup, name)
 		if err != nil {
-			return fmt.Errorf("Bad: GetSearchService: %+v", err)
+			return fmt.Errorf("Bad: Get on searchServicesClient: %+v", err)
 		}
-		if !readResponse.IsSuccessful() {
-			return fmt.Errorf("Bad: GetSearchService: %+v", readResponse.Error)
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: Search Service %q (resource group: %q) does not exist", name, resourceGroup)
 		}
 
 		return nil
@@ -90,24 +90,25 @@ func testCheckAzureRMSearchServiceExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMSearchServiceDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).rivieraClient
+	conn := testAccProvider.Meta().(*ArmClient).searchServicesClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_search_service" {
 			continue
 		}
 
-		readRequest := conn.NewRequestForURI(rs.Primary.ID)
-		readRequest.Command = &search.GetSearchService{}
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
-		readResponse, err := readRequest.Execute()
+		resp, err := conn.Get(resourceGroup, name)
 		if err != nil {
-			return fmt.Errorf("Bad: GetSearchService: %+v", err)
+			if resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return err
 		}
 
-		if readResponse.IsSuccessful() {
-			return fmt.Errorf("Bad: Search Service still exists: %+v", readResponse.Error)
-		}
+		return fmt.Errorf("Bad: Search Service still exists:\n%#v", resp.Properties)
 	}
 
 	return nil
@@ -87,7 +87,7 @@ func resourceArmServiceBusNamespace() *schema.Resource {
 
 func resourceArmServiceBusNamespaceCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	namespaceClient := client.serviceBusNamespacesClient
+	namespaceClient := client.serviceBusNamespacesClient()
 	log.Printf("[INFO] preparing arguments for AzureRM ServiceBus Namespace creation.")
 
 	name := d.Get("name").(string)
@@ -95,7 +95,6 @@ func resourceArmServiceBusNamespaceCreate(d *schema.ResourceData, meta interface
 	resGroup := d.Get("resource_group_name").(string)
 	sku := d.Get("sku").(string)
 	capacity := int32(d.Get("capacity").(int))
-	tags := d.Get("tags").(map[string]interface{})
 
 	parameters := servicebus.NamespaceCreateOrUpdateParameters{
 		Location: &location,
@@ -104,12 +103,15 @@ func resourceArmServiceBusNamespaceCreate(d *schema.ResourceData, meta interface
 			Tier:     servicebus.SkuTier(sku),
 			Capacity: &capacity,
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
-	_, error := namespaceClient.CreateOrUpdate(resGroup, name, parameters, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := namespaceClient.CreateOrUpdate(resGroup, name, parameters, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.ServiceBus/namespaces", name)
 		return err
 	}
 
@@ -128,14 +130,14 @@ func resourceArmServiceBusNamespaceCreate(d *schema.ResourceData, meta interface
 }
 
 func resourceArmServiceBusNamespaceRead(d *schema.ResourceData, meta interface{}) error {
-	namespaceClient := meta.(*ArmClient).serviceBusNamespacesClient
+	namespaceClient := meta.(*ArmClient).serviceBusNamespacesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ServiceBus/namespaces")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["namespaces"]
+	name := names[0]
 
 	resp, err := namespaceClient.Get(resGroup, name)
 	if err != nil {
@@ -162,22 +164,24 @@ func resourceArmServiceBusNamespaceRead(d *schema.ResourceData, meta interface{}
 		d.Set("default_secondary_key", keys.SecondaryKey)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmServiceBusNamespaceDelete(d *schema.ResourceData, meta interface{}) error {
-	namespaceClient := meta.(*ArmClient).serviceBusNamespacesClient
+	namespaceClient := meta.(*ArmClient).serviceBusNamespacesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ServiceBus/namespaces")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["namespaces"]
+	name := names[0]
 
-	deleteResp, error := namespaceClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	deleteResp, error := namespaceClient.Delete(resGroup, name, ctx.Done())
 	resp := <-deleteResp
 	err = <-error
 
@@ -99,7 +99,7 @@ func resourceArmServiceBusQueue() *schema.Resource {
 }
 
 func resourceArmServiceBusQueueCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).serviceBusQueuesClient
+	client := meta.(*ArmClient).serviceBusQueuesClient()
 	log.Printf("[INFO] preparing arguments for AzureRM ServiceBus Queue creation/update.")
 
 	name := d.Get("name").(string)
@@ -141,7 +141,7 @@ func resourceArmServiceBusQueueCreateUpdate(d *schema.ResourceData, meta interfa
 
 	// We need to retrieve the namespace because Premium namespace works differently from Basic and Standard,
 	// so it needs different rules applied to it.
-	namespace, nsErr := meta.(*ArmClient).serviceBusNamespacesClient.Get(resGroup, namespaceName)
+	namespace, nsErr := meta.(*ArmClient).serviceBusNamespacesClient().Get(resGroup, namespaceName)
 	if nsErr != nil {
 		return nsErr
 	}
@@ -177,15 +177,15 @@ func resourceArmServiceBusQueueCreateUpdate(d *schema.ResourceData, meta interfa
 }
 
 func resourceArmServiceBusQueueRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).serviceBusQueuesClient
+	client := meta.(*ArmClient).serviceBusQueuesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ServiceBus/namespaces/queues")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	name := id.Path["queues"]
+	namespaceName := names[0]
+	name := names[1]
 
 	resp, err := client.Get(resGroup, namespaceName, name)
 	if err != nil {
@@ -221,7 +221,7 @@ func resourceArmServiceBusQueueRead(d *schema.ResourceData, meta interface{}) er
 	// If the queue is NOT in a premium namespace (ie. it is Basic or Standard) and partitioning is enabled
 	// then the max size returned by the API will be 16 times greater than the value set.
 	if *props.EnablePartitioning {
-		namespace, err := meta.(*ArmClient).serviceBusNamespacesClient.Get(resGroup, namespaceName)
+		namespace, err := meta.(*ArmClient).serviceBusNamespacesClient().Get(resGroup, namespaceName)
 		if err != nil {
 			return err
 		}
@@ -238,15 +238,15 @@ func resourceArmServiceBusQueueRead(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceArmServiceBusQueueDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).serviceBusQueuesClient
+	client := meta.(*ArmClient).serviceBusQueuesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ServiceBus/namespaces/queues")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	name := id.Path["queues"]
+	namespaceName := names[0]
+	name := names[1]
 
 	_, err = client.Delete(resGroup, namespaceName, name)
 
@@ -5,14 +5,13 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMServiceBusQueue_basic(t *testing.T) {
 	resourceName := "azurerm_servicebus_queue.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMServiceBusQueue_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -35,7 +34,7 @@ func TestAccAzureRMServiceBusQueue_basic(t *testing.T) {
 
 func TestAccAzureRMServiceBusQueue_update(t *testing.T) {
 	resourceName := "azurerm_servicebus_queue.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMServiceBusQueue_basic(ri, location)
 	postConfig := testAccAzureRMServiceBusQueue_update(ri, location)
@@ -67,7 +66,7 @@ func TestAccAzureRMServiceBusQueue_update(t *testing.T) {
 
 func TestAccAzureRMServiceBusQueue_enablePartitioningStandard(t *testing.T) {
 	resourceName := "azurerm_servicebus_queue.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMServiceBusQueue_basic(ri, location)
 	postConfig := testAccAzureRMServiceBusQueue_enablePartitioningStandard(ri, location)
@@ -98,7 +97,7 @@ func TestAccAzureRMServiceBusQueue_enablePartitioningStandard(t *testing.T) {
 
 func TestAccAzureRMServiceBusQueue_defaultEnablePartitioningPremium(t *testing.T) {
 	resourceName := "azurerm_servicebus_queue.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMServiceBusQueue_Premium(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -120,7 +119,7 @@ func TestAccAzureRMServiceBusQueue_defaultEnablePartitioningPremium(t *testing.T
 
 func TestAccAzureRMServiceBusQueue_enableDuplicateDetection(t *testing.T) {
 	resourceName := "azurerm_servicebus_queue.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMServiceBusQueue_basic(ri, location)
 	postConfig := testAccAzureRMServiceBusQueue_enableDuplicateDetection(ri, location)
@@ -148,7 +147,7 @@ func TestAccAzureRMServiceBusQueue_enableDuplicateDetection(t *testing.T) {
 }
 
 func testCheckAzureRMServiceBusQueueDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*ArmClient).serviceBusQueuesClient
+	client := testAccProvider.Meta().(*ArmClient).serviceBusQueuesClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_servicebus_queue" {
@@ -190,7 +189,7 @@ func testCheckAzureRMServiceBusQueueExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for queue: %s", queueName)
 		}
 
-		client := testAccProvider.Meta().(*ArmClient).serviceBusQueuesClient
+		client := testAccProvider.Meta().(*ArmClient).serviceBusQueuesClient()
 
 		resp, err := client.Get(resourceGroup, namespaceName, queueName)
 		if err != nil {
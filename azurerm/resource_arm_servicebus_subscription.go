@@ -94,7 +94,7 @@ func resourceArmServiceBusSubscription() *schema.Resource {
 }
 
 func resourceArmServiceBusSubscriptionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).serviceBusSubscriptionsClient
+	client := meta.(*ArmClient).serviceBusSubscriptionsClient()
 	log.Printf("[INFO] preparing arguments for Azure ARM ServiceBus Subscription creation.")
 
 	name := d.Get("name").(string)
@@ -147,16 +147,16 @@ func resourceArmServiceBusSubscriptionCreate(d *schema.ResourceData, meta interf
 }
 
 func resourceArmServiceBusSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).serviceBusSubscriptionsClient
+	client := meta.(*ArmClient).serviceBusSubscriptionsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ServiceBus/namespaces/topics/subscriptions")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	topicName := id.Path["topics"]
-	name := id.Path["subscriptions"]
+	namespaceName := names[0]
+	topicName := names[1]
+	name := names[2]
 
 	log.Printf("[INFO] subscriptionID: %s, args: %s, %s, %s, %s", d.Id(), resGroup, namespaceName, topicName, name)
 
@@ -189,16 +189,16 @@ func resourceArmServiceBusSubscriptionRead(d *schema.ResourceData, meta interfac
 }
 
 func resourceArmServiceBusSubscriptionDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).serviceBusSubscriptionsClient
+	client := meta.(*ArmClient).serviceBusSubscriptionsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ServiceBus/namespaces/topics/subscriptions")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	topicName := id.Path["topics"]
-	name := id.Path["subscriptions"]
+	namespaceName := names[0]
+	topicName := names[1]
+	name := names[2]
 
 	_, err = client.Delete(resGroup, namespaceName, topicName, name)
 
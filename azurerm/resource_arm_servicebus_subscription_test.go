@@ -5,13 +5,12 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMServiceBusSubscription_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMServiceBusSubscription_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -31,7 +30,7 @@ func TestAccAzureRMServiceBusSubscription_basic(t *testing.T) {
 
 func TestAccAzureRMServiceBusSubscription_update(t *testing.T) {
 	resourceName := "azurerm_servicebus_subscription.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMServiceBusSubscription_basic(ri, location)
 	postConfig := testAccAzureRMServiceBusSubscription_update(ri, location)
@@ -59,7 +58,7 @@ func TestAccAzureRMServiceBusSubscription_update(t *testing.T) {
 
 func TestAccAzureRMServiceBusSubscription_updateRequiresSession(t *testing.T) {
 	resourceName := "azurerm_servicebus_subscription.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMServiceBusSubscription_basic(ri, location)
 	postConfig := testAccAzureRMServiceBusSubscription_updateRequiresSession(ri, location)
@@ -86,7 +85,7 @@ func TestAccAzureRMServiceBusSubscription_updateRequiresSession(t *testing.T) {
 }
 
 func testCheckAzureRMServiceBusSubscriptionDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*ArmClient).serviceBusSubscriptionsClient
+	client := testAccProvider.Meta().(*ArmClient).serviceBusSubscriptionsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_servicebus_subscription" {
@@ -130,7 +129,7 @@ func testCheckAzureRMServiceBusSubscriptionExists(name string) resource.TestChec
 			return fmt.Errorf("Bad: no resource group found in state for subscription: %s", topicName)
 		}
 
-		client := testAccProvider.Meta().(*ArmClient).serviceBusSubscriptionsClient
+		client := testAccProvider.Meta().(*ArmClient).serviceBusSubscriptionsClient()
 
 		resp, err := client.Get(resourceGroup, namespaceName, topicName, subscriptionName)
 		if err != nil {
@@ -112,7 +112,7 @@ func resourceArmServiceBusTopic() *schema.Resource {
 }
 
 func resourceArmServiceBusTopicCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).serviceBusTopicsClient
+	client := meta.(*ArmClient).serviceBusTopicsClient()
 	log.Printf("[INFO] preparing arguments for Azure ARM ServiceBus Topic creation.")
 
 	name := d.Get("name").(string)
@@ -175,15 +175,15 @@ func resourceArmServiceBusTopicCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceArmServiceBusTopicRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).serviceBusTopicsClient
+	client := meta.(*ArmClient).serviceBusTopicsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ServiceBus/namespaces/topics")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	name := id.Path["topics"]
+	namespaceName := names[0]
+	name := names[1]
 
 	resp, err := client.Get(resGroup, namespaceName, name)
 	if err != nil {
@@ -220,7 +220,7 @@ func resourceArmServiceBusTopicRead(d *schema.ResourceData, meta interface{}) er
 	// if the topic is in a premium namespace and partitioning is enabled then the
 	// max size returned by the API will be 16 times greater than the value set
 	if *props.EnablePartitioning {
-		namespace, err := meta.(*ArmClient).serviceBusNamespacesClient.Get(resGroup, namespaceName)
+		namespace, err := meta.(*ArmClient).serviceBusNamespacesClient().Get(resGroup, namespaceName)
 		if err != nil {
 			return err
 		}
@@ -237,15 +237,15 @@ func resourceArmServiceBusTopicRead(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceArmServiceBusTopicDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).serviceBusTopicsClient
+	client := meta.(*ArmClient).serviceBusTopicsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.ServiceBus/namespaces/topics")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	namespaceName := id.Path["namespaces"]
-	name := id.Path["topics"]
+	namespaceName := names[0]
+	name := names[1]
 
 	_, err = client.Delete(resGroup, namespaceName, name)
 
@@ -3,10 +3,12 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/arm/sql"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/jen20/riviera/azure"
-	"github.com/jen20/riviera/sql"
+	"github.com/satori/uuid"
 )
 
 func resourceArmSqlDatabase() *schema.Resource {
@@ -15,6 +17,9 @@ func resourceArmSqlDatabase() *schema.Resource {
 		Read:   resourceArmSqlDatabaseRead,
 		Update: resourceArmSqlDatabaseCreate,
 		Delete: resourceArmSqlDatabaseDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -44,15 +49,17 @@ func resourceArmSqlDatabase() *schema.Resource {
 			},
 
 			"source_database_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Sql/servers/databases"),
 			},
 
 			"restore_point_in_time": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateRFC3339Date,
 			},
 
 			"edition": {
@@ -75,9 +82,11 @@ func resourceArmSqlDatabase() *schema.Resource {
 			},
 
 			"requested_service_objective_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validateUUID,
+				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
 			},
 
 			"requested_service_objective_name": {
@@ -87,9 +96,10 @@ func resourceArmSqlDatabase() *schema.Resource {
 			},
 
 			"source_database_deletion_date": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateRFC3339Date,
 			},
 
 			"elastic_pool_name": {
@@ -119,134 +129,171 @@ func resourceArmSqlDatabase() *schema.Resource {
 }
 
 func resourceArmSqlDatabaseCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
-
-	tags := d.Get("tags").(map[string]interface{})
-	expandedTags := expandTags(tags)
-
-	command := &sql.CreateOrUpdateDatabase{
-		Name:              d.Get("name").(string),
-		Location:          d.Get("location").(string),
-		ResourceGroupName: d.Get("resource_group_name").(string),
-		ServerName:        d.Get("server_name").(string),
-		Tags:              *expandedTags,
-		CreateMode:        azure.String(d.Get("create_mode").(string)),
-	}
-
-	if v, ok := d.GetOk("source_database_id"); ok {
-		command.SourceDatabaseID = azure.String(v.(string))
-	}
+	client := meta.(*ArmClient).sqlDatabasesClient()
 
-	if v, ok := d.GetOk("edition"); ok {
-		command.Edition = azure.String(v.(string))
-	}
+	name := d.Get("name").(string)
+	serverName := d.Get("server_name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	location := d.Get("location").(string)
 
-	if v, ok := d.GetOk("collation"); ok {
-		command.Collation = azure.String(v.(string))
+	properties, err := expandAzureRmSqlDatabaseProperties(d)
+	if err != nil {
+		return err
 	}
 
-	if v, ok := d.GetOk("max_size_bytes"); ok {
-		command.MaxSizeBytes = azure.String(v.(string))
+	parameters := sql.Database{
+		Location:           &location,
+		Tags:               expandTagsForResource(d, meta),
+		DatabaseProperties: properties,
 	}
 
-	if v, ok := d.GetOk("source_database_deletion_date"); ok {
-		command.SourceDatabaseDeletionDate = azure.String(v.(string))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := client.CreateOrUpdate(resGroup, serverName, name, parameters, ctx.Done())
+	err = <-error
+	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Sql/servers/databases", serverName, name)
+		return fmt.Errorf("Error creating SQL Database %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resGroup, err)
 	}
 
-	if v, ok := d.GetOk("requested_service_objective_id"); ok {
-		command.RequestedServiceObjectiveID = azure.String(v.(string))
+	read, err := client.Get(resGroup, serverName, name, "")
+	if err != nil {
+		return fmt.Errorf("Error reading SQL Database %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resGroup, err)
 	}
-
-	if v, ok := d.GetOk("elastic_pool_name"); ok {
-		command.ElasticPoolName = azure.String(v.(string))
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read SQL Database %q (SQL Server %q / Resource Group %q) ID", name, serverName, resGroup)
 	}
 
-	if v, ok := d.GetOk("requested_service_objective_name"); ok {
-		command.RequestedServiceObjectiveName = azure.String(v.(string))
-	}
+	d.SetId(*read.ID)
 
-	if v, ok := d.GetOk("restore_point_in_time"); ok {
-		command.RestorePointInTime = azure.String(v.(string))
-	}
+	return resourceArmSqlDatabaseRead(d, meta)
+}
 
-	createRequest := rivieraClient.NewRequest()
-	createRequest.Command = command
+func resourceArmSqlDatabaseRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).sqlDatabasesClient()
 
-	createResponse, err := createRequest.Execute()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Sql/servers/databases")
 	if err != nil {
-		return fmt.Errorf("Error creating SQL Database: %s", err)
-	}
-	if !createResponse.IsSuccessful() {
-		return fmt.Errorf("Error creating SQL Database: %s", createResponse.Error)
+		return err
 	}
+	resGroup := id.ResourceGroup
+	serverName := names[0]
+	name := names[1]
 
-	readRequest := rivieraClient.NewRequest()
-	readRequest.Command = &sql.GetDatabase{
-		Name:              d.Get("name").(string),
-		ResourceGroupName: d.Get("resource_group_name").(string),
-		ServerName:        d.Get("server_name").(string),
+	resp, err := client.Get(resGroup, serverName, name, "")
+	if err != nil {
+		if responseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Error reading SQL Database %q - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading SQL Database %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resGroup, err)
 	}
 
-	readResponse, err := readRequest.Execute()
-	if err != nil {
-		return fmt.Errorf("Error reading SQL Database: %s", err)
+	d.Set("name", name)
+	d.Set("resource_group_name", resGroup)
+	d.Set("server_name", serverName)
+	if resp.Location != nil {
+		d.Set("location", azureRMNormalizeLocation(*resp.Location))
 	}
-	if !readResponse.IsSuccessful() {
-		return fmt.Errorf("Error reading SQL Database: %s", readResponse.Error)
+
+	if props := resp.DatabaseProperties; props != nil {
+		d.Set("edition", string(props.Edition))
+		d.Set("collation", props.Collation)
+		d.Set("max_size_bytes", props.MaxSizeBytes)
+		d.Set("requested_service_objective_name", string(props.RequestedServiceObjectiveName))
+		if props.RequestedServiceObjectiveID != nil {
+			d.Set("requested_service_objective_id", props.RequestedServiceObjectiveID.String())
+		}
+		d.Set("elastic_pool_name", props.ElasticPoolName)
+		d.Set("default_secondary_location", props.DefaultSecondaryLocation)
+		if props.CreationDate != nil {
+			d.Set("creation_date", props.CreationDate.Format(time.RFC3339))
+		}
 	}
 
-	resp := readResponse.Parsed.(*sql.GetDatabaseResponse)
-	d.SetId(*resp.ID)
+	flattenAndSetTags(d, resp.Tags, meta)
 
-	return resourceArmSqlDatabaseRead(d, meta)
+	return nil
 }
 
-func resourceArmSqlDatabaseRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+func resourceArmSqlDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).sqlDatabasesClient()
 
-	readRequest := rivieraClient.NewRequestForURI(d.Id())
-	readRequest.Command = &sql.GetDatabase{}
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Sql/servers/databases")
+	if err != nil {
+		return err
+	}
 
-	readResponse, err := readRequest.Execute()
+	resp, err := client.Delete(id.ResourceGroup, names[0], names[1])
 	if err != nil {
-		return fmt.Errorf("Error reading SQL Database: %s", err)
+		if responseWasNotFound(resp) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting SQL Database %q (SQL Server %q / Resource Group %q): %+v", names[1], names[0], id.ResourceGroup, err)
+	}
+
+	return nil
+}
+
+func expandAzureRmSqlDatabaseProperties(d *schema.ResourceData) (*sql.DatabaseProperties, error) {
+	properties := sql.DatabaseProperties{
+		CreateMode: sql.CreateMode(d.Get("create_mode").(string)),
 	}
-	if !readResponse.IsSuccessful() {
-		log.Printf("[INFO] Error reading SQL Database %q - removing from state", d.Id())
-		d.SetId("")
-		return fmt.Errorf("Error reading SQL Database: %s", readResponse.Error)
+
+	if v, ok := d.GetOk("source_database_id"); ok {
+		sourceDatabaseID := v.(string)
+		properties.SourceDatabaseID = &sourceDatabaseID
 	}
 
-	resp := readResponse.Parsed.(*sql.GetDatabaseResponse)
+	if v, ok := d.GetOk("edition"); ok {
+		properties.Edition = sql.DatabaseEdition(v.(string))
+	}
 
-	d.Set("name", resp.Name)
-	d.Set("creation_date", resp.CreationDate)
-	d.Set("default_secondary_location", resp.DefaultSecondaryLocation)
-	d.Set("elastic_pool_name", resp.ElasticPoolName)
+	if v, ok := d.GetOk("collation"); ok {
+		collation := v.(string)
+		properties.Collation = &collation
+	}
 
-	flattenAndSetTags(d, resp.Tags)
+	if v, ok := d.GetOk("max_size_bytes"); ok {
+		maxSizeBytes := v.(string)
+		properties.MaxSizeBytes = &maxSizeBytes
+	}
 
-	return nil
-}
+	if v, ok := d.GetOk("source_database_deletion_date"); ok {
+		deletionDate, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing `source_database_deletion_date`: %s", err)
+		}
+		properties.SourceDatabaseDeletionDate = &date.Time{Time: deletionDate}
+	}
 
-func resourceArmSqlDatabaseDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+	if v, ok := d.GetOk("requested_service_objective_id"); ok {
+		serviceObjectiveID, err := uuid.FromString(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing `requested_service_objective_id`: %s", err)
+		}
+		properties.RequestedServiceObjectiveID = &serviceObjectiveID
+	}
 
-	deleteRequest := rivieraClient.NewRequestForURI(d.Id())
-	deleteRequest.Command = &sql.DeleteDatabase{}
+	if v, ok := d.GetOk("elastic_pool_name"); ok {
+		elasticPoolName := v.(string)
+		properties.ElasticPoolName = &elasticPoolName
+	}
 
-	deleteResponse, err := deleteRequest.Execute()
-	if err != nil {
-		return fmt.Errorf("Error deleting SQL Database: %s", err)
+	if v, ok := d.GetOk("requested_service_objective_name"); ok {
+		properties.RequestedServiceObjectiveName = sql.ServiceObjectiveName(v.(string))
 	}
-	if !deleteResponse.IsSuccessful() {
-		return fmt.Errorf("Error deleting SQL Database: %s", deleteResponse.Error)
+
+	if v, ok := d.GetOk("restore_point_in_time"); ok {
+		restorePoint, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing `restore_point_in_time`: %s", err)
+		}
+		properties.RestorePointInTime = &date.Time{Time: restorePoint}
 	}
 
-	return nil
+	return &properties, nil
 }
 
 func validateArmSqlDatabaseEdition(v interface{}, k string) (ws []string, errors []error) {
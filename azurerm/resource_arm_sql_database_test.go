@@ -2,13 +2,12 @@ package azurerm
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/jen20/riviera/sql"
 )
 
 func TestResourceAzureRMSqlDatabaseEdition_validation(t *testing.T) {
@@ -48,7 +47,7 @@ func TestResourceAzureRMSqlDatabaseEdition_validation(t *testing.T) {
 }
 
 func TestAccAzureRMSqlDatabase_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMSqlDatabase_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -67,7 +66,7 @@ func TestAccAzureRMSqlDatabase_basic(t *testing.T) {
 }
 
 func TestAccAzureRMSqlDatabase_elasticPool(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMSqlDatabase_elasticPool(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -87,7 +86,7 @@ func TestAccAzureRMSqlDatabase_elasticPool(t *testing.T) {
 }
 
 func TestAccAzureRMSqlDatabase_withTags(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMSqlDatabase_withTags(ri, location)
 	postConfig := testAccAzureRMSqlDatabase_withTagsUpdate(ri, location)
@@ -118,7 +117,7 @@ func TestAccAzureRMSqlDatabase_withTags(t *testing.T) {
 }
 
 func TestAccAzureRMSqlDatabase_dataWarehouse(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMSqlDatabase_dataWarehouse(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -137,7 +136,7 @@ func TestAccAzureRMSqlDatabase_dataWarehouse(t *testing.T) {
 }
 
 func TestAccAzureRMSqlDatabase_restorePointInTime(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMSqlDatabase_basic(ri, location)
 	timeToRestore := time.Now().Add(15 * time.Minute)
@@ -157,7 +156,11 @@ func TestAccAzureRMSqlDatabase_restorePointInTime(t *testing.T) {
 				),
 			},
 			{
-				PreConfig: func() { time.Sleep(timeToRestore.Sub(time.Now().Add(-1 * time.Minute))) },
+				PreConfig: func() {
+					if !testReplaying() {
+						time.Sleep(timeToRestore.Sub(time.Now().Add(-1 * time.Minute)))
+					}
+				},
 				Config:    postCongif,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMSqlDatabaseExists("azurerm_sql_database.test"),
@@ -176,17 +179,19 @@ func testCheckAzureRMSqlDatabaseExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Not found: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).rivieraClient
+		name := rs.Primary.Attributes["name"]
+		serverName := rs.Primary.Attributes["server_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
-		readRequest := conn.NewRequestForURI(rs.Primary.ID)
-		readRequest.Command = &sql.GetDatabase{}
+		conn := testAccProvider.Meta().(*ArmClient).sqlDatabasesClient()
 
-		readResponse, err := readRequest.Execute()
+		resp, err := conn.Get(resourceGroup, serverName, name, "")
 		if err != nil {
-			return fmt.Errorf("Bad: GetDatabase: %s", err)
+			return fmt.Errorf("Bad: Get on sqlDatabasesClient: %+v", err)
 		}
-		if !readResponse.IsSuccessful() {
-			return fmt.Errorf("Bad: GetDatabase: %s", readResponse.Error)
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: SQL Database %q (resource group: %q) does not exist", name, resourceGroup)
 		}
 
 		return nil
@@ -194,24 +199,26 @@ func testCheckAzureRMSqlDatabaseExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMSqlDatabaseDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).rivieraClient
+	conn := testAccProvider.Meta().(*ArmClient).sqlDatabasesClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_sql_database" {
 			continue
 		}
 
-		readRequest := conn.NewRequestForURI(rs.Primary.ID)
-		readRequest.Command = &sql.GetDatabase{}
+		name := rs.Primary.Attributes["name"]
+		serverName := rs.Primary.Attributes["server_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
-		readResponse, err := readRequest.Execute()
+		resp, err := conn.Get(resourceGroup, serverName, name, "")
 		if err != nil {
-			return fmt.Errorf("Bad: GetDatabase: %s", err)
+			if resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return err
 		}
 
-		if readResponse.IsSuccessful() {
-			return fmt.Errorf("Bad: SQL Database still exists: %s", readResponse.Error)
-		}
+		return fmt.Errorf("Bad: SQL Database still exists:\n%#v", resp.DatabaseProperties)
 	}
 
 	return nil
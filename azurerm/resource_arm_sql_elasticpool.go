@@ -84,7 +84,7 @@ func resourceArmSqlElasticPool() *schema.Resource {
 
 func resourceArmSqlElasticPoolCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	elasticPoolsClient := client.sqlElasticPoolsClient
+	elasticPoolsClient := client.sqlElasticPoolsClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM SQL ElasticPool creation.")
 
@@ -92,18 +92,20 @@ func resourceArmSqlElasticPoolCreate(d *schema.ResourceData, meta interface{}) e
 	serverName := d.Get("server_name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	elasticPool := sql.ElasticPool{
 		Name:                  &name,
 		Location:              &location,
 		ElasticPoolProperties: getArmSqlElasticPoolProperties(d),
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
-	_, error := elasticPoolsClient.CreateOrUpdate(resGroup, serverName, name, elasticPool, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := elasticPoolsClient.CreateOrUpdate(resGroup, serverName, name, elasticPool, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Sql/servers/elasticPools", serverName, name)
 		return err
 	}
 
@@ -122,7 +124,7 @@ func resourceArmSqlElasticPoolCreate(d *schema.ResourceData, meta interface{}) e
 
 func resourceArmSqlElasticPoolRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	elasticPoolsClient := client.sqlElasticPoolsClient
+	elasticPoolsClient := client.sqlElasticPoolsClient()
 
 	resGroup, serverName, name, err := parseArmSqlElasticPoolId(d.Id())
 	if err != nil {
@@ -157,14 +159,14 @@ func resourceArmSqlElasticPoolRead(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmSqlElasticPoolDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	elasticPoolsClient := client.sqlElasticPoolsClient
+	elasticPoolsClient := client.sqlElasticPoolsClient()
 
 	resGroup, serverName, name, err := parseArmSqlElasticPoolId(d.Id())
 	if err != nil {
@@ -204,12 +206,12 @@ func getArmSqlElasticPoolProperties(d *schema.ResourceData) *sql.ElasticPoolProp
 }
 
 func parseArmSqlElasticPoolId(sqlElasticPoolId string) (string, string, string, error) {
-	id, err := parseAzureResourceID(sqlElasticPoolId)
+	id, names, err := parseAzureResourceIDOfType(sqlElasticPoolId, "Microsoft.Sql/servers/elasticPools")
 	if err != nil {
 		return "", "", "", fmt.Errorf("[ERROR] Unable to parse SQL ElasticPool ID '%s': %+v", sqlElasticPoolId, err)
 	}
 
-	return id.ResourceGroup, id.Path["servers"], id.Path["elasticPools"], nil
+	return id.ResourceGroup, names[0], names[1], nil
 }
 
 func validateSqlElasticPoolEdition() schema.SchemaValidateFunc {
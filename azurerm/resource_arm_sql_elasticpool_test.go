@@ -5,13 +5,12 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMSqlElasticPool_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMSqlElasticPool_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -31,7 +30,7 @@ func TestAccAzureRMSqlElasticPool_basic(t *testing.T) {
 
 func TestAccAzureRMSqlElasticPool_resizeDtu(t *testing.T) {
 	resourceName := "azurerm_sql_elasticpool.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMSqlElasticPool_basic(ri, location)
 	postConfig := testAccAzureRMSqlElasticPool_resizedDtu(ri, location)
@@ -73,7 +72,7 @@ func testCheckAzureRMSqlElasticPoolExists(name string) resource.TestCheckFunc {
 			return err
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).sqlElasticPoolsClient
+		conn := testAccProvider.Meta().(*ArmClient).sqlElasticPoolsClient()
 
 		resp, err := conn.Get(resourceGroup, serverName, name)
 		if err != nil {
@@ -89,7 +88,7 @@ func testCheckAzureRMSqlElasticPoolExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMSqlElasticPoolDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).sqlElasticPoolsClient
+	conn := testAccProvider.Meta().(*ArmClient).sqlElasticPoolsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_sql_elasticpool" {
@@ -4,9 +4,8 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/Azure/azure-sdk-for-go/arm/sql"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/jen20/riviera/azure"
-	"github.com/jen20/riviera/sql"
 )
 
 func resourceArmSqlFirewallRule() *schema.Resource {
@@ -52,95 +51,86 @@ func resourceArmSqlFirewallRule() *schema.Resource {
 }
 
 func resourceArmSqlFirewallRuleCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
-
-	createRequest := rivieraClient.NewRequest()
-	createRequest.Command = &sql.CreateOrUpdateFirewallRule{
-		Name:              d.Get("name").(string),
-		ResourceGroupName: d.Get("resource_group_name").(string),
-		ServerName:        d.Get("server_name").(string),
-		StartIPAddress:    azure.String(d.Get("start_ip_address").(string)),
-		EndIPAddress:      azure.String(d.Get("end_ip_address").(string)),
+	client := meta.(*ArmClient).sqlFirewallRulesClient()
+
+	name := d.Get("name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	serverName := d.Get("server_name").(string)
+	startIPAddress := d.Get("start_ip_address").(string)
+	endIPAddress := d.Get("end_ip_address").(string)
+
+	parameters := sql.FirewallRule{
+		FirewallRuleProperties: &sql.FirewallRuleProperties{
+			StartIPAddress: &startIPAddress,
+			EndIPAddress:   &endIPAddress,
+		},
 	}
 
-	createResponse, err := createRequest.Execute()
+	_, err := client.CreateOrUpdate(resGroup, serverName, name, parameters)
 	if err != nil {
-		return fmt.Errorf("Error creating SQL Server Firewall Rule: %s", err)
-	}
-	if !createResponse.IsSuccessful() {
-		return fmt.Errorf("Error creating SQL Server Firewall Rule: %s", createResponse.Error)
-	}
-
-	readRequest := rivieraClient.NewRequest()
-	readRequest.Command = &sql.GetFirewallRule{
-		Name:              d.Get("name").(string),
-		ResourceGroupName: d.Get("resource_group_name").(string),
-		ServerName:        d.Get("server_name").(string),
+		return fmt.Errorf("Error creating SQL Server Firewall Rule %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resGroup, err)
 	}
 
-	readResponse, err := readRequest.Execute()
+	read, err := client.Get(resGroup, serverName, name)
 	if err != nil {
-		return fmt.Errorf("Error reading SQL Server Firewall Rule: %s", err)
+		return fmt.Errorf("Error reading SQL Server Firewall Rule %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resGroup, err)
 	}
-	if !readResponse.IsSuccessful() {
-		return fmt.Errorf("Error reading SQL Server Firewall Rule: %s", readResponse.Error)
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read SQL Server Firewall Rule %q (SQL Server %q / Resource Group %q) ID", name, serverName, resGroup)
 	}
 
-	resp := readResponse.Parsed.(*sql.GetFirewallRuleResponse)
-	d.SetId(*resp.ID)
+	d.SetId(*read.ID)
 
 	return resourceArmSqlFirewallRuleRead(d, meta)
 }
 
 func resourceArmSqlFirewallRuleRead(d *schema.ResourceData, meta interface{}) error {
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Sql/servers/firewallRules")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
+	serverName := names[0]
+	name := names[1]
 
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+	client := meta.(*ArmClient).sqlFirewallRulesClient()
 
-	readRequest := rivieraClient.NewRequestForURI(d.Id())
-	readRequest.Command = &sql.GetFirewallRule{}
-
-	readResponse, err := readRequest.Execute()
+	resp, err := client.Get(resGroup, serverName, name)
 	if err != nil {
-		return fmt.Errorf("Error reading SQL Server Firewall Rule: %s", err)
-	}
-	if !readResponse.IsSuccessful() {
-		log.Printf("[INFO] Error reading SQL Server Firewall Rule %q - removing from state", d.Id())
-		d.SetId("")
-		return fmt.Errorf("Error reading SQL Server Firewall Rule: %s", readResponse.Error)
+		if responseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Error reading SQL Server Firewall Rule %q - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading SQL Server Firewall Rule %q (SQL Server %q / Resource Group %q): %+v", name, serverName, resGroup, err)
 	}
 
-	resp := readResponse.Parsed.(*sql.GetFirewallRuleResponse)
-
 	d.Set("resource_group_name", resGroup)
-	d.Set("location", azureRMNormalizeLocation(*resp.Location))
-	d.Set("name", resp.Name)
-	d.Set("server_name", id.Path["servers"])
-	d.Set("start_ip_address", resp.StartIPAddress)
-	d.Set("end_ip_address", resp.EndIPAddress)
+	d.Set("name", name)
+	d.Set("server_name", serverName)
+
+	if props := resp.FirewallRuleProperties; props != nil {
+		d.Set("start_ip_address", props.StartIPAddress)
+		d.Set("end_ip_address", props.EndIPAddress)
+	}
 
 	return nil
 }
 
 func resourceArmSqlFirewallRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
-
-	deleteRequest := rivieraClient.NewRequestForURI(d.Id())
-	deleteRequest.Command = &sql.DeleteFirewallRule{}
+	client := meta.(*ArmClient).sqlFirewallRulesClient()
 
-	deleteResponse, err := deleteRequest.Execute()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Sql/servers/firewallRules")
 	if err != nil {
-		return fmt.Errorf("Error deleting SQL Server Firewall Rule: %s", err)
+		return err
 	}
-	if !deleteResponse.IsSuccessful() {
-		return fmt.Errorf("Error deleting SQL Server Firewall Rule: %s", deleteResponse.Error)
+
+	resp, err := client.Delete(id.ResourceGroup, names[0], names[1])
+	if err != nil {
+		if responseWasNotFound(resp) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting SQL Server Firewall Rule %q (SQL Server %q / Resource Group %q): %+v", names[1], names[0], id.ResourceGroup, err)
 	}
 
 	return nil
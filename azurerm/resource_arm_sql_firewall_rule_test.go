@@ -2,17 +2,16 @@ package azurerm
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/jen20/riviera/sql"
 )
 
 func TestAccAzureRMSqlFirewallRule_basic(t *testing.T) {
 	resourceName := "azurerm_sql_firewall_rule.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	preConfig := testAccAzureRMSqlFirewallRule_basic(ri, testLocation())
 	postConfig := testAccAzureRMSqlFirewallRule_withUpdates(ri, testLocation())
 
@@ -49,17 +48,19 @@ func testCheckAzureRMSqlFirewallRuleExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Not found: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).rivieraClient
+		name := rs.Primary.Attributes["name"]
+		serverName := rs.Primary.Attributes["server_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
-		readRequest := conn.NewRequestForURI(rs.Primary.ID)
-		readRequest.Command = &sql.GetFirewallRule{}
+		conn := testAccProvider.Meta().(*ArmClient).sqlFirewallRulesClient()
 
-		readResponse, err := readRequest.Execute()
+		resp, err := conn.Get(resourceGroup, serverName, name)
 		if err != nil {
-			return fmt.Errorf("Bad: GetFirewallRule: %+v", err)
+			return fmt.Errorf("Bad: Get on sqlFirewallRulesClient: %+v", err)
 		}
-		if !readResponse.IsSuccessful() {
-			return fmt.Errorf("Bad: GetFirewallRule: %+v", readResponse.Error)
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: SQL Firewall Rule %q (resource group: %q) does not exist", name, resourceGroup)
 		}
 
 		return nil
@@ -67,24 +68,26 @@ func testCheckAzureRMSqlFirewallRuleExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMSqlFirewallRuleDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).rivieraClient
+	conn := testAccProvider.Meta().(*ArmClient).sqlFirewallRulesClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_sql_firewall_rule" {
 			continue
 		}
 
-		readRequest := conn.NewRequestForURI(rs.Primary.ID)
-		readRequest.Command = &sql.GetFirewallRule{}
+		name := rs.Primary.Attributes["name"]
+		serverName := rs.Primary.Attributes["server_name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
-		readResponse, err := readRequest.Execute()
+		resp, err := conn.Get(resourceGroup, serverName, name)
 		if err != nil {
-			return fmt.Errorf("Bad: GetFirewallRule: %+v", err)
+			if resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return err
 		}
 
-		if readResponse.IsSuccessful() {
-			return fmt.Errorf("Bad: SQL Server Firewall Rule still exists: %+v", readResponse.Error)
-		}
+		return fmt.Errorf("Bad: SQL Firewall Rule still exists:\n%#v", resp.FirewallRuleProperties)
 	}
 
 	return nil
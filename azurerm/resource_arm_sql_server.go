@@ -4,9 +4,8 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/Azure/azure-sdk-for-go/arm/sql"
 	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/jen20/riviera/azure"
-	"github.com/jen20/riviera/sql"
 )
 
 func resourceArmSqlServer() *schema.Resource {
@@ -61,100 +60,93 @@ func resourceArmSqlServer() *schema.Resource {
 }
 
 func resourceArmSqlServerCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
-
-	tags := d.Get("tags").(map[string]interface{})
-	expandedTags := expandTags(tags)
-
-	createRequest := rivieraClient.NewRequest()
-	createRequest.Command = &sql.CreateOrUpdateServer{
-		Name:                       d.Get("name").(string),
-		Location:                   d.Get("location").(string),
-		ResourceGroupName:          d.Get("resource_group_name").(string),
-		AdministratorLogin:         azure.String(d.Get("administrator_login").(string)),
-		AdministratorLoginPassword: azure.String(d.Get("administrator_login_password").(string)),
-		Version:                    azure.String(d.Get("version").(string)),
-		Tags:                       *expandedTags,
+	client := meta.(*ArmClient).sqlServersClient()
+
+	name := d.Get("name").(string)
+	resGroup := d.Get("resource_group_name").(string)
+	location := d.Get("location").(string)
+	adminUsername := d.Get("administrator_login").(string)
+	adminPassword := d.Get("administrator_login_password").(string)
+
+	parameters := sql.Server{
+		Location: &location,
+		Tags:     expandTagsForResource(d, meta),
+		ServerProperties: &sql.ServerProperties{
+			Version:                    sql.ServerVersion(d.Get("version").(string)),
+			AdministratorLogin:         &adminUsername,
+			AdministratorLoginPassword: &adminPassword,
+		},
 	}
 
-	createResponse, err := createRequest.Execute()
+	_, err := client.CreateOrUpdate(resGroup, name, parameters)
 	if err != nil {
-		return fmt.Errorf("Error creating SQL Server: %s", err)
-	}
-	if !createResponse.IsSuccessful() {
-		return fmt.Errorf("Error creating SQL Server: %s", createResponse.Error)
-	}
-
-	readRequest := rivieraClient.NewRequest()
-	readRequest.Command = &sql.GetServer{
-		Name:              d.Get("name").(string),
-		ResourceGroupName: d.Get("resource_group_name").(string),
+		return fmt.Errorf("Error creating SQL Server %q (Resource Group %q): %+v", name, resGroup, err)
 	}
 
-	readResponse, err := readRequest.Execute()
+	read, err := client.Get(resGroup, name)
 	if err != nil {
-		return fmt.Errorf("Error reading SQL Server: %s", err)
+		return fmt.Errorf("Error reading SQL Server %q (Resource Group %q): %+v", name, resGroup, err)
 	}
-	if !readResponse.IsSuccessful() {
-		return fmt.Errorf("Error reading SQL Server: %s", readResponse.Error)
+	if read.ID == nil {
+		return fmt.Errorf("Cannot read SQL Server %q (Resource Group %q) ID", name, resGroup)
 	}
 
-	resp := readResponse.Parsed.(*sql.GetServerResponse)
-	d.SetId(*resp.ID)
+	d.SetId(*read.ID)
 
 	return resourceArmSqlServerRead(d, meta)
 }
 
 func resourceArmSqlServerRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
+	client := meta.(*ArmClient).sqlServersClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Sql/servers")
 	if err != nil {
 		return err
 	}
+	resGroup := id.ResourceGroup
+	name := names[0]
 
-	readRequest := rivieraClient.NewRequestForURI(d.Id())
-	readRequest.Command = &sql.GetServer{}
-
-	readResponse, err := readRequest.Execute()
+	resp, err := client.Get(resGroup, name)
 	if err != nil {
-		return fmt.Errorf("Error reading SQL Server: %s", err)
-	}
-	if !readResponse.IsSuccessful() {
-		log.Printf("[INFO] Error reading SQL Server %q - removing from state", d.Id())
-		d.SetId("")
-		return fmt.Errorf("Error reading SQL Server: %s", readResponse.Error)
+		if responseWasNotFound(resp.Response) {
+			log.Printf("[INFO] Error reading SQL Server %q - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading SQL Server %q (Resource Group %q): %+v", name, resGroup, err)
 	}
 
-	resp := readResponse.Parsed.(*sql.GetServerResponse)
+	d.Set("name", name)
+	d.Set("resource_group_name", resGroup)
+	if resp.Location != nil {
+		d.Set("location", azureRMNormalizeLocation(*resp.Location))
+	}
 
-	d.Set("name", id.Path["servers"])
-	d.Set("resource_group_name", id.ResourceGroup)
-	d.Set("location", azureRMNormalizeLocation(*resp.Location))
-	d.Set("fully_qualified_domain_name", resp.FullyQualifiedDomainName)
-	d.Set("administrator_login", resp.AdministratorLogin)
-	d.Set("version", resp.Version)
+	if props := resp.ServerProperties; props != nil {
+		d.Set("fully_qualified_domain_name", props.FullyQualifiedDomainName)
+		d.Set("administrator_login", props.AdministratorLogin)
+		d.Set("version", string(props.Version))
+	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmSqlServerDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient)
-	rivieraClient := client.rivieraClient
-
-	deleteRequest := rivieraClient.NewRequestForURI(d.Id())
-	deleteRequest.Command = &sql.DeleteServer{}
+	client := meta.(*ArmClient).sqlServersClient()
 
-	deleteResponse, err := deleteRequest.Execute()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Sql/servers")
 	if err != nil {
-		return fmt.Errorf("Error deleting SQL Server: %s", err)
+		return err
 	}
-	if !deleteResponse.IsSuccessful() {
-		return fmt.Errorf("Error deleting SQL Server: %s", deleteResponse.Error)
+
+	resp, err := client.Delete(id.ResourceGroup, names[0])
+	if err != nil {
+		if responseWasNotFound(resp) {
+			return nil
+		}
+		return fmt.Errorf("Error deleting SQL Server %q (Resource Group %q): %+v", names[0], id.ResourceGroup, err)
 	}
 
 	return nil
@@ -2,16 +2,15 @@ package azurerm
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
-	"github.com/jen20/riviera/sql"
 )
 
 func TestAccAzureRMSqlServer_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMSqlServer_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -31,7 +30,7 @@ func TestAccAzureRMSqlServer_basic(t *testing.T) {
 
 func TestAccAzureRMSqlServer_withTags(t *testing.T) {
 	resourceName := "azurerm_sql_server.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMSqlServer_withTags(ri, location)
 	postConfig := testAccAzureRMSqlServer_withTagsUpdated(ri, location)
@@ -67,17 +66,18 @@ func testCheckAzureRMSqlServerExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Not found: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).rivieraClient
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
-		readRequest := conn.NewRequestForURI(rs.Primary.ID)
-		readRequest.Command = &sql.GetServer{}
+		conn := testAccProvider.Meta().(*ArmClient).sqlServersClient()
 
-		readResponse, err := readRequest.Execute()
+		resp, err := conn.Get(resourceGroup, name)
 		if err != nil {
-			return fmt.Errorf("Bad: GetServer: %+v", err)
+			return fmt.Errorf("Bad: Get on sqlServersClient: %+v", err)
 		}
-		if !readResponse.IsSuccessful() {
-			return fmt.Errorf("Bad: GetServer: %+v", readResponse.Error)
+
+		if resp.StatusCode == http.StatusNotFound {
+			return fmt.Errorf("Bad: SQL Server %q (resource group: %q) does not exist", name, resourceGroup)
 		}
 
 		return nil
@@ -85,24 +85,25 @@ func testCheckAzureRMSqlServerExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMSqlServerDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).rivieraClient
+	conn := testAccProvider.Meta().(*ArmClient).sqlServersClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_sql_server" {
 			continue
 		}
 
-		readRequest := conn.NewRequestForURI(rs.Primary.ID)
-		readRequest.Command = &sql.GetServer{}
+		name := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
-		readResponse, err := readRequest.Execute()
+		resp, err := conn.Get(resourceGroup, name)
 		if err != nil {
-			return fmt.Errorf("Bad: GetServer: % +v", err)
+			if resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return err
 		}
 
-		if readResponse.IsSuccessful() {
-			return fmt.Errorf("Bad: SQL Server still exists: %+v", readResponse.Error)
-		}
+		return fmt.Errorf("Bad: SQL Server still exists:\n%#v", resp.ServerProperties)
 	}
 
 	return nil
@@ -158,7 +158,7 @@ func resourceArmStorageAccount() *schema.Resource {
 
 func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	storageClient := client.storageServiceClient
+	storageClient := client.storageServiceClient()
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("name").(string)
@@ -166,7 +166,6 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	accountType := d.Get("account_type").(string)
 
 	location := d.Get("location").(string)
-	tags := d.Get("tags").(map[string]interface{})
 	enableBlobEncryption := d.Get("enable_blob_encryption").(bool)
 	enableHTTPSTrafficOnly := d.Get("enable_https_traffic_only").(bool)
 
@@ -177,7 +176,7 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	opts := storage.AccountCreateParameters{
 		Location: &location,
 		Sku:      &sku,
-		Tags:     expandTags(tags),
+		Tags:     expandTagsForResource(d, meta),
 		Kind:     storage.Kind(accountKind),
 		AccountPropertiesCreateParameters: &storage.AccountPropertiesCreateParameters{
 			Encryption: &storage.Encryption{
@@ -204,7 +203,9 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	// Create
-	_, createError := storageClient.Create(resourceGroupName, storageAccountName, opts, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, createError := storageClient.Create(resourceGroupName, storageAccountName, opts, ctx.Done())
 	createErr := <-createError
 
 	// The only way to get the ID back apparently is to read the resource again
@@ -219,6 +220,7 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	// If we had a create error earlier then we return with that error now.
 	// We do this later here so that we can grab the ID above is possible.
 	if createErr != nil {
+		trackInterruptedCreate(ctx, d, meta, resourceGroupName, "Microsoft.Storage/storageAccounts", storageAccountName)
 		return fmt.Errorf(
 			"Error creating Azure Storage Account '%s': %s",
 			storageAccountName, createErr)
@@ -240,10 +242,10 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 		Pending:    []string{"Updating", "Creating"},
 		Target:     []string{"Succeeded"},
 		Refresh:    storageAccountStateRefreshFunc(client, resourceGroupName, storageAccountName),
-		Timeout:    30 * time.Minute,
+		Timeout:    createOrUpdateTimeout(d),
 		MinTimeout: 15 * time.Second,
 	}
-	if _, err := stateConf.WaitForState(); err != nil {
+	if _, err := meta.(*ArmClient).waitForState(stateConf); err != nil {
 		return fmt.Errorf("Error waiting for Storage Account (%s) to become available: %s", storageAccountName, err)
 	}
 
@@ -254,12 +256,12 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 // and idempotent operation for CreateOrUpdate. In particular updating all of the parameters
 // available requires a call to Update per parameter...
 func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).storageServiceClient
-	id, err := parseAzureResourceID(d.Id())
+	client := meta.(*ArmClient).storageServiceClient()
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Storage/storageAccounts")
 	if err != nil {
 		return err
 	}
-	storageAccountName := id.Path["storageAccounts"]
+	storageAccountName := names[0]
 	resourceGroupName := id.ResourceGroup
 
 	d.Partial(true)
@@ -299,10 +301,9 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if d.HasChange("tags") {
-		tags := d.Get("tags").(map[string]interface{})
 
 		opts := storage.AccountUpdateParameters{
-			Tags: expandTags(tags),
+			Tags: expandTagsForResource(d, meta),
 		}
 		_, err := client.Update(resourceGroupName, storageAccountName, opts)
 		if err != nil {
@@ -356,13 +357,13 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceArmStorageAccountRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).storageServiceClient
+	client := meta.(*ArmClient).storageServiceClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Storage/storageAccounts")
 	if err != nil {
 		return err
 	}
-	name := id.Path["storageAccounts"]
+	name := names[0]
 	resGroup := id.ResourceGroup
 
 	resp, err := client.GetProperties(resGroup, name)
@@ -435,19 +436,19 @@ func resourceArmStorageAccountRead(d *schema.ResourceData, meta interface{}) err
 
 	d.Set("name", resp.Name)
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmStorageAccountDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).storageServiceClient
+	client := meta.(*ArmClient).storageServiceClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Storage/storageAccounts")
 	if err != nil {
 		return err
 	}
-	name := id.Path["storageAccounts"]
+	name := names[0]
 	resGroup := id.ResourceGroup
 
 	_, err = client.Delete(resGroup, name)
@@ -486,7 +487,7 @@ func validateArmStorageAccountType(v interface{}, k string) (ws []string, es []e
 
 func storageAccountStateRefreshFunc(client *ArmClient, resourceGroupName string, storageAccountName string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		res, err := client.storageServiceClient.GetProperties(resourceGroupName, storageAccountName)
+		res, err := client.storageServiceClient().GetProperties(resourceGroupName, storageAccountName)
 		if err != nil {
 			return nil, "", fmt.Errorf("Error issuing read request in storageAccountStateRefreshFunc to Azure ARM for Storage Account '%s' (RG: '%s'): %s", storageAccountName, resourceGroupName, err)
 		}
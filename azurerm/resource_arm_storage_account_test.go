@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -52,8 +51,8 @@ func TestValidateArmStorageAccountName(t *testing.T) {
 
 func TestAccAzureRMStorageAccount_basic(t *testing.T) {
 	resourceName := "azurerm_storage_account.testsa"
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	location := testLocation()
 	preConfig := testAccAzureRMStorageAccount_basic(ri, rs, location)
 	postConfig := testAccAzureRMStorageAccount_update(ri, rs, location)
@@ -88,8 +87,8 @@ func TestAccAzureRMStorageAccount_basic(t *testing.T) {
 
 func TestAccAzureRMStorageAccount_disappears(t *testing.T) {
 	resourceName := "azurerm_storage_account.testsa"
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	preConfig := testAccAzureRMStorageAccount_basic(ri, rs, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -113,8 +112,8 @@ func TestAccAzureRMStorageAccount_disappears(t *testing.T) {
 }
 
 func TestAccAzureRMStorageAccount_blobConnectionString(t *testing.T) {
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	preConfig := testAccAzureRMStorageAccount_basic(ri, rs, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -134,8 +133,8 @@ func TestAccAzureRMStorageAccount_blobConnectionString(t *testing.T) {
 }
 
 func TestAccAzureRMStorageAccount_blobEncryption(t *testing.T) {
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	location := testLocation()
 	preConfig := testAccAzureRMStorageAccount_blobEncryption(ri, rs, location)
 	postConfig := testAccAzureRMStorageAccount_blobEncryptionDisabled(ri, rs, location)
@@ -165,8 +164,8 @@ func TestAccAzureRMStorageAccount_blobEncryption(t *testing.T) {
 }
 
 func TestAccAzureRMStorageAccount_enableHttpsTrafficOnly(t *testing.T) {
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	location := testLocation()
 	preConfig := testAccAzureRMStorageAccount_enableHttpsTrafficOnly(ri, rs, location)
 	postConfig := testAccAzureRMStorageAccount_enableHttpsTrafficOnlyDisabled(ri, rs, location)
@@ -196,8 +195,8 @@ func TestAccAzureRMStorageAccount_enableHttpsTrafficOnly(t *testing.T) {
 }
 
 func TestAccAzureRMStorageAccount_blobStorageWithUpdate(t *testing.T) {
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	location := testLocation()
 	preConfig := testAccAzureRMStorageAccount_blobStorage(ri, rs, location)
 	postConfig := testAccAzureRMStorageAccount_blobStorageUpdate(ri, rs, location)
@@ -228,8 +227,8 @@ func TestAccAzureRMStorageAccount_blobStorageWithUpdate(t *testing.T) {
 }
 
 func TestAccAzureRMStorageAccount_NonStandardCasing(t *testing.T) {
-	ri := acctest.RandInt()
-	rs := acctest.RandString(4)
+	ri := testRandInt(t)
+	rs := testRandString(t, 4)
 	preConfig := testAccAzureRMStorageAccountNonStandardCasing(ri, rs, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -265,7 +264,7 @@ func testCheckAzureRMStorageAccountExists(name string) resource.TestCheckFunc {
 		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
 		// Ensure resource group exists in API
-		conn := testAccProvider.Meta().(*ArmClient).storageServiceClient
+		conn := testAccProvider.Meta().(*ArmClient).storageServiceClient()
 
 		resp, err := conn.GetProperties(resourceGroup, storageAccount)
 		if err != nil {
@@ -292,7 +291,7 @@ func testCheckAzureRMStorageAccountDisappears(name string) resource.TestCheckFun
 		resourceGroup := rs.Primary.Attributes["resource_group_name"]
 
 		// Ensure resource group exists in API
-		conn := testAccProvider.Meta().(*ArmClient).storageServiceClient
+		conn := testAccProvider.Meta().(*ArmClient).storageServiceClient()
 
 		_, err := conn.Delete(resourceGroup, storageAccount)
 		if err != nil {
@@ -304,7 +303,7 @@ func testCheckAzureRMStorageAccountDisappears(name string) resource.TestCheckFun
 }
 
 func testCheckAzureRMStorageAccountDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).storageServiceClient
+	conn := testAccProvider.Meta().(*ArmClient).storageServiceClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_storage_account" {
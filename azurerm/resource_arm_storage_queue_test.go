@@ -5,7 +5,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -32,11 +31,11 @@ func TestResourceAzureRMStorageQueueName_Validation(t *testing.T) {
 			ErrCount: 1,
 		},
 		{
-			Value:    acctest.RandString(256),
+			Value:    testRandString(t, 256),
 			ErrCount: 1,
 		},
 		{
-			Value:    acctest.RandString(1),
+			Value:    testRandString(t, 1),
 			ErrCount: 1,
 		},
 	}
@@ -51,8 +50,8 @@ func TestResourceAzureRMStorageQueueName_Validation(t *testing.T) {
 }
 
 func TestAccAzureRMStorageQueue_basic(t *testing.T) {
-	ri := acctest.RandInt()
-	rs := strings.ToLower(acctest.RandString(11))
+	ri := testRandInt(t)
+	rs := strings.ToLower(testRandString(t, 11))
 	config := testAccAzureRMStorageQueue_basic(ri, rs, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -7,7 +7,6 @@ import (
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/storage"
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -15,8 +14,8 @@ import (
 func TestAccAzureRMStorageTable_basic(t *testing.T) {
 	var table storage.Table
 
-	ri := acctest.RandInt()
-	rs := strings.ToLower(acctest.RandString(11))
+	ri := testRandInt(t)
+	rs := strings.ToLower(testRandString(t, 11))
 	config := testAccAzureRMStorageTable_basic(ri, rs, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -37,8 +36,8 @@ func TestAccAzureRMStorageTable_basic(t *testing.T) {
 func TestAccAzureRMStorageTable_disappears(t *testing.T) {
 	var table storage.Table
 
-	ri := acctest.RandInt()
-	rs := strings.ToLower(acctest.RandString(11))
+	ri := testRandInt(t)
+	rs := strings.ToLower(testRandString(t, 11))
 	config := testAccAzureRMStorageTable_basic(ri, rs, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -3,13 +3,12 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
-var subnetResourceName = "azurerm_subnet"
-
 func resourceArmSubnet() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmSubnetCreate,
@@ -45,15 +44,17 @@ func resourceArmSubnet() *schema.Resource {
 			},
 
 			"network_security_group_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/networkSecurityGroups"),
 			},
 
 			"route_table_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/routeTables"),
 			},
 
 			"ip_configurations": {
@@ -69,7 +70,7 @@ func resourceArmSubnet() *schema.Resource {
 
 func resourceArmSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	subnetClient := client.subnetClient
+	subnetClient := client.subnetClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM Subnet creation.")
 
@@ -78,51 +79,39 @@ func resourceArmSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 	resGroup := d.Get("resource_group_name").(string)
 	addressPrefix := d.Get("address_prefix").(string)
 
-	azureRMLockByName(vnetName, virtualNetworkResourceName)
-	defer azureRMUnlockByName(vnetName, virtualNetworkResourceName)
-
 	properties := network.SubnetPropertiesFormat{
 		AddressPrefix: &addressPrefix,
 	}
 
-	if v, ok := d.GetOk("network_security_group_id"); ok {
-		nsgId := v.(string)
+	nsgId := d.Get("network_security_group_id").(string)
+	if nsgId != "" {
 		properties.NetworkSecurityGroup = &network.SecurityGroup{
 			ID: &nsgId,
 		}
-
-		networkSecurityGroupName, err := parseNetworkSecurityGroupName(nsgId)
-		if err != nil {
-			return err
-		}
-
-		azureRMLockByName(networkSecurityGroupName, networkSecurityGroupResourceName)
-		defer azureRMUnlockByName(networkSecurityGroupName, networkSecurityGroupResourceName)
 	}
 
-	if v, ok := d.GetOk("route_table_id"); ok {
-		rtId := v.(string)
+	rtId := d.Get("route_table_id").(string)
+	if rtId != "" {
 		properties.RouteTable = &network.RouteTable{
 			ID: &rtId,
 		}
-
-		routeTableName, err := parseRouteTableName(rtId)
-		if err != nil {
-			return err
-		}
-
-		azureRMLockByName(routeTableName, routeTableResourceName)
-		defer azureRMUnlockByName(routeTableName, routeTableResourceName)
 	}
 
+	vnetId := networkResourceID(client.subscriptionId, resGroup, "virtualNetworks", vnetName)
+	unlock := lockResourceIDs(vnetId, nsgId, rtId)
+	defer unlock()
+
 	subnet := network.Subnet{
 		Name: &name,
 		SubnetPropertiesFormat: &properties,
 	}
 
-	_, error := subnetClient.CreateOrUpdate(resGroup, vnetName, name, subnet, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := subnetClient.CreateOrUpdate(resGroup, vnetName, name, subnet, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/virtualNetworks/subnets", vnetName, name)
 		return err
 	}
 
@@ -140,24 +129,31 @@ func resourceArmSubnetCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceArmSubnetRead(d *schema.ResourceData, meta interface{}) error {
-	subnetClient := meta.(*ArmClient).subnetClient
+	subnetClient := meta.(*ArmClient).subnetClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseSubnetID(d.Id())
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	vnetName := id.Path["virtualNetworks"]
-	name := id.Path["subnets"]
-
-	resp, err := subnetClient.Get(resGroup, vnetName, name, "")
+	vnetName := id.VirtualNetworkName
+	name := id.Name
 
+	// the Subnet is read from its Virtual Network (which is shared with the other Subnets in it),
+	// unless it isn't there yet
+	resp, found, err := retrieveSubnetFromVirtualNetwork(meta, resGroup, vnetName, name)
 	if err != nil {
-		if responseWasNotFound(resp.Response) {
-			d.SetId("")
-			return nil
+		return err
+	}
+	if !found {
+		resp, err = subnetClient.Get(resGroup, vnetName, name, "")
+		if err != nil {
+			if responseWasNotFound(resp.Response) {
+				d.SetId("")
+				return nil
+			}
+			return fmt.Errorf("Error making Read request on Azure Subnet %s: %+v", name, err)
 		}
-		return fmt.Errorf("Error making Read request on Azure Subnet %s: %+v", name, err)
 	}
 
 	d.Set("name", name)
@@ -194,46 +190,61 @@ func resourceArmSubnetRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceArmSubnetDelete(d *schema.ResourceData, meta interface{}) error {
-	subnetClient := meta.(*ArmClient).subnetClient
+	subnetClient := meta.(*ArmClient).subnetClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseSubnetID(d.Id())
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["subnets"]
-	vnetName := id.Path["virtualNetworks"]
+	name := id.Name
+	vnetName := id.VirtualNetworkName
 
-	if v, ok := d.GetOk("network_security_group_id"); ok {
-		networkSecurityGroupId := v.(string)
-		networkSecurityGroupName, err := parseNetworkSecurityGroupName(networkSecurityGroupId)
-		if err != nil {
-			return err
-		}
+	unlock := lockResourceIDs(parentResourceID(d.Id()), d.Get("network_security_group_id").(string), d.Get("route_table_id").(string))
+	defer unlock()
 
-		azureRMLockByName(networkSecurityGroupName, networkSecurityGroupResourceName)
-		defer azureRMUnlockByName(networkSecurityGroupName, networkSecurityGroupResourceName)
-	}
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := subnetClient.Delete(resGroup, vnetName, name, ctx.Done())
+	err = <-error
+
+	return err
+}
+
+type virtualNetworkRead struct {
+	virtualNetwork network.VirtualNetwork
+	exists         bool
+}
 
-	if v, ok := d.GetOk("route_table_id"); ok {
-		rtId := v.(string)
-		routeTableName, err := parseRouteTableName(rtId)
+// retrieveSubnetFromVirtualNetwork returns the Subnet from its Virtual Network, which is cached
+// (since it's read by each of its Subnets) until it's modified.
+func retrieveSubnetFromVirtualNetwork(meta interface{}, resGroup, vnetName, name string) (network.Subnet, bool, error) {
+	client := meta.(*ArmClient)
+	vnetId := networkResourceID(client.subscriptionId, resGroup, "virtualNetworks", vnetName)
+	value, err := client.readCache.get(vnetId, func() (interface{}, error) {
+		resp, err := client.vnetClient().Get(resGroup, vnetName, "")
 		if err != nil {
-			return err
+			if responseWasNotFound(resp.Response) {
+				return virtualNetworkRead{}, nil
+			}
+			return virtualNetworkRead{}, fmt.Errorf("Error making Read request on Azure Virtual Network %s: %+v", vnetName, err)
 		}
-
-		azureRMLockByName(routeTableName, routeTableResourceName)
-		defer azureRMUnlockByName(routeTableName, routeTableResourceName)
+		return virtualNetworkRead{resp, true}, nil
+	})
+	if err != nil {
+		return network.Subnet{}, false, err
 	}
 
-	azureRMLockByName(vnetName, virtualNetworkResourceName)
-	defer azureRMUnlockByName(vnetName, virtualNetworkResourceName)
-
-	azureRMLockByName(name, subnetResourceName)
-	defer azureRMUnlockByName(name, subnetResourceName)
+	read := value.(virtualNetworkRead)
+	if !read.exists || read.virtualNetwork.VirtualNetworkPropertiesFormat == nil || read.virtualNetwork.Subnets == nil {
+		return network.Subnet{}, false, nil
+	}
 
-	_, error := subnetClient.Delete(resGroup, vnetName, name, make(chan struct{}))
-	err = <-error
+	for _, subnet := range *read.virtualNetwork.Subnets {
+		if subnet.Name != nil && strings.EqualFold(*subnet.Name, name) && subnet.SubnetPropertiesFormat != nil {
+			return subnet, true, nil
+		}
+	}
 
-	return err
+	return network.Subnet{}, false, nil
 }
@@ -7,14 +7,13 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMSubnet_basic(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMSubnet_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -34,7 +33,7 @@ func TestAccAzureRMSubnet_basic(t *testing.T) {
 
 func TestAccAzureRMSubnet_routeTableUpdate(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	initConfig := testAccAzureRMSubnet_routeTable(ri, location)
 	updatedConfig := testAccAzureRMSubnet_updatedRouteTable(ri, location)
@@ -62,7 +61,7 @@ func TestAccAzureRMSubnet_routeTableUpdate(t *testing.T) {
 }
 
 func TestAccAzureRMSubnet_bug7986(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	initConfig := testAccAzureRMSubnet_bug7986(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -82,7 +81,7 @@ func TestAccAzureRMSubnet_bug7986(t *testing.T) {
 }
 
 func TestAccAzureRMSubnet_bug15204(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	initConfig := testAccAzureRMSubnet_bug15204(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -102,7 +101,7 @@ func TestAccAzureRMSubnet_bug15204(t *testing.T) {
 
 func TestAccAzureRMSubnet_disappears(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMSubnet_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -139,7 +138,7 @@ func testCheckAzureRMSubnetExists(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for subnet: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).subnetClient
+		conn := testAccProvider.Meta().(*ArmClient).subnetClient()
 
 		resp, err := conn.Get(resourceGroup, vnetName, name, "")
 		if err != nil {
@@ -175,7 +174,7 @@ func testCheckAzureRMSubnetRouteTableExists(subnetName string, routeTableId stri
 			return fmt.Errorf("Bad: no resource group found in state for subnet: %s", name)
 		}
 
-		vnetConn := testAccProvider.Meta().(*ArmClient).vnetClient
+		vnetConn := testAccProvider.Meta().(*ArmClient).vnetClient()
 		vnetResp, vnetErr := vnetConn.Get(resourceGroup, vnetName, "")
 		if vnetErr != nil {
 			return fmt.Errorf("Bad: Get on vnetClient: %+v", vnetErr)
@@ -185,7 +184,7 @@ func testCheckAzureRMSubnetRouteTableExists(subnetName string, routeTableId stri
 			return fmt.Errorf("Bad: Vnet %q (resource group: %q) does not have subnets after update", vnetName, resourceGroup)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).subnetClient
+		conn := testAccProvider.Meta().(*ArmClient).subnetClient()
 
 		resp, err := conn.Get(resourceGroup, vnetName, name, "")
 		if err != nil {
@@ -223,7 +222,7 @@ func testCheckAzureRMSubnetDisappears(name string) resource.TestCheckFunc {
 			return fmt.Errorf("Bad: no resource group found in state for subnet: %s", name)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).subnetClient
+		conn := testAccProvider.Meta().(*ArmClient).subnetClient()
 
 		_, error := conn.Delete(resourceGroup, vnetName, name, make(chan struct{}))
 		err := <-error
@@ -236,7 +235,7 @@ func testCheckAzureRMSubnetDisappears(name string) resource.TestCheckFunc {
 }
 
 func testCheckAzureRMSubnetDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).subnetClient
+	conn := testAccProvider.Meta().(*ArmClient).subnetClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_subnet" {
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -20,6 +19,13 @@ func resourceArmTemplateDeployment() *schema.Resource {
 		Update: resourceArmTemplateDeploymentCreate,
 		Delete: resourceArmTemplateDeploymentDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(40 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(40 * time.Minute),
+			Delete: schema.DefaultTimeout(40 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -60,7 +66,7 @@ func resourceArmTemplateDeployment() *schema.Resource {
 
 func resourceArmTemplateDeploymentCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	deployClient := client.deploymentsClient
+	deployClient := client.deploymentsClient()
 
 	name := d.Get("name").(string)
 	resGroup := d.Get("resource_group_name").(string)
@@ -99,9 +105,12 @@ func resourceArmTemplateDeploymentCreate(d *schema.ResourceData, meta interface{
 		Properties: &properties,
 	}
 
-	_, error := deployClient.CreateOrUpdate(resGroup, name, deployment, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := deployClient.CreateOrUpdate(resGroup, name, deployment, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Resources/deployments", name)
 		return fmt.Errorf("Error creating deployment: %+v", err)
 	}
 
@@ -115,33 +124,19 @@ func resourceArmTemplateDeploymentCreate(d *schema.ResourceData, meta interface{
 
 	d.SetId(*read.ID)
 
-	log.Printf("[DEBUG] Waiting for Template Deployment (%s) to become available", name)
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"creating", "updating", "accepted", "running"},
-		Target:  []string{"succeeded"},
-		Refresh: templateDeploymentStateRefreshFunc(client, resGroup, name),
-		Timeout: 40 * time.Minute,
-	}
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("Error waiting for Template Deployment (%s) to become available: %+v", name, err)
-	}
-
 	return resourceArmTemplateDeploymentRead(d, meta)
 }
 
 func resourceArmTemplateDeploymentRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	deployClient := client.deploymentsClient
+	deployClient := client.deploymentsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Resources/deployments")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["deployments"]
-	if name == "" {
-		name = id.Path["Deployments"]
-	}
+	name := names[0]
 
 	resp, err := deployClient.Get(resGroup, name)
 	if err != nil {
@@ -194,19 +189,18 @@ func resourceArmTemplateDeploymentRead(d *schema.ResourceData, meta interface{})
 
 func resourceArmTemplateDeploymentDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	deployClient := client.deploymentsClient
+	deployClient := client.deploymentsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Resources/deployments")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["deployments"]
-	if name == "" {
-		name = id.Path["Deployments"]
-	}
+	name := names[0]
 
-	_, error := deployClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := deployClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 
 	return err
@@ -233,14 +227,3 @@ func normalizeJson(jsonString interface{}) string {
 	b, _ := json.Marshal(j)
 	return string(b[:])
 }
-
-func templateDeploymentStateRefreshFunc(client *ArmClient, resourceGroupName string, name string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		res, err := client.deploymentsClient.Get(resourceGroupName, name)
-		if err != nil {
-			return nil, "", fmt.Errorf("Error issuing read request in templateDeploymentStateRefreshFunc to Azure ARM for Template Deployment '%s' (RG: '%s'): %+v", name, resourceGroupName, err)
-		}
-
-		return res, strings.ToLower(*res.Properties.ProvisioningState), nil
-	}
-}
@@ -3,7 +3,6 @@ package azurerm
 import (
 	"fmt"
 	"log"
-	"regexp"
 
 	"github.com/Azure/azure-sdk-for-go/arm/trafficmanager"
 	"github.com/hashicorp/terraform/helper/schema"
@@ -52,8 +51,9 @@ func resourceArmTrafficManagerEndpoint() *schema.Resource {
 			},
 
 			"target_resource_id": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateResourceIDOrEmpty,
 			},
 
 			"endpoint_status": {
@@ -101,7 +101,7 @@ func resourceArmTrafficManagerEndpoint() *schema.Resource {
 }
 
 func resourceArmTrafficManagerEndpointCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).trafficManagerEndpointsClient
+	client := meta.(*ArmClient).trafficManagerEndpointsClient()
 
 	log.Printf("[INFO] preparing arguments for ARM TrafficManager Endpoint creation.")
 
@@ -136,26 +136,16 @@ func resourceArmTrafficManagerEndpointCreate(d *schema.ResourceData, meta interf
 }
 
 func resourceArmTrafficManagerEndpointRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).trafficManagerEndpointsClient
+	client := meta.(*ArmClient).trafficManagerEndpointsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseTrafficManagerEndpointID(d.Id())
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-
-	// lookup endpointType in Azure ID path
-	var endpointType string
-	typeRegex := regexp.MustCompile("azureEndpoints|externalEndpoints|nestedEndpoints")
-	for k := range id.Path {
-		if typeRegex.MatchString(k) {
-			endpointType = k
-		}
-	}
-	profileName := id.Path["trafficManagerProfiles"]
-
-	// endpoint name is keyed by endpoint type in ARM ID
-	name := id.Path[endpointType]
+	profileName := id.ProfileName
+	endpointType := id.EndpointType
+	name := id.Name
 
 	resp, err := client.Get(resGroup, profileName, endpointType, name)
 	if err != nil {
@@ -185,18 +175,16 @@ func resourceArmTrafficManagerEndpointRead(d *schema.ResourceData, meta interfac
 }
 
 func resourceArmTrafficManagerEndpointDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).trafficManagerEndpointsClient
+	client := meta.(*ArmClient).trafficManagerEndpointsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, err := parseTrafficManagerEndpointID(d.Id())
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	endpointType := d.Get("type").(string)
-	profileName := id.Path["trafficManagerProfiles"]
-
-	// endpoint name is keyed by endpoint type in ARM ID
-	name := id.Path[endpointType]
+	endpointType := id.EndpointType
+	profileName := id.ProfileName
+	name := id.Name
 
 	_, err = client.Delete(resGroup, profileName, endpointType, name)
 
@@ -239,3 +227,31 @@ func getArmTrafficManagerEndpointProperties(d *schema.ResourceData) *trafficmana
 
 	return &endpointProps
 }
+
+type trafficManagerEndpointID struct {
+	ResourceGroup string
+	ProfileName   string
+	EndpointType  string
+	Name          string
+}
+
+// parseTrafficManagerEndpointID parses the ID of an Endpoint, where the name is keyed by
+// the type of the Endpoint (e.g. `azureEndpoints`) in the ID.
+func parseTrafficManagerEndpointID(input string) (*trafficManagerEndpointID, error) {
+	for _, endpointType := range []string{"azureEndpoints", "externalEndpoints", "nestedEndpoints"} {
+		id, names, err := parseAzureResourceIDOfType(input, "Microsoft.Network/trafficManagerProfiles/"+endpointType)
+		if err != nil {
+			continue
+		}
+
+		return &trafficManagerEndpointID{
+			ResourceGroup: id.ResourceGroup,
+			ProfileName:   names[0],
+			EndpointType:  endpointType,
+			Name:          names[1],
+		}, nil
+	}
+
+	return nil, fmt.Errorf("Expected %q to be the ID of a Traffic Manager Endpoint in the format "+
+		"/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Network/trafficManagerProfiles/{name}/{azureEndpoints|externalEndpoints|nestedEndpoints}/{name}", input)
+}
@@ -6,7 +6,6 @@ import (
 	"path"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -14,7 +13,7 @@ import (
 func TestAccAzureRMTrafficManagerEndpoint_basic(t *testing.T) {
 	azureResourceName := "azurerm_traffic_manager_endpoint.testAzure"
 	externalResourceName := "azurerm_traffic_manager_endpoint.testExternal"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMTrafficManagerEndpoint_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -38,7 +37,7 @@ func TestAccAzureRMTrafficManagerEndpoint_basic(t *testing.T) {
 func TestAccAzureRMTrafficManagerEndpoint_disappears(t *testing.T) {
 	azureResourceName := "azurerm_traffic_manager_endpoint.testAzure"
 	externalResourceName := "azurerm_traffic_manager_endpoint.testExternal"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMTrafficManagerEndpoint_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -64,7 +63,7 @@ func TestAccAzureRMTrafficManagerEndpoint_disappears(t *testing.T) {
 func TestAccAzureRMTrafficManagerEndpoint_basicDisableExternal(t *testing.T) {
 	azureResourceName := "azurerm_traffic_manager_endpoint.testAzure"
 	externalResourceName := "azurerm_traffic_manager_endpoint.testExternal"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	preConfig := testAccAzureRMTrafficManagerEndpoint_basic(ri, testLocation())
 	postConfig := testAccAzureRMTrafficManagerEndpoint_basicDisableExternal(ri, testLocation())
 
@@ -100,7 +99,7 @@ func TestAccAzureRMTrafficManagerEndpoint_updateWeight(t *testing.T) {
 	firstResourceName := "azurerm_traffic_manager_endpoint.testExternal"
 	secondResourceName := "azurerm_traffic_manager_endpoint.testExternalNew"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMTrafficManagerEndpoint_weight(ri, location)
 	postConfig := testAccAzureRMTrafficManagerEndpoint_updateWeight(ri, location)
@@ -137,7 +136,7 @@ func TestAccAzureRMTrafficManagerEndpoint_updatePriority(t *testing.T) {
 	firstResourceName := "azurerm_traffic_manager_endpoint.testExternal"
 	secondResourceName := "azurerm_traffic_manager_endpoint.testExternalNew"
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	preConfig := testAccAzureRMTrafficManagerEndpoint_priority(ri, location)
 	postConfig := testAccAzureRMTrafficManagerEndpoint_updatePriority(ri, location)
@@ -170,7 +169,7 @@ func TestAccAzureRMTrafficManagerEndpoint_updatePriority(t *testing.T) {
 }
 
 func TestAccAzureRMTrafficManagerEndpoint_nestedEndpoints(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMTrafficManagerEndpoint_nestedEndpoints(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -191,7 +190,7 @@ func TestAccAzureRMTrafficManagerEndpoint_nestedEndpoints(t *testing.T) {
 
 func TestAccAzureRMTrafficManagerEndpoint_location(t *testing.T) {
 	resourceName := "azurerm_traffic_manager_endpoint.test"
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	location := testLocation()
 	first := testAccAzureRMTrafficManagerEndpoint_location(ri, location)
 	second := testAccAzureRMTrafficManagerEndpoint_locationUpdated(ri, location)
@@ -234,7 +233,7 @@ func testCheckAzureRMTrafficManagerEndpointExists(name string) resource.TestChec
 		}
 
 		// Ensure resource group/virtual network combination exists in API
-		conn := testAccProvider.Meta().(*ArmClient).trafficManagerEndpointsClient
+		conn := testAccProvider.Meta().(*ArmClient).trafficManagerEndpointsClient()
 
 		resp, err := conn.Get(resourceGroup, profileName, path.Base(endpointType), name)
 		if err != nil {
@@ -266,7 +265,7 @@ func testCheckAzureRMTrafficManagerEndpointDisappears(name string) resource.Test
 		}
 
 		// Ensure resource group/virtual network combination exists in API
-		conn := testAccProvider.Meta().(*ArmClient).trafficManagerEndpointsClient
+		conn := testAccProvider.Meta().(*ArmClient).trafficManagerEndpointsClient()
 
 		_, err := conn.Delete(resourceGroup, profileName, path.Base(endpointType), name)
 		if err != nil {
@@ -278,7 +277,7 @@ func testCheckAzureRMTrafficManagerEndpointDisappears(name string) resource.Test
 }
 
 func testCheckAzureRMTrafficManagerEndpointDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).trafficManagerEndpointsClient
+	conn := testAccProvider.Meta().(*ArmClient).trafficManagerEndpointsClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_traffic_manager_endpoint" {
@@ -116,7 +116,7 @@ func resourceArmTrafficManagerProfile() *schema.Resource {
 }
 
 func resourceArmTrafficManagerProfileCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).trafficManagerProfilesClient
+	client := meta.(*ArmClient).trafficManagerProfilesClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM virtual network creation.")
 
@@ -124,13 +124,12 @@ func resourceArmTrafficManagerProfileCreate(d *schema.ResourceData, meta interfa
 	// must be provided in request
 	location := "global"
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	profile := trafficmanager.Profile{
 		Name:              &name,
 		Location:          &location,
 		ProfileProperties: getArmTrafficManagerProfileProperties(d),
-		Tags:              expandTags(tags),
+		Tags:              expandTagsForResource(d, meta),
 	}
 
 	_, err := client.CreateOrUpdate(resGroup, name, profile)
@@ -152,14 +151,14 @@ func resourceArmTrafficManagerProfileCreate(d *schema.ResourceData, meta interfa
 }
 
 func resourceArmTrafficManagerProfileRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).trafficManagerProfilesClient
+	client := meta.(*ArmClient).trafficManagerProfilesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/trafficManagerProfiles")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["trafficManagerProfiles"]
+	name := names[0]
 
 	resp, err := client.Get(resGroup, name)
 	if err != nil {
@@ -187,20 +186,20 @@ func resourceArmTrafficManagerProfileRead(d *schema.ResourceData, meta interface
 	monitorFlat := flattenAzureRMTrafficManagerProfileMonitorConfig(profile.MonitorConfig)
 	d.Set("monitor_config", schema.NewSet(resourceAzureRMTrafficManagerMonitorConfigHash, monitorFlat))
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmTrafficManagerProfileDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).trafficManagerProfilesClient
+	client := meta.(*ArmClient).trafficManagerProfilesClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/trafficManagerProfiles")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["trafficManagerProfiles"]
+	name := names[0]
 
 	_, err = client.Delete(resGroup, name)
 
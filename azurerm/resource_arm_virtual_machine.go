@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/compute"
 	"github.com/Azure/azure-sdk-for-go/storage"
@@ -25,6 +26,13 @@ func resourceArmVirtualMachine() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -73,6 +81,7 @@ func resourceArmVirtualMachine() *schema.Resource {
 				StateFunc: func(id interface{}) string {
 					return strings.ToLower(id.(string))
 				},
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Compute/availabilitySets"),
 			},
 
 			"license_type": {
@@ -162,6 +171,7 @@ func resourceArmVirtualMachine() *schema.Resource {
 							ForceNew:      true,
 							Computed:      true,
 							ConflictsWith: []string{"storage_os_disk.vhd_uri"},
+							ValidateFunc:  validateResourceIDOfTypeOrEmpty("Microsoft.Compute/disks"),
 						},
 
 						"managed_disk_type": {
@@ -229,6 +239,7 @@ func resourceArmVirtualMachine() *schema.Resource {
 							ForceNew:      true,
 							Computed:      true,
 							ConflictsWith: []string{"storage_data_disk.vhd_uri"},
+							ValidateFunc:  validateResourceIDOfTypeOrEmpty("Microsoft.Compute/disks"),
 						},
 
 						"managed_disk_type": {
@@ -458,8 +469,9 @@ func resourceArmVirtualMachine() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"source_vault_id": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateResourceIDOfType("Microsoft.KeyVault/vaults"),
 						},
 
 						"vault_certificates": {
@@ -486,14 +498,16 @@ func resourceArmVirtualMachine() *schema.Resource {
 				Type:     schema.TypeSet,
 				Required: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validateResourceIDOfType("Microsoft.Network/networkInterfaces"),
 				},
 				Set: schema.HashString,
 			},
 
 			"primary_network_interface_id": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/networkInterfaces"),
 			},
 
 			"tags": tagsSchema(),
@@ -503,15 +517,14 @@ func resourceArmVirtualMachine() *schema.Resource {
 
 func resourceArmVirtualMachineCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	vmClient := client.vmClient
+	vmClient := client.vmClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM Virtual Machine creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
-	expandedTags := expandTags(tags)
+	expandedTags := expandTagsForResource(d, meta)
 
 	osDisk, err := expandAzureRmVirtualMachineOsDisk(d)
 	if err != nil {
@@ -592,9 +605,12 @@ func resourceArmVirtualMachineCreate(d *schema.ResourceData, meta interface{}) e
 		vm.Plan = plan
 	}
 
-	_, vmError := vmClient.CreateOrUpdate(resGroup, name, vm, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, vmError := vmClient.CreateOrUpdate(resGroup, name, vm, ctx.Done())
 	vmErr := <-vmError
 	if vmErr != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Compute/virtualMachines", name)
 		return vmErr
 	}
 
@@ -612,14 +628,14 @@ func resourceArmVirtualMachineCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceArmVirtualMachineRead(d *schema.ResourceData, meta interface{}) error {
-	vmClient := meta.(*ArmClient).vmClient
+	vmClient := meta.(*ArmClient).vmClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/virtualMachines")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["virtualMachines"]
+	name := names[0]
 
 	resp, err := vmClient.Get(resGroup, name, "")
 
@@ -710,22 +726,24 @@ func resourceArmVirtualMachineRead(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmVirtualMachineDelete(d *schema.ResourceData, meta interface{}) error {
-	vmClient := meta.(*ArmClient).vmClient
+	vmClient := meta.(*ArmClient).vmClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/virtualMachines")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["virtualMachines"]
+	name := names[0]
 
-	_, error := vmClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := vmClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 
 	if err != nil {
@@ -746,7 +764,7 @@ func resourceArmVirtualMachineDelete(d *schema.ResourceData, meta interface{}) e
 				return fmt.Errorf("Error deleting OS Disk VHD: %+v", err)
 			}
 		} else if osDisk.ManagedDisk != nil {
-			if err = resourceArmVirtualMachineDeleteManagedDisk(*osDisk.ManagedDisk.ID, meta); err != nil {
+			if err = resourceArmVirtualMachineDeleteManagedDisk(*osDisk.ManagedDisk.ID, ctx.Done(), meta); err != nil {
 				return fmt.Errorf("Error deleting OS Managed Disk: %+v", err)
 			}
 		} else {
@@ -769,7 +787,7 @@ func resourceArmVirtualMachineDelete(d *schema.ResourceData, meta interface{}) e
 					return fmt.Errorf("Error deleting Data Disk VHD: %+v", err)
 				}
 			} else if disk.ManagedDisk != nil {
-				if err = resourceArmVirtualMachineDeleteManagedDisk(*disk.ManagedDisk.ID, meta); err != nil {
+				if err = resourceArmVirtualMachineDeleteManagedDisk(*disk.ManagedDisk.ID, ctx.Done(), meta); err != nil {
 					return fmt.Errorf("Error deleting Data Managed Disk: %+v", err)
 				}
 			} else {
@@ -820,17 +838,17 @@ func resourceArmVirtualMachineDeleteVhd(uri string, meta interface{}) error {
 	return nil
 }
 
-func resourceArmVirtualMachineDeleteManagedDisk(managedDiskID string, meta interface{}) error {
-	diskClient := meta.(*ArmClient).diskClient
+func resourceArmVirtualMachineDeleteManagedDisk(managedDiskID string, cancel <-chan struct{}, meta interface{}) error {
+	diskClient := meta.(*ArmClient).diskClient()
 
-	id, err := parseAzureResourceID(managedDiskID)
+	id, names, err := parseAzureResourceIDOfType(managedDiskID, "Microsoft.Compute/disks")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["disks"]
+	name := names[0]
 
-	_, error := diskClient.Delete(resGroup, name, make(chan struct{}))
+	_, error := diskClient.Delete(resGroup, name, cancel)
 	err = <-error
 	if err != nil {
 		return fmt.Errorf("Error deleting Managed Disk (%s %s) %+v", name, resGroup, err)
@@ -1536,7 +1554,7 @@ func expandAzureRmVirtualMachineOsDisk(d *schema.ResourceData) (*compute.OSDisk,
 }
 
 func findStorageAccountResourceGroup(meta interface{}, storageAccountName string) (string, error) {
-	client := meta.(*ArmClient).resourceFindClient
+	client := meta.(*ArmClient).resourceFindClient()
 	filter := fmt.Sprintf("name eq '%s' and resourceType eq 'Microsoft.Storage/storageAccounts'", storageAccountName)
 	expand := ""
 	var pager *int32
@@ -82,7 +82,7 @@ func resourceArmVirtualMachineExtensions() *schema.Resource {
 }
 
 func resourceArmVirtualMachineExtensionsCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).vmExtensionClient
+	client := meta.(*ArmClient).vmExtensionClient()
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
@@ -92,7 +92,6 @@ func resourceArmVirtualMachineExtensionsCreate(d *schema.ResourceData, meta inte
 	extensionType := d.Get("type").(string)
 	typeHandlerVersion := d.Get("type_handler_version").(string)
 	autoUpgradeMinor := d.Get("auto_upgrade_minor_version").(bool)
-	tags := d.Get("tags").(map[string]interface{})
 
 	extension := compute.VirtualMachineExtension{
 		Location: &location,
@@ -102,7 +101,7 @@ func resourceArmVirtualMachineExtensionsCreate(d *schema.ResourceData, meta inte
 			TypeHandlerVersion:      &typeHandlerVersion,
 			AutoUpgradeMinorVersion: &autoUpgradeMinor,
 		},
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
 	if settingsString := d.Get("settings").(string); settingsString != "" {
@@ -121,9 +120,12 @@ func resourceArmVirtualMachineExtensionsCreate(d *schema.ResourceData, meta inte
 		extension.VirtualMachineExtensionProperties.ProtectedSettings = &protectedSettings
 	}
 
-	_, error := client.CreateOrUpdate(resGroup, vmName, name, extension, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := client.CreateOrUpdate(resGroup, vmName, name, extension, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Compute/virtualMachines/extensions", vmName, name)
 		return err
 	}
 
@@ -142,15 +144,15 @@ func resourceArmVirtualMachineExtensionsCreate(d *schema.ResourceData, meta inte
 }
 
 func resourceArmVirtualMachineExtensionsRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).vmExtensionClient
+	client := meta.(*ArmClient).vmExtensionClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/virtualMachines/extensions")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	vmName := id.Path["virtualMachines"]
-	name := id.Path["extensions"]
+	vmName := names[0]
+	name := names[1]
 
 	resp, err := client.Get(resGroup, vmName, name, "")
 
@@ -179,23 +181,25 @@ func resourceArmVirtualMachineExtensionsRead(d *schema.ResourceData, meta interf
 		d.Set("settings", settings)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmVirtualMachineExtensionsDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).vmExtensionClient
+	client := meta.(*ArmClient).vmExtensionClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/virtualMachines/extensions")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["extensions"]
-	vmName := id.Path["virtualMachines"]
+	name := names[1]
+	vmName := names[0]
 
-	_, error := client.Delete(resGroup, vmName, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := client.Delete(resGroup, vmName, name, ctx.Done())
 	err = <-error
 
 	return err
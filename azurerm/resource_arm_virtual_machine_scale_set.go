@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/arm/compute"
 	"github.com/hashicorp/terraform/helper/hashcode"
@@ -24,6 +25,13 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -122,8 +130,9 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"source_vault_id": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateResourceIDOfType("Microsoft.KeyVault/vaults"),
 						},
 
 						"vault_certificates": {
@@ -265,23 +274,30 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 									},
 
 									"subnet_id": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateResourceIDOfType("Microsoft.Network/virtualNetworks/subnets"),
 									},
 
 									"load_balancer_backend_address_pool_ids": {
 										Type:     schema.TypeSet,
 										Optional: true,
-										Elem:     &schema.Schema{Type: schema.TypeString},
-										Set:      schema.HashString,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validateResourceIDOfType("Microsoft.Network/loadBalancers/backendAddressPools"),
+										},
+										Set: schema.HashString,
 									},
 
 									"load_balancer_inbound_nat_rules_ids": {
 										Type:     schema.TypeSet,
 										Optional: true,
 										Computed: true,
-										Elem:     &schema.Schema{Type: schema.TypeString},
-										Set:      schema.HashString,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validateResourceIDOfType("Microsoft.Network/loadBalancers/inboundNatPools"),
+										},
+										Set: schema.HashString,
 									},
 								},
 							},
@@ -502,14 +518,13 @@ func resourceArmVirtualMachineScaleSet() *schema.Resource {
 
 func resourceArmVirtualMachineScaleSetCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	vmScaleSetClient := client.vmScaleSetClient
+	vmScaleSetClient := client.vmScaleSetClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM Virtual Machine Scale Set creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
 
 	sku, err := expandVirtualMachineScaleSetSku(d)
 	if err != nil {
@@ -570,7 +585,7 @@ func resourceArmVirtualMachineScaleSetCreate(d *schema.ResourceData, meta interf
 	scaleSetParams := compute.VirtualMachineScaleSet{
 		Name:     &name,
 		Location: &location,
-		Tags:     expandTags(tags),
+		Tags:     expandTagsForResource(d, meta),
 		Sku:      sku,
 		VirtualMachineScaleSetProperties: &scaleSetProps,
 	}
@@ -584,9 +599,12 @@ func resourceArmVirtualMachineScaleSetCreate(d *schema.ResourceData, meta interf
 		scaleSetParams.Plan = plan
 	}
 
-	_, vmError := vmScaleSetClient.CreateOrUpdate(resGroup, name, scaleSetParams, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, vmError := vmScaleSetClient.CreateOrUpdate(resGroup, name, scaleSetParams, ctx.Done())
 	vmErr := <-vmError
 	if vmErr != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Compute/virtualMachineScaleSets", name)
 		return vmErr
 	}
 
@@ -604,14 +622,14 @@ func resourceArmVirtualMachineScaleSetCreate(d *schema.ResourceData, meta interf
 }
 
 func resourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interface{}) error {
-	vmScaleSetClient := meta.(*ArmClient).vmScaleSetClient
+	vmScaleSetClient := meta.(*ArmClient).vmScaleSetClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/virtualMachineScaleSets")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["virtualMachineScaleSets"]
+	name := names[0]
 
 	resp, err := vmScaleSetClient.Get(resGroup, name)
 	if err != nil {
@@ -698,22 +716,24 @@ func resourceArmVirtualMachineScaleSetRead(d *schema.ResourceData, meta interfac
 		}
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmVirtualMachineScaleSetDelete(d *schema.ResourceData, meta interface{}) error {
-	vmScaleSetClient := meta.(*ArmClient).vmScaleSetClient
+	vmScaleSetClient := meta.(*ArmClient).vmScaleSetClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Compute/virtualMachineScaleSets")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["virtualMachineScaleSets"]
+	name := names[0]
 
-	_, error := vmScaleSetClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := vmScaleSetClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 
 	return err
@@ -23,7 +23,7 @@ func testCheckAzureRMVirtualMachineExists(name string, vm *compute.VirtualMachin
 			return fmt.Errorf("Bad: no resource group found in state for virtual machine: %s", vmName)
 		}
 
-		conn := testAccProvider.Meta().(*ArmClient).vmClient
+		conn := testAccProvider.Meta().(*ArmClient).vmClient()
 
 		resp, err := conn.Get(resourceGroup, vmName, "")
 		if err != nil {
@@ -41,7 +41,7 @@ func testCheckAzureRMVirtualMachineExists(name string, vm *compute.VirtualMachin
 }
 
 func testCheckAzureRMVirtualMachineDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).vmClient
+	conn := testAccProvider.Meta().(*ArmClient).vmClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_virtual_machine" {
@@ -11,8 +11,6 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
-var virtualNetworkResourceName = "azurerm_virtual_network"
-
 func resourceArmVirtualNetwork() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmVirtualNetworkCreate,
@@ -61,8 +59,9 @@ func resourceArmVirtualNetwork() *schema.Resource {
 							Required: true,
 						},
 						"security_group": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateResourceIDOfTypeOrEmpty("Microsoft.Network/networkSecurityGroups"),
 						},
 					},
 				},
@@ -84,14 +83,13 @@ func resourceArmVirtualNetwork() *schema.Resource {
 
 func resourceArmVirtualNetworkCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient)
-	vnetClient := client.vnetClient
+	vnetClient := client.vnetClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM virtual network creation.")
 
 	name := d.Get("name").(string)
 	location := d.Get("location").(string)
 	resGroup := d.Get("resource_group_name").(string)
-	tags := d.Get("tags").(map[string]interface{})
 	vnetProperties, vnetPropsErr := getVirtualNetworkProperties(d, meta)
 	if vnetPropsErr != nil {
 		return vnetPropsErr
@@ -101,27 +99,25 @@ func resourceArmVirtualNetworkCreate(d *schema.ResourceData, meta interface{}) e
 		Name:                           &name,
 		Location:                       &location,
 		VirtualNetworkPropertiesFormat: vnetProperties,
-		Tags: expandTags(tags),
+		Tags: expandTagsForResource(d, meta),
 	}
 
-	networkSecurityGroupNames := make([]string, 0)
+	idsToLock := []string{networkResourceID(client.subscriptionId, resGroup, "virtualNetworks", name)}
 	for _, subnet := range *vnet.VirtualNetworkPropertiesFormat.Subnets {
 		if subnet.NetworkSecurityGroup != nil {
-			nsgName, err := parseNetworkSecurityGroupName(*subnet.NetworkSecurityGroup.ID)
-			if err != nil {
-				return err
-			}
-
-			networkSecurityGroupNames = append(networkSecurityGroupNames, nsgName)
+			idsToLock = append(idsToLock, *subnet.NetworkSecurityGroup.ID)
 		}
 	}
 
-	azureRMLockMultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
-	defer azureRMUnlockMultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
+	unlock := lockResourceIDs(idsToLock...)
+	defer unlock()
 
-	_, error := vnetClient.CreateOrUpdate(resGroup, name, vnet, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := vnetClient.CreateOrUpdate(resGroup, name, vnet, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/virtualNetworks", name)
 		return err
 	}
 
@@ -139,14 +135,14 @@ func resourceArmVirtualNetworkCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceArmVirtualNetworkRead(d *schema.ResourceData, meta interface{}) error {
-	vnetClient := meta.(*ArmClient).vnetClient
+	vnetClient := meta.(*ArmClient).vnetClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/virtualNetworks")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["virtualNetworks"]
+	name := names[0]
 
 	resp, err := vnetClient.Get(resGroup, name, "")
 	if err != nil {
@@ -190,30 +186,32 @@ func resourceArmVirtualNetworkRead(d *schema.ResourceData, meta interface{}) err
 		d.Set("dns_servers", dnses)
 	}
 
-	flattenAndSetTags(d, resp.Tags)
+	flattenAndSetTags(d, resp.Tags, meta)
 
 	return nil
 }
 
 func resourceArmVirtualNetworkDelete(d *schema.ResourceData, meta interface{}) error {
-	vnetClient := meta.(*ArmClient).vnetClient
+	vnetClient := meta.(*ArmClient).vnetClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/virtualNetworks")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	name := id.Path["virtualNetworks"]
+	name := names[0]
 
-	nsgNames, err := expandAzureRmVirtualNetworkVirtualNetworkSecurityGroupNames(d)
+	nsgIds, err := expandAzureRmVirtualNetworkVirtualNetworkSecurityGroupIDs(d)
 	if err != nil {
 		return fmt.Errorf("[ERROR] Error parsing Network Security Group ID's: %+v", err)
 	}
 
-	azureRMLockMultipleByName(&nsgNames, virtualNetworkResourceName)
-	defer azureRMUnlockMultipleByName(&nsgNames, virtualNetworkResourceName)
+	unlock := lockResourceIDs(append(nsgIds, d.Id())...)
+	defer unlock()
 
-	_, error := vnetClient.Delete(resGroup, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := vnetClient.Delete(resGroup, name, ctx.Done())
 	err = <-error
 
 	return err
@@ -300,7 +298,7 @@ func resourceAzureSubnetHash(v interface{}) int {
 func getExistingSubnet(resGroup string, vnetName string, subnetName string, meta interface{}) (*network.Subnet, error) {
 	//attempt to retrieve existing subnet from the server
 	existingSubnet := network.Subnet{}
-	subnetClient := meta.(*ArmClient).subnetClient
+	subnetClient := meta.(*ArmClient).subnetClient()
 	resp, err := subnetClient.Get(resGroup, vnetName, subnetName, "")
 
 	if err != nil {
@@ -334,8 +332,8 @@ func getExistingSubnet(resGroup string, vnetName string, subnetName string, meta
 	return &existingSubnet, nil
 }
 
-func expandAzureRmVirtualNetworkVirtualNetworkSecurityGroupNames(d *schema.ResourceData) ([]string, error) {
-	nsgNames := make([]string, 0)
+func expandAzureRmVirtualNetworkVirtualNetworkSecurityGroupIDs(d *schema.ResourceData) ([]string, error) {
+	nsgIds := make([]string, 0)
 
 	if v, ok := d.GetOk("subnet"); ok {
 		subnets := v.(*schema.Set).List()
@@ -347,15 +345,14 @@ func expandAzureRmVirtualNetworkVirtualNetworkSecurityGroupNames(d *schema.Resou
 
 			networkSecurityGroupId := subnet["security_group"].(string)
 			if networkSecurityGroupId != "" {
-				nsgName, err := parseNetworkSecurityGroupName(networkSecurityGroupId)
-				if err != nil {
+				if _, err := parseNetworkSecurityGroupName(networkSecurityGroupId); err != nil {
 					return nil, err
 				}
 
-				nsgNames = append(nsgNames, nsgName)
+				nsgIds = append(nsgIds, networkSecurityGroupId)
 			}
 		}
 	}
 
-	return nsgNames, nil
+	return nsgIds, nil
 }
@@ -3,16 +3,11 @@ package azurerm
 import (
 	"fmt"
 	"log"
-	"sync"
 
 	"github.com/Azure/azure-sdk-for-go/arm/network"
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
-// peerMutex is used to prevet multiple Peering resources being creaed, updated
-// or deleted at the same time
-var peerMutex = &sync.Mutex{}
-
 func resourceArmVirtualNetworkPeering() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmVirtualNetworkPeeringCreate,
@@ -43,9 +38,10 @@ func resourceArmVirtualNetworkPeering() *schema.Resource {
 			},
 
 			"remote_virtual_network_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateResourceIDOfType("Microsoft.Network/virtualNetworks"),
 			},
 
 			"allow_virtual_network_access": {
@@ -76,7 +72,7 @@ func resourceArmVirtualNetworkPeering() *schema.Resource {
 }
 
 func resourceArmVirtualNetworkPeeringCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).vnetPeeringsClient
+	client := meta.(*ArmClient).vnetPeeringsClient()
 
 	log.Printf("[INFO] preparing arguments for Azure ARM virtual network peering creation.")
 
@@ -89,12 +85,17 @@ func resourceArmVirtualNetworkPeeringCreate(d *schema.ResourceData, meta interfa
 		VirtualNetworkPeeringPropertiesFormat: getVirtualNetworkPeeringProperties(d),
 	}
 
-	peerMutex.Lock()
-	defer peerMutex.Unlock()
+	// peering updates both of the Virtual Networks, so they're locked until it's complete
+	vnetId := networkResourceID(client.SubscriptionID, resGroup, "virtualNetworks", vnetName)
+	unlock := lockResourceIDs(vnetId, d.Get("remote_virtual_network_id").(string))
+	defer unlock()
 
-	_, error := client.CreateOrUpdate(resGroup, vnetName, name, peer, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(createOrUpdateTimeout(d))
+	defer cancel()
+	_, error := client.CreateOrUpdate(resGroup, vnetName, name, peer, ctx.Done())
 	err := <-error
 	if err != nil {
+		trackInterruptedCreate(ctx, d, meta, resGroup, "Microsoft.Network/virtualNetworks/virtualNetworkPeerings", vnetName, name)
 		return err
 	}
 
@@ -112,15 +113,15 @@ func resourceArmVirtualNetworkPeeringCreate(d *schema.ResourceData, meta interfa
 }
 
 func resourceArmVirtualNetworkPeeringRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).vnetPeeringsClient
+	client := meta.(*ArmClient).vnetPeeringsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/virtualNetworks/virtualNetworkPeerings")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	vnetName := id.Path["virtualNetworks"]
-	name := id.Path["virtualNetworkPeerings"]
+	vnetName := names[0]
+	name := names[1]
 
 	resp, err := client.Get(resGroup, vnetName, name)
 	if err != nil {
@@ -147,20 +148,22 @@ func resourceArmVirtualNetworkPeeringRead(d *schema.ResourceData, meta interface
 }
 
 func resourceArmVirtualNetworkPeeringDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*ArmClient).vnetPeeringsClient
+	client := meta.(*ArmClient).vnetPeeringsClient()
 
-	id, err := parseAzureResourceID(d.Id())
+	id, names, err := parseAzureResourceIDOfType(d.Id(), "Microsoft.Network/virtualNetworks/virtualNetworkPeerings")
 	if err != nil {
 		return err
 	}
 	resGroup := id.ResourceGroup
-	vnetName := id.Path["virtualNetworks"]
-	name := id.Path["virtualNetworkPeerings"]
+	vnetName := names[0]
+	name := names[1]
 
-	peerMutex.Lock()
-	defer peerMutex.Unlock()
+	unlock := lockResourceIDs(parentResourceID(d.Id()), d.Get("remote_virtual_network_id").(string))
+	defer unlock()
 
-	_, error := client.Delete(resGroup, vnetName, name, make(chan struct{}))
+	ctx, cancel := meta.(*ArmClient).operationContext(d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	_, error := client.Delete(resGroup, vnetName, name, ctx.Done())
 	err = <-error
 
 	return err
@@ -5,13 +5,12 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestAccAzureRMVirtualNetwork_basic(t *testing.T) {
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualNetwork_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -31,7 +30,7 @@ func TestAccAzureRMVirtualNetwork_basic(t *testing.T) {
 
 func TestAccAzureRMVirtualNetwork_disappears(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	config := testAccAzureRMVirtualNetwork_basic(ri, testLocation())
 
 	resource.Test(t, resource.TestCase{
@@ -53,7 +52,7 @@ func TestAccAzureRMVirtualNetwork_disappears(t *testing.T) {
 
 func TestAccAzureRMVirtualNetwork_withTags(t *testing.T) {
 
-	ri := acctest.RandInt()
+	ri := testRandInt(t)
 	preConfig := testAccAzureRMVirtualNetwork_withTags(ri, testLocation())
 	postConfig := testAccAzureRMVirtualNetwork_withTagsUpdated(ri, testLocation())
 
@@ -103,7 +102,7 @@ func testCheckAzureRMVirtualNetworkExists(name string) resource.TestCheckFunc {
 		}
 
 		// Ensure resource group/virtual network combination exists in API
-		conn := testAccProvider.Meta().(*ArmClient).vnetClient
+		conn := testAccProvider.Meta().(*ArmClient).vnetClient()
 
 		resp, err := conn.Get(resourceGroup, virtualNetworkName, "")
 		if err != nil {
@@ -133,7 +132,7 @@ func testCheckAzureRMVirtualNetworkDisappears(name string) resource.TestCheckFun
 		}
 
 		// Ensure resource group/virtual network combination exists in API
-		conn := testAccProvider.Meta().(*ArmClient).vnetClient
+		conn := testAccProvider.Meta().(*ArmClient).vnetClient()
 
 		_, error := conn.Delete(resourceGroup, virtualNetworkName, make(chan struct{}))
 		err := <-error
@@ -146,7 +145,7 @@ func testCheckAzureRMVirtualNetworkDisappears(name string) resource.TestCheckFun
 }
 
 func testCheckAzureRMVirtualNetworkDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*ArmClient).vnetClient
+	conn := testAccProvider.Meta().(*ArmClient).vnetClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_virtual_network" {
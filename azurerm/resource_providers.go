@@ -0,0 +1,295 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceProviderNamespaces are the Resource Provider namespaces which must be registered with
+// the subscription before each resource can be created.
+var resourceProviderNamespaces = map[string][]string{
+	"azurerm_application_insights":        {"Microsoft.Insights"},
+	"azurerm_automation_account":          {"Microsoft.Automation"},
+	"azurerm_automation_credential":       {"Microsoft.Automation"},
+	"azurerm_automation_runbook":          {"Microsoft.Automation"},
+	"azurerm_automation_schedule":         {"Microsoft.Automation"},
+	"azurerm_availability_set":            {"Microsoft.Compute"},
+	"azurerm_cdn_endpoint":                {"Microsoft.Cdn"},
+	"azurerm_cdn_profile":                 {"Microsoft.Cdn"},
+	"azurerm_container_registry":          {"Microsoft.ContainerRegistry"},
+	"azurerm_container_service":           {"Microsoft.ContainerService"},
+	"azurerm_cosmosdb_account":            {"Microsoft.DocumentDB"},
+	"azurerm_dns_a_record":                {"Microsoft.Network"},
+	"azurerm_dns_aaaa_record":             {"Microsoft.Network"},
+	"azurerm_dns_cname_record":            {"Microsoft.Network"},
+	"azurerm_dns_mx_record":               {"Microsoft.Network"},
+	"azurerm_dns_ns_record":               {"Microsoft.Network"},
+	"azurerm_dns_ptr_record":              {"Microsoft.Network"},
+	"azurerm_dns_srv_record":              {"Microsoft.Network"},
+	"azurerm_dns_txt_record":              {"Microsoft.Network"},
+	"azurerm_dns_zone":                    {"Microsoft.Network"},
+	"azurerm_eventhub":                    {"Microsoft.EventHub"},
+	"azurerm_eventhub_authorization_rule": {"Microsoft.EventHub"},
+	"azurerm_eventhub_consumer_group":     {"Microsoft.EventHub"},
+	"azurerm_eventhub_namespace":          {"Microsoft.EventHub"},
+	"azurerm_express_route_circuit":       {"Microsoft.Network"},
+	"azurerm_image":                       {"Microsoft.Compute"},
+	"azurerm_key_vault":                   {"Microsoft.KeyVault"},
+	"azurerm_lb":                          {"Microsoft.Network"},
+	"azurerm_lb_backend_address_pool":     {"Microsoft.Network"},
+	"azurerm_lb_nat_pool":                 {"Microsoft.Network"},
+	"azurerm_lb_nat_rule":                 {"Microsoft.Network"},
+	"azurerm_lb_probe":                    {"Microsoft.Network"},
+	"azurerm_lb_rule":                     {"Microsoft.Network"},
+	"azurerm_local_network_gateway":       {"Microsoft.Network"},
+	"azurerm_managed_disk":                {"Microsoft.Compute"},
+	"azurerm_network_interface":           {"Microsoft.Network"},
+	"azurerm_network_security_group":      {"Microsoft.Network"},
+	"azurerm_network_security_rule":       {"Microsoft.Network"},
+	"azurerm_public_ip":                   {"Microsoft.Network"},
+	"azurerm_redis_cache":                 {"Microsoft.Cache"},
+	"azurerm_resource_group":              {"Microsoft.Resources"},
+	"azurerm_route":                       {"Microsoft.Network"},
+	"azurerm_route_table":                 {"Microsoft.Network"},
+	"azurerm_search_service":              {"Microsoft.Search"},
+	"azurerm_servicebus_namespace":        {"Microsoft.ServiceBus"},
+	"azurerm_servicebus_queue":            {"Microsoft.ServiceBus"},
+	"azurerm_servicebus_subscription":     {"Microsoft.ServiceBus"},
+	"azurerm_servicebus_topic":            {"Microsoft.ServiceBus"},
+	"azurerm_sql_database":                {"Microsoft.Sql"},
+	"azurerm_sql_elasticpool":             {"Microsoft.Sql"},
+	"azurerm_sql_firewall_rule":           {"Microsoft.Sql"},
+	"azurerm_sql_server":                  {"Microsoft.Sql"},
+	"azurerm_storage_account":             {"Microsoft.Storage"},
+	"azurerm_storage_blob":                {"Microsoft.Storage"},
+	"azurerm_storage_container":           {"Microsoft.Storage"},
+	"azurerm_storage_queue":               {"Microsoft.Storage"},
+	"azurerm_storage_share":               {"Microsoft.Storage"},
+	"azurerm_storage_table":               {"Microsoft.Storage"},
+	"azurerm_subnet":                      {"Microsoft.Network"},
+	"azurerm_template_deployment":         {"Microsoft.Resources"},
+	"azurerm_traffic_manager_endpoint":    {"Microsoft.Network"},
+	"azurerm_traffic_manager_profile":     {"Microsoft.Network"},
+	"azurerm_virtual_machine":             {"Microsoft.Compute", "Microsoft.Network", "Microsoft.Storage"},
+	"azurerm_virtual_machine_extension":   {"Microsoft.Compute"},
+	"azurerm_virtual_machine_scale_set":   {"Microsoft.Compute", "Microsoft.Network", "Microsoft.Storage"},
+	"azurerm_virtual_network":             {"Microsoft.Network"},
+	"azurerm_virtual_network_peering":     {"Microsoft.Network"},
+}
+
+// resourceProviderRegistrar registers Resource Providers with the subscription, waiting until
+// each has finished registering. Each namespace is only registered once per provider instance,
+// even when it's required by several resources being created concurrently.
+type resourceProviderRegistrar struct {
+	client       resources.ProvidersClient
+	timeout      time.Duration
+	pollInterval time.Duration
+
+	// stopCh is closed when Terraform asks the provider to stop, ending the registration
+	stopCh func() <-chan struct{}
+
+	// configured are the namespaces configured on the provider, which are registered before
+	// any others. When exclusive is set only the configured namespaces are registered.
+	configured []string
+	exclusive  bool
+
+	// the namespaces already registered are retrieved the first time any are registered,
+	// unless they were retrieved when the provider was configured
+	listOnce sync.Once
+
+	lock          sync.Mutex
+	registrations map[string]*resourceProviderRegistration
+}
+
+type resourceProviderRegistration struct {
+	done chan struct{}
+	err  error
+}
+
+func newResourceProviderRegistrar(client resources.ProvidersClient, timeout time.Duration) *resourceProviderRegistrar {
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+
+	return &resourceProviderRegistrar{
+		client:        client,
+		timeout:       timeout,
+		pollInterval:  10 * time.Second,
+		registrations: make(map[string]*resourceProviderRegistration),
+	}
+}
+
+// withClient returns a registrar for the subscription of the client, which registers the same
+// namespaces as this one.
+func (r *resourceProviderRegistrar) withClient(client resources.ProvidersClient) *resourceProviderRegistrar {
+	registrar := newResourceProviderRegistrar(client, r.timeout)
+	registrar.pollInterval = r.pollInterval
+	registrar.stopCh = r.stopCh
+	registrar.configured = r.configured
+	registrar.exclusive = r.exclusive
+	return registrar
+}
+
+// registered records the namespaces which are already registered with the subscription, so
+// that no requests are made to register them.
+func (r *resourceProviderRegistrar) registered(providerList []resources.Provider) {
+	r.listOnce.Do(func() {})
+	r.markRegistered(providerList)
+}
+
+func (r *resourceProviderRegistrar) markRegistered(providerList []resources.Provider) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, p := range providerList {
+		if p.Namespace == nil || p.RegistrationState == nil {
+			continue
+		}
+
+		if strings.EqualFold(*p.RegistrationState, "Registered") {
+			log.Printf("[DEBUG] Skipping provider registration for namespace %s\n", *p.Namespace)
+			done := make(chan struct{})
+			close(done)
+			r.registrations[strings.ToLower(*p.Namespace)] = &resourceProviderRegistration{done: done}
+		}
+	}
+}
+
+// registerFor registers the namespaces required by a resource - or when the namespaces to
+// register have been configured exclusively, only those namespaces.
+func (r *resourceProviderRegistrar) registerFor(namespaces []string) error {
+	if r.exclusive {
+		return r.register()
+	}
+
+	return r.register(namespaces...)
+}
+
+// register registers the configured namespaces and each of the specified namespaces with the
+// subscription (unless they're already registered) and waits until they've all been
+// registered, returning any errors encountered.
+func (r *resourceProviderRegistrar) register(namespaces ...string) error {
+	r.listOnce.Do(func() {
+		providerList, err := r.client.List(nil, "")
+		if err != nil {
+			// each namespace will be registered, which succeeds when it's already registered
+			log.Printf("[WARN] Unable to list the Resource Providers registered with the subscription: %s", err)
+			return
+		}
+
+		if providerList.Value != nil {
+			r.markRegistered(*providerList.Value)
+		}
+	})
+
+	var pending []*resourceProviderRegistration
+
+	r.lock.Lock()
+	for _, namespace := range append(r.configured, namespaces...) {
+		key := strings.ToLower(namespace)
+		registration, ok := r.registrations[key]
+		if !ok {
+			registration = &resourceProviderRegistration{done: make(chan struct{})}
+			r.registrations[key] = registration
+
+			go func(namespace string, registration *resourceProviderRegistration) {
+				registration.err = r.registerNamespace(namespace)
+				if registration.err != nil {
+					// allow the registration to be retried by the next resource requiring it
+					r.lock.Lock()
+					delete(r.registrations, strings.ToLower(namespace))
+					r.lock.Unlock()
+				}
+				close(registration.done)
+			}(namespace, registration)
+		}
+
+		pending = append(pending, registration)
+	}
+	r.lock.Unlock()
+
+	var err *multierror.Error
+	for _, registration := range pending {
+		<-registration.done
+		if registration.err != nil {
+			err = multierror.Append(err, registration.err)
+		}
+	}
+
+	return err.ErrorOrNil()
+}
+
+func (r *resourceProviderRegistrar) registerNamespace(namespace string) error {
+	log.Printf("[DEBUG] Registering provider with namespace %s\n", namespace)
+	if _, err := r.client.Register(namespace); err != nil {
+		return fmt.Errorf("Cannot register provider %s with Azure Resource Manager: %s.", namespace, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"NotRegistered", "Registering", "Unregistered"},
+		Target:       []string{"Registered"},
+		Refresh:      resourceProviderRegistrationStateRefreshFunc(r.client, namespace),
+		Timeout:      r.timeout,
+		PollInterval: r.pollInterval,
+	}
+	if _, err := waitForStateUntilStopped(stateConf, r.stop()); err != nil {
+		return fmt.Errorf("Error waiting for provider %s to be registered with Azure Resource Manager: %s", namespace, err)
+	}
+
+	return nil
+}
+
+func (r *resourceProviderRegistrar) stop() <-chan struct{} {
+	if r.stopCh == nil {
+		return nil
+	}
+
+	return r.stopCh()
+}
+
+func resourceProviderRegistrationStateRefreshFunc(client resources.ProvidersClient, namespace string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		provider, err := client.Get(namespace, "")
+		if err != nil {
+			return nil, "", fmt.Errorf("Error retrieving the registration state of provider %s: %s", namespace, err)
+		}
+
+		if provider.RegistrationState == nil {
+			return provider, "Registering", nil
+		}
+
+		// the state is compared case-sensitively, but the API isn't consistent
+		for _, state := range []string{"NotRegistered", "Registering", "Unregistered", "Registered"} {
+			if strings.EqualFold(*provider.RegistrationState, state) {
+				return provider, state, nil
+			}
+		}
+
+		return provider, *provider.RegistrationState, nil
+	}
+}
+
+// registerResourceProvidersBeforeCreate wraps the Create function of the resource so that the
+// Resource Providers it requires are registered before it's created - meaning only the
+// namespaces of the resources in use are registered, which permits service principals which
+// can only register those namespaces.
+func registerResourceProvidersBeforeCreate(create schema.CreateFunc, namespaces []string) schema.CreateFunc {
+	return func(d *schema.ResourceData, meta interface{}) error {
+		client := meta.(*ArmClient)
+		if client.resourceProviders != nil {
+			if err := client.resourceProviders.registerFor(namespaces); err != nil {
+				return err
+			}
+		}
+
+		return create(d, meta)
+	}
+}
@@ -0,0 +1,121 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceProviderRegistrar_register(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	var lock sync.Mutex
+	polls := 0
+	server.handle("POST", "/providers/Microsoft.Foo/register", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"namespace":"Microsoft.Foo","registrationState":"Registering"}`)
+	})
+	server.handle("GET", "/providers/Microsoft.Foo", func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		polls++
+		state := "Registering"
+		if polls >= 3 {
+			state = "Registered"
+		}
+		fmt.Fprintf(w, `{"namespace":"Microsoft.Foo","registrationState":%q}`, state)
+	})
+	server.handle("POST", "/providers/Microsoft.Bar/register", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"code":"AuthorizationFailed","message":"Forbidden"}}`)
+	})
+
+	registrar := server.armClientRegisteringProviders(t).resourceProviders
+	registrar.pollInterval = time.Millisecond
+	registrar.registered([]resources.Provider{
+		{Namespace: stringPtr("Microsoft.Compute"), RegistrationState: stringPtr("Registered")},
+		{Namespace: stringPtr("Microsoft.Foo"), RegistrationState: stringPtr("NotRegistered")},
+	})
+
+	// resources being created concurrently share the registration of the namespace
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- registrar.register("Microsoft.Foo", "microsoft.compute")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Expected the namespaces to be registered but got: %s", err)
+		}
+	}
+	if count := server.requestCount("POST", "/providers/Microsoft.Foo/register"); count != 1 {
+		t.Fatalf("Expected Microsoft.Foo to be registered once but it was registered %d times", count)
+	}
+	if count := server.requestCount("POST", "/providers/Microsoft.Compute/register"); count != 0 {
+		t.Fatalf("Expected Microsoft.Compute not to be registered since it's already registered but it was registered %d times", count)
+	}
+	lock.Lock()
+	if polls < 3 {
+		t.Fatalf("Expected the registration state to be polled until it was registered but it was polled %d times", polls)
+	}
+	lock.Unlock()
+
+	// failures are returned, rather than being swallowed, and are retried
+	for attempt := 1; attempt <= 2; attempt++ {
+		err := registrar.register("Microsoft.Foo", "Microsoft.Bar")
+		if err == nil || !strings.Contains(err.Error(), "Microsoft.Bar") {
+			t.Fatalf("Expected an error registering Microsoft.Bar but got: %v", err)
+		}
+		if count := server.requestCount("POST", "/providers/Microsoft.Bar/register"); count != attempt {
+			t.Fatalf("Expected Microsoft.Bar to be registered %d times but it was registered %d times", attempt, count)
+		}
+	}
+}
+
+func TestResourceProviderRegistrar_timeout(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	server.handle("POST", "/providers/Microsoft.Foo/register", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"namespace":"Microsoft.Foo","registrationState":"Registering"}`)
+	})
+	server.handle("GET", "/providers/Microsoft.Foo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"namespace":"Microsoft.Foo","registrationState":"Registering"}`)
+	})
+
+	client := server.armClient(t)
+	registrar := newResourceProviderRegistrar(client.providers(), 50*time.Millisecond)
+	registrar.pollInterval = time.Millisecond
+
+	err := registrar.register("Microsoft.Foo")
+	if err == nil || !strings.Contains(err.Error(), "Error waiting for provider Microsoft.Foo") {
+		t.Fatalf("Expected the registration to time out but got: %v", err)
+	}
+}
+
+func TestResourceProviderNamespaces(t *testing.T) {
+	p := Provider().(*schema.Provider)
+	for name := range p.ResourcesMap {
+		if _, ok := resourceProviderNamespaces[name]; !ok {
+			t.Fatalf("Expected the Resource Provider namespaces required by %q to be defined", name)
+		}
+	}
+}
+
+func stringPtr(input string) *string {
+	return &input
+}
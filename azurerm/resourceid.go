@@ -3,19 +3,39 @@ package azurerm
 import (
 	"fmt"
 	"net/url"
-	"sort"
 	"strings"
 )
 
 // ResourceID represents a parsed long-form Azure Resource Manager ID
 // with the Subscription ID, Resource Group and the Provider as top-
-// level fields, and other key-value pairs available via a map in the
-// Path field.
+// level fields, and the remaining key-value pairs available in order
+// via the Segments field.
 type ResourceID struct {
 	SubscriptionID string
 	ResourceGroup  string
 	Provider       string
-	Path           map[string]string
+
+	// Segments are the key-value pairs following the Provider, in the order they appear in
+	// the ID. Extension resources (such as Locks) include a further `providers` segment.
+	Segments []ResourceIDSegment
+}
+
+// ResourceIDSegment is a key-value pair within a Resource ID, e.g. `virtualNetworks/example`.
+type ResourceIDSegment struct {
+	Key   string
+	Value string
+}
+
+// Get returns the value of the first segment with the specified key, which (as with Resource
+// Manager) is compared case-insensitively.
+func (id *ResourceID) Get(key string) (string, bool) {
+	for _, segment := range id.Segments {
+		if strings.EqualFold(segment.Key, key) {
+			return segment.Value, true
+		}
+	}
+
+	return "", false
 }
 
 // parseAzureResourceID converts a long-form Azure Resource Manager ID
@@ -46,10 +66,7 @@ func parseAzureResourceID(id string) (*ResourceID, error) {
 		return nil, fmt.Errorf("The number of path segments is not divisible by 2 in %q", path)
 	}
 
-	var subscriptionID string
-
-	// Put the constituent key-value pairs into a map
-	componentMap := make(map[string]string, len(components)/2)
+	segments := make([]ResourceIDSegment, 0, len(components)/2)
 	for current := 0; current < len(components); current += 2 {
 		key := components[current]
 		value := components[current+1]
@@ -59,49 +76,78 @@ func parseAzureResourceID(id string) (*ResourceID, error) {
 			return nil, fmt.Errorf("Key/Value cannot be empty strings. Key: '%s', Value: '%s'", key, value)
 		}
 
-		// Catch the subscriptionID before it can be overwritten by another "subscriptions"
-		// value in the ID which is the case for the Service Bus subscription resource
-		if key == "subscriptions" && subscriptionID == "" {
-			subscriptionID = value
-		} else {
-			componentMap[key] = value
-		}
+		segments = append(segments, ResourceIDSegment{
+			Key:   key,
+			Value: value,
+		})
 	}
 
-	// Build up a ResourceID from the map
-	idObj := &ResourceID{}
-	idObj.Path = componentMap
+	idObj := &ResourceID{
+		Segments: []ResourceIDSegment{},
+	}
 
-	if subscriptionID != "" {
-		idObj.SubscriptionID = subscriptionID
-	} else {
+	if !strings.EqualFold(segments[0].Key, "subscriptions") {
 		return nil, fmt.Errorf("No subscription ID found in: %q", path)
 	}
+	idObj.SubscriptionID = segments[0].Value
 
-	if resourceGroup, ok := componentMap["resourceGroups"]; ok {
-		idObj.ResourceGroup = resourceGroup
-		delete(componentMap, "resourceGroups")
-	} else {
-		// Some Azure APIs are weird and provide things in lower case...
-		// However it's not clear whether the casing of other elements in the URI
-		// matter, so we explicitly look for that case here.
-		if resourceGroup, ok := componentMap["resourcegroups"]; ok {
-			idObj.ResourceGroup = resourceGroup
-			delete(componentMap, "resourcegroups")
-		} else {
-			return nil, fmt.Errorf("No resource group name found in: %q", path)
-		}
+	// Some Azure APIs are weird and provide things in lower case...
+	if len(segments) < 2 || !strings.EqualFold(segments[1].Key, "resourceGroups") {
+		return nil, fmt.Errorf("No resource group name found in: %q", path)
 	}
+	idObj.ResourceGroup = segments[1].Value
 
 	// It is OK not to have a provider in the case of a resource group
-	if provider, ok := componentMap["providers"]; ok {
-		idObj.Provider = provider
-		delete(componentMap, "providers")
+	remaining := segments[2:]
+	if len(remaining) > 0 {
+		if !strings.EqualFold(remaining[0].Key, "providers") {
+			return nil, fmt.Errorf("Expected a provider after the resource group but got %q in: %q", remaining[0].Key, path)
+		}
+
+		idObj.Provider = remaining[0].Value
+		idObj.Segments = append(idObj.Segments, remaining[1:]...)
 	}
 
 	return idObj, nil
 }
 
+// parseAzureResourceIDOfType parses the ID and checks it's for a resource of the specified type,
+// such as `Microsoft.Network/loadBalancers/loadBalancingRules`. The keys and the Provider are
+// compared case-insensitively. The names of the resource's parents and then the resource itself
+// are returned in order.
+func parseAzureResourceIDOfType(input string, resourceType string) (*ResourceID, []string, error) {
+	types := strings.Split(resourceType, "/")
+	provider, keys := types[0], types[1:]
+
+	format := "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/" + provider
+	for _, key := range keys {
+		format += fmt.Sprintf("/%s/{name}", key)
+	}
+
+	id, err := parseAzureResourceID(input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error parsing %q as a %s ID: %s", input, resourceType, err)
+	}
+
+	if !strings.EqualFold(id.Provider, provider) {
+		return nil, nil, fmt.Errorf("Expected the %s ID %q to be in the format %q but the provider was %q", resourceType, input, format, id.Provider)
+	}
+
+	if len(id.Segments) != len(keys) {
+		return nil, nil, fmt.Errorf("Expected the %s ID %q to be in the format %q but got %d segments after the provider rather than %d", resourceType, input, format, len(id.Segments), len(keys))
+	}
+
+	names := make([]string, 0, len(keys))
+	for i, key := range keys {
+		if !strings.EqualFold(id.Segments[i].Key, key) {
+			return nil, nil, fmt.Errorf("Expected the %s ID %q to be in the format %q but got %q rather than %q", resourceType, input, format, id.Segments[i].Key, key)
+		}
+		names = append(names, id.Segments[i].Value)
+	}
+
+	return id, names, nil
+}
+
 func composeAzureResourceID(idObj *ResourceID) (id string, err error) {
 	if idObj.SubscriptionID == "" || idObj.ResourceGroup == "" {
 		return "", fmt.Errorf("SubscriptionID and ResourceGroup cannot be empty")
@@ -110,45 +156,91 @@ func composeAzureResourceID(idObj *ResourceID) (id string, err error) {
 	id = fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", idObj.SubscriptionID, idObj.ResourceGroup)
 
 	if idObj.Provider != "" {
-		if len(idObj.Path) < 1 {
-			return "", fmt.Errorf("ResourceID.Path should have at least one item when ResourceID.Provider is specified")
+		if len(idObj.Segments) < 1 {
+			return "", fmt.Errorf("ResourceID.Segments should have at least one item when ResourceID.Provider is specified")
 		}
 
 		id += fmt.Sprintf("/providers/%s", idObj.Provider)
 
-		// sort the path keys so our output is deterministic
-		var pathKeys []string
-		for k := range idObj.Path {
-			pathKeys = append(pathKeys, k)
-		}
-		sort.Strings(pathKeys)
-
-		for _, k := range pathKeys {
-			v := idObj.Path[k]
-			if k == "" || v == "" {
-				return "", fmt.Errorf("ResourceID.Path cannot contain empty strings")
+		for _, segment := range idObj.Segments {
+			if segment.Key == "" || segment.Value == "" {
+				return "", fmt.Errorf("ResourceID.Segments cannot contain empty strings")
 			}
-			id += fmt.Sprintf("/%s/%s", k, v)
+			id += fmt.Sprintf("/%s/%s", segment.Key, segment.Value)
 		}
 	}
 
 	return
 }
 
-func parseNetworkSecurityGroupName(networkSecurityGroupId string) (string, error) {
-	id, err := parseAzureResourceID(networkSecurityGroupId)
+// loadBalancerChildID is the ID of a sub-resource of a Load Balancer, such as a Rule or Probe.
+type loadBalancerChildID struct {
+	ResourceGroup    string
+	LoadBalancerName string
+	Name             string
+}
+
+// parseLoadBalancerChildID parses the ID of a sub-resource of a Load Balancer, where the child
+// type is the key used in the ID such as `loadBalancingRules` or `probes`.
+func parseLoadBalancerChildID(input string, childType string) (*loadBalancerChildID, error) {
+	id, names, err := parseAzureResourceIDOfType(input, "Microsoft.Network/loadBalancers/"+childType)
 	if err != nil {
-		return "", fmt.Errorf("[ERROR] Unable to Parse Network Security Group ID '%s': %+v", networkSecurityGroupId, err)
+		return nil, err
 	}
 
-	return id.Path["networkSecurityGroups"], nil
+	return &loadBalancerChildID{
+		ResourceGroup:    id.ResourceGroup,
+		LoadBalancerName: names[0],
+		Name:             names[1],
+	}, nil
+}
+
+// subnetID is the ID of a Subnet within a Virtual Network.
+type subnetID struct {
+	ResourceGroup      string
+	VirtualNetworkName string
+	Name               string
 }
 
-func parseRouteTableName(routeTableId string) (string, error) {
-	id, err := parseAzureResourceID(routeTableId)
+func parseSubnetID(input string) (*subnetID, error) {
+	id, names, err := parseAzureResourceIDOfType(input, "Microsoft.Network/virtualNetworks/subnets")
 	if err != nil {
-		return "", fmt.Errorf("[ERROR] Unable to parse Route Table ID '%s': %+v", routeTableId, err)
+		return nil, err
+	}
+
+	return &subnetID{
+		ResourceGroup:      id.ResourceGroup,
+		VirtualNetworkName: names[0],
+		Name:               names[1],
+	}, nil
+}
+
+// dnsRecordID is the ID of a Record Set within a DNS Zone.
+type dnsRecordID struct {
+	ResourceGroup string
+	ZoneName      string
+	Name          string
+}
+
+// parseDnsRecordID parses the ID of a DNS Record Set of the specified type, such as `A` or `MX`.
+func parseDnsRecordID(input string, recordType string) (*dnsRecordID, error) {
+	id, names, err := parseAzureResourceIDOfType(input, "Microsoft.Network/dnszones/"+recordType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dnsRecordID{
+		ResourceGroup: id.ResourceGroup,
+		ZoneName:      names[0],
+		Name:          names[1],
+	}, nil
+}
+
+func parseNetworkSecurityGroupName(networkSecurityGroupId string) (string, error) {
+	_, names, err := parseAzureResourceIDOfType(networkSecurityGroupId, "Microsoft.Network/networkSecurityGroups")
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Unable to Parse Network Security Group ID '%s': %+v", networkSecurityGroupId, err)
 	}
 
-	return id.Path["routeTables"], nil
+	return names[0], nil
 }
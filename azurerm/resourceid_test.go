@@ -2,6 +2,7 @@ package azurerm
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -44,7 +45,7 @@ func TestParseAzureResourceID(t *testing.T) {
 				SubscriptionID: "6d74bdd2-9f84-11e5-9bd9-7831c1c4c038",
 				ResourceGroup:  "testGroup1",
 				Provider:       "",
-				Path:           map[string]string{},
+				Segments:       []ResourceIDSegment{},
 			},
 			false,
 		},
@@ -54,7 +55,7 @@ func TestParseAzureResourceID(t *testing.T) {
 				SubscriptionID: "6d74bdd2-9f84-11e5-9bd9-7831c1c4c038",
 				ResourceGroup:  "testGroup1",
 				Provider:       "Microsoft.Network",
-				Path:           map[string]string{},
+				Segments:       []ResourceIDSegment{},
 			},
 			false,
 		},
@@ -70,8 +71,8 @@ func TestParseAzureResourceID(t *testing.T) {
 				SubscriptionID: "6d74bdd2-9f84-11e5-9bd9-7831c1c4c038",
 				ResourceGroup:  "testGroup1",
 				Provider:       "Microsoft.Network",
-				Path: map[string]string{
-					"virtualNetworks": "virtualNetwork1",
+				Segments: []ResourceIDSegment{
+					{Key: "virtualNetworks", Value: "virtualNetwork1"},
 				},
 			},
 			false,
@@ -82,8 +83,8 @@ func TestParseAzureResourceID(t *testing.T) {
 				SubscriptionID: "6d74bdd2-9f84-11e5-9bd9-7831c1c4c038",
 				ResourceGroup:  "testGroup1",
 				Provider:       "Microsoft.Network",
-				Path: map[string]string{
-					"virtualNetworks": "virtualNetwork1",
+				Segments: []ResourceIDSegment{
+					{Key: "virtualNetworks", Value: "virtualNetwork1"},
 				},
 			},
 			false,
@@ -94,9 +95,9 @@ func TestParseAzureResourceID(t *testing.T) {
 				SubscriptionID: "6d74bdd2-9f84-11e5-9bd9-7831c1c4c038",
 				ResourceGroup:  "testGroup1",
 				Provider:       "Microsoft.Network",
-				Path: map[string]string{
-					"virtualNetworks": "virtualNetwork1",
-					"subnets":         "publicInstances1",
+				Segments: []ResourceIDSegment{
+					{Key: "virtualNetworks", Value: "virtualNetwork1"},
+					{Key: "subnets", Value: "publicInstances1"},
 				},
 			},
 			false,
@@ -107,8 +108,8 @@ func TestParseAzureResourceID(t *testing.T) {
 				SubscriptionID: "34ca515c-4629-458e-bf7c-738d77e0d0ea",
 				ResourceGroup:  "acceptanceTestResourceGroup1",
 				Provider:       "Microsoft.Cdn",
-				Path: map[string]string{
-					"profiles": "acceptanceTestCdnProfile1",
+				Segments: []ResourceIDSegment{
+					{Key: "profiles", Value: "acceptanceTestCdnProfile1"},
 				},
 			},
 			false,
@@ -119,14 +120,35 @@ func TestParseAzureResourceID(t *testing.T) {
 				SubscriptionID: "34ca515c-4629-458e-bf7c-738d77e0d0ea",
 				ResourceGroup:  "testGroup1",
 				Provider:       "Microsoft.ServiceBus",
-				Path: map[string]string{
-					"namespaces":    "testNamespace1",
-					"topics":        "testTopic1",
-					"subscriptions": "testSubscription1",
+				Segments: []ResourceIDSegment{
+					{Key: "namespaces", Value: "testNamespace1"},
+					{Key: "topics", Value: "testTopic1"},
+					{Key: "subscriptions", Value: "testSubscription1"},
 				},
 			},
 			false,
 		},
+		{
+			// Extension resources contain a second provider
+			"/subscriptions/34ca515c-4629-458e-bf7c-738d77e0d0ea/resourceGroups/testGroup1/providers/Microsoft.Network/virtualNetworks/virtualNetwork1/providers/Microsoft.Authorization/locks/lock1",
+			&ResourceID{
+				SubscriptionID: "34ca515c-4629-458e-bf7c-738d77e0d0ea",
+				ResourceGroup:  "testGroup1",
+				Provider:       "Microsoft.Network",
+				Segments: []ResourceIDSegment{
+					{Key: "virtualNetworks", Value: "virtualNetwork1"},
+					{Key: "providers", Value: "Microsoft.Authorization"},
+					{Key: "locks", Value: "lock1"},
+				},
+			},
+			false,
+		},
+		{
+			// The provider must follow the resource group
+			"/subscriptions/34ca515c-4629-458e-bf7c-738d77e0d0ea/resourceGroups/testGroup1/virtualNetworks/virtualNetwork1",
+			nil,
+			true,
+		},
 	}
 
 	for _, test := range testCases {
@@ -155,15 +177,15 @@ func TestComposeAzureResourceID(t *testing.T) {
 				SubscriptionID: "00000000-0000-0000-0000-000000000000",
 				ResourceGroup:  "testGroup1",
 				Provider:       "foo.bar",
-				Path: map[string]string{
-					"k1": "v1",
-					"k2": "v2",
-					"k3": "v3",
-					"k4": "v4",
-					"k5": "v5",
-					"k6": "v6",
-					"k7": "v7",
-					"k8": "v8",
+				Segments: []ResourceIDSegment{
+					{Key: "k1", Value: "v1"},
+					{Key: "k2", Value: "v2"},
+					{Key: "k3", Value: "v3"},
+					{Key: "k4", Value: "v4"},
+					{Key: "k5", Value: "v5"},
+					{Key: "k6", Value: "v6"},
+					{Key: "k7", Value: "v7"},
+					{Key: "k8", Value: "v8"},
 				},
 			},
 			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/foo.bar/k1/v1/k2/v2/k3/v3/k4/v4/k5/v5/k6/v6/k7/v7/k8/v8",
@@ -178,7 +200,7 @@ func TestComposeAzureResourceID(t *testing.T) {
 			false,
 		},
 		{
-			// If Provider is specified, there must be at least one element in Path.
+			// If Provider is specified, there must be at least one element in Segments.
 			&ResourceID{
 				SubscriptionID: "00000000-0000-0000-0000-000000000000",
 				ResourceGroup:  "testGroup1",
@@ -188,28 +210,28 @@ func TestComposeAzureResourceID(t *testing.T) {
 			true,
 		},
 		{
-			// One of the keys in Path is an empty string.
+			// One of the keys in Segments is an empty string.
 			&ResourceID{
 				SubscriptionID: "00000000-0000-0000-0000-000000000000",
 				ResourceGroup:  "testGroup1",
 				Provider:       "foo.bar",
-				Path: map[string]string{
-					"k2": "v2",
-					"":   "v1",
+				Segments: []ResourceIDSegment{
+					{Key: "k2", Value: "v2"},
+					{Key: "", Value: "v1"},
 				},
 			},
 			"",
 			true,
 		},
 		{
-			// One of the values in Path is an empty string.
+			// One of the values in Segments is an empty string.
 			&ResourceID{
 				SubscriptionID: "00000000-0000-0000-0000-000000000000",
 				ResourceGroup:  "testGroup1",
 				Provider:       "foo.bar",
-				Path: map[string]string{
-					"k1": "v1",
-					"k2": "",
+				Segments: []ResourceIDSegment{
+					{Key: "k1", Value: "v1"},
+					{Key: "k2", Value: ""},
 				},
 			},
 			"",
@@ -233,3 +255,123 @@ func TestComposeAzureResourceID(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceID_Get(t *testing.T) {
+	id, err := parseAzureResourceID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/virtualNetworks/virtualNetwork1/subnets/subnet1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if value, ok := id.Get("VIRTUALNETWORKS"); !ok || value != "virtualNetwork1" {
+		t.Fatalf("Expected the key to be matched case-insensitively but got %q", value)
+	}
+
+	if _, ok := id.Get("networkInterfaces"); ok {
+		t.Fatalf("Expected a missing key not to be found")
+	}
+}
+
+func TestParseAzureResourceIDOfType(t *testing.T) {
+	resourceType := "Microsoft.Network/loadBalancers/loadBalancingRules"
+	testCases := []struct {
+		id            string
+		expectedNames []string
+		expectError   bool
+	}{
+		{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/loadBalancers/lb1/loadBalancingRules/rule1",
+			[]string{"lb1", "rule1"},
+			false,
+		},
+		{
+			// Resource Manager treats the keys and provider case-insensitively
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/microsoft.network/loadbalancers/lb1/LoadBalancingRules/rule1",
+			[]string{"lb1", "rule1"},
+			false,
+		},
+		{
+			// The parent resource
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/loadBalancers/lb1",
+			nil,
+			true,
+		},
+		{
+			// A different type of child
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/loadBalancers/lb1/probes/probe1",
+			nil,
+			true,
+		},
+		{
+			// A different provider
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Compute/loadBalancers/lb1/loadBalancingRules/rule1",
+			nil,
+			true,
+		},
+		{
+			// An extension resource of the rule
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/loadBalancers/lb1/loadBalancingRules/rule1/providers/Microsoft.Authorization/locks/lock1",
+			nil,
+			true,
+		},
+	}
+
+	for _, test := range testCases {
+		_, names, err := parseAzureResourceIDOfType(test.id, resourceType)
+		if err != nil {
+			if test.expectError {
+				continue
+			}
+			t.Fatalf("Unexpected error parsing %q: %s", test.id, err)
+		}
+
+		if test.expectError {
+			t.Fatalf("Expected an error parsing %q", test.id)
+		}
+
+		if !reflect.DeepEqual(test.expectedNames, names) {
+			t.Fatalf("Expected the names %+v but got %+v", test.expectedNames, names)
+		}
+	}
+}
+
+func TestParseAzureResourceIDOfType_errorDescribesFormat(t *testing.T) {
+	_, _, err := parseAzureResourceIDOfType("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/virtualNetworks/vnet1", "Microsoft.Network/virtualNetworks/subnets")
+	if err == nil {
+		t.Fatalf("Expected an error parsing a Virtual Network ID as a Subnet ID")
+	}
+
+	expected := "/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Network/virtualNetworks/{name}/subnets/{name}"
+	if !strings.Contains(err.Error(), expected) {
+		t.Fatalf("Expected the error to contain the format %q but got %q", expected, err)
+	}
+}
+
+func TestParseTypedResourceIDs(t *testing.T) {
+	lbRule, err := parseLoadBalancerChildID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/loadBalancers/lb1/loadBalancingRules/rule1", "loadBalancingRules")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if *lbRule != (loadBalancerChildID{ResourceGroup: "testGroup1", LoadBalancerName: "lb1", Name: "rule1"}) {
+		t.Fatalf("Unexpected Load Balancer Rule ID: %+v", lbRule)
+	}
+
+	subnet, err := parseSubnetID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/virtualNetworks/vnet1/subnets/subnet1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if *subnet != (subnetID{ResourceGroup: "testGroup1", VirtualNetworkName: "vnet1", Name: "subnet1"}) {
+		t.Fatalf("Unexpected Subnet ID: %+v", subnet)
+	}
+
+	record, err := parseDnsRecordID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/dnszones/example.com/MX/mail", "MX")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if *record != (dnsRecordID{ResourceGroup: "testGroup1", ZoneName: "example.com", Name: "mail"}) {
+		t.Fatalf("Unexpected DNS Record ID: %+v", record)
+	}
+
+	if _, err := parseDnsRecordID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Network/dnszones/example.com/A/mail", "MX"); err == nil {
+		t.Fatalf("Expected an error parsing an A Record ID as an MX Record ID")
+	}
+}
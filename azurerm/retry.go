@@ -0,0 +1,285 @@
+package azurerm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const (
+	defaultMinRetryBackoff     = 1 * time.Second
+	defaultMaxRetryBackoff     = 60 * time.Second
+	defaultRateLimitThreshold  = 100
+	defaultMaxRateLimitBackoff = 10 * time.Second
+
+	rateLimitRemainingHeaderPrefix = "X-Ms-Ratelimit-Remaining-"
+)
+
+// retryPolicy describes how requests to the Azure APIs are retried when they're throttled
+// (429), conflict with another in-progress operation (409) or fail with a transient error.
+// A single policy is shared between all of the clients so that the rate limits reported by
+// Resource Manager slow down every request made by the provider, not just the one which
+// observed them.
+type retryPolicy struct {
+	maxRetries  int
+	maxDuration time.Duration
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+
+	// once fewer than rateLimitThreshold requests remain in the current window (as reported
+	// in the `x-ms-ratelimit-remaining-*` headers) requests are spread out by up to
+	// maxRateLimitBackoff, to avoid being throttled outright
+	rateLimitThreshold  int
+	maxRateLimitBackoff time.Duration
+
+	lock      sync.Mutex
+	notBefore time.Time
+}
+
+func newRetryPolicy(maxRetries int, maxDuration time.Duration) *retryPolicy {
+	return &retryPolicy{
+		maxRetries:          maxRetries,
+		maxDuration:         maxDuration,
+		minBackoff:          defaultMinRetryBackoff,
+		maxBackoff:          defaultMaxRetryBackoff,
+		rateLimitThreshold:  defaultRateLimitThreshold,
+		maxRateLimitBackoff: defaultMaxRateLimitBackoff,
+	}
+}
+
+// withRetries returns a SendDecorator which retries requests according to the policy.
+func withRetries(policy *retryPolicy) autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (resp *http.Response, err error) {
+			rr := autorest.NewRetriableRequest(r)
+			deadline := time.Now().Add(policy.maxDuration)
+
+			if !policy.waitForRateLimit(r) {
+				return nil, fmt.Errorf("The request to %s was cancelled", r.URL)
+			}
+
+			for attempt := 0; ; attempt++ {
+				if err = rr.Prepare(); err != nil {
+					return resp, err
+				}
+
+				resp, err = s.Do(rr.Request())
+				policy.recordRateLimit(resp)
+
+				retry, reason := shouldRetryRequest(r, resp, err)
+				if !retry {
+					return resp, err
+				}
+
+				if attempt >= policy.maxRetries {
+					log.Printf("[DEBUG] Giving up on %s %s after %d retries (%s)", r.Method, r.URL, attempt, reason)
+					return resp, err
+				}
+
+				delay := policy.backoff(resp, attempt)
+				if rateLimitDelay := policy.rateLimitDelay(); rateLimitDelay > delay {
+					delay = rateLimitDelay
+				}
+				if time.Now().Add(delay).After(deadline) {
+					log.Printf("[DEBUG] Giving up on %s %s since retrying in %s would exceed %s (%s)", r.Method, r.URL, delay, policy.maxDuration, reason)
+					return resp, err
+				}
+
+				log.Printf("[DEBUG] Retrying %s %s in %s (attempt %d of %d): %s", r.Method, r.URL, delay, attempt+1, policy.maxRetries, reason)
+				if !delayForRequest(r, delay) {
+					return resp, err
+				}
+
+				if resp != nil {
+					// drain the body so the connection can be re-used
+					ioutil.ReadAll(resp.Body)
+					resp.Body.Close()
+				}
+			}
+		})
+	}
+}
+
+// shouldRetryRequest returns whether the request should be retried and if so, why.
+func shouldRetryRequest(r *http.Request, resp *http.Response, err error) (bool, string) {
+	if err != nil {
+		// errors from cancelling the request aren't transient
+		if requestWasCancelled(r) {
+			return false, ""
+		}
+		return true, err.Error()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true, "the request was throttled"
+	case http.StatusRequestTimeout, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true, resp.Status
+	case http.StatusConflict:
+		if code := peekErrorCode(resp); code == "AnotherOperationInProgress" {
+			return true, "another operation is in progress"
+		}
+	}
+
+	return false, ""
+}
+
+// peekErrorCode returns the `code` from the error returned by Resource Manager, leaving
+// the response body intact for the caller to consume.
+func peekErrorCode(resp *http.Response) string {
+	if resp.Body == nil {
+		return ""
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var armError struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &armError); err != nil {
+		return ""
+	}
+
+	return armError.Error.Code
+}
+
+// backoff returns the delay before the next attempt - which is the `Retry-After` returned by
+// the API when present, otherwise an exponential backoff with full jitter.
+func (p *retryPolicy) backoff(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return delay
+		}
+	}
+
+	ceiling := p.minBackoff << uint(attempt)
+	if ceiling <= 0 || ceiling > p.maxBackoff {
+		ceiling = p.maxBackoff
+	}
+
+	return p.minBackoff/2 + time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+// parseRetryAfter parses the `Retry-After` header, which is either a number of seconds
+// or a HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(time.Now())
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
+
+// recordRateLimit inspects the remaining requests reported by Resource Manager and, when
+// they're running low, delays any further requests proportionally.
+func (p *retryPolicy) recordRateLimit(resp *http.Response) {
+	if resp == nil || p.rateLimitThreshold <= 0 {
+		return
+	}
+
+	remaining := -1
+	for header, values := range resp.Header {
+		if !strings.HasPrefix(http.CanonicalHeaderKey(header), rateLimitRemainingHeaderPrefix) || len(values) == 0 {
+			continue
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(values[0]))
+		if err != nil {
+			continue
+		}
+
+		if remaining == -1 || value < remaining {
+			remaining = value
+		}
+	}
+
+	if remaining == -1 || remaining >= p.rateLimitThreshold {
+		return
+	}
+
+	delay := p.maxRateLimitBackoff * time.Duration(p.rateLimitThreshold-remaining) / time.Duration(p.rateLimitThreshold)
+	log.Printf("[DEBUG] Only %d requests remain before Azure Resource Manager throttles requests, delaying further requests by %s", remaining, delay)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if notBefore := time.Now().Add(delay); notBefore.After(p.notBefore) {
+		p.notBefore = notBefore
+	}
+}
+
+// rateLimitDelay returns how long to wait before requests are allowed to be sent.
+func (p *retryPolicy) rateLimitDelay() time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.notBefore.Sub(time.Now())
+}
+
+// waitForRateLimit blocks until requests are allowed to be sent, returning false if the
+// request was cancelled whilst waiting.
+func (p *retryPolicy) waitForRateLimit(r *http.Request) bool {
+	delay := p.rateLimitDelay()
+	if delay <= 0 {
+		return true
+	}
+
+	return delayForRequest(r, delay)
+}
+
+// delayForRequest waits for the specified duration, returning false if the request was
+// cancelled before it elapsed.
+func delayForRequest(r *http.Request, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Cancel:
+		return false
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func requestWasCancelled(r *http.Request) bool {
+	select {
+	case <-r.Cancel:
+		return true
+	case <-r.Context().Done():
+		return true
+	default:
+		return false
+	}
+}
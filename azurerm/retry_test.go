@@ -0,0 +1,252 @@
+package azurerm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// testRetryServer returns each of the responses in turn, repeating the last one once
+// they've been exhausted, and records the requests it received.
+type testRetryServer struct {
+	*httptest.Server
+
+	lock      sync.Mutex
+	responses []func(w http.ResponseWriter)
+	bodies    []string
+	times     []time.Time
+}
+
+func newTestRetryServer(responses ...func(w http.ResponseWriter)) *testRetryServer {
+	s := &testRetryServer{responses: responses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		s.lock.Lock()
+		index := len(s.bodies)
+		s.bodies = append(s.bodies, string(body))
+		s.times = append(s.times, time.Now())
+		s.lock.Unlock()
+
+		if index >= len(s.responses) {
+			index = len(s.responses) - 1
+		}
+		s.responses[index](w)
+	}))
+	return s
+}
+
+func (s *testRetryServer) requestCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.bodies)
+}
+
+func respondWith(statusCode int, headers map[string]string, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(statusCode)
+		fmt.Fprint(w, body)
+	}
+}
+
+func testRetryPolicy(maxRetries int, maxDuration time.Duration) *retryPolicy {
+	policy := newRetryPolicy(maxRetries, maxDuration)
+	policy.minBackoff = 10 * time.Millisecond
+	policy.maxBackoff = 50 * time.Millisecond
+	policy.maxRateLimitBackoff = 200 * time.Millisecond
+	return policy
+}
+
+func sendTestRequest(t *testing.T, policy *retryPolicy, url string, body string) *http.Response {
+	req, err := http.NewRequest("PUT", url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Error building request: %s", err)
+	}
+
+	sender := autorest.CreateSender(withRetries(policy))
+	resp, err := sender.Do(req)
+	if err != nil {
+		t.Fatalf("Unexpected error sending request: %s", err)
+	}
+	return resp
+}
+
+func TestRetry_retryableResponses(t *testing.T) {
+	testCases := []struct {
+		name     string
+		response func(w http.ResponseWriter)
+	}{
+		{
+			name:     "throttled",
+			response: respondWith(http.StatusTooManyRequests, map[string]string{"Retry-After": "0"}, ""),
+		},
+		{
+			name:     "service unavailable",
+			response: respondWith(http.StatusServiceUnavailable, nil, ""),
+		},
+		{
+			name:     "gateway timeout",
+			response: respondWith(http.StatusGatewayTimeout, nil, ""),
+		},
+		{
+			name:     "another operation in progress",
+			response: respondWith(http.StatusConflict, nil, `{"error":{"code":"AnotherOperationInProgress","message":"Another operation on this or dependent resource is in progress."}}`),
+		},
+	}
+
+	for _, tc := range testCases {
+		server := newTestRetryServer(tc.response, tc.response, respondWith(http.StatusOK, nil, `{"id":"example"}`))
+
+		resp := sendTestRequest(t, testRetryPolicy(5, time.Minute), server.URL, `{"name":"example"}`)
+		server.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("[%s] Expected the request to eventually succeed but got %d", tc.name, resp.StatusCode)
+		}
+		if count := server.requestCount(); count != 3 {
+			t.Fatalf("[%s] Expected 3 requests but got %d", tc.name, count)
+		}
+		for _, body := range server.bodies {
+			if body != `{"name":"example"}` {
+				t.Fatalf("[%s] Expected the request body to be re-sent on each attempt but got %q", tc.name, body)
+			}
+		}
+	}
+}
+
+func TestRetry_nonRetryableResponses(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		body       string
+	}{
+		{
+			name:       "not found",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "bad request",
+			statusCode: http.StatusBadRequest,
+			body:       `{"error":{"code":"InvalidParameter"}}`,
+		},
+		{
+			name:       "conflict",
+			statusCode: http.StatusConflict,
+			body:       `{"error":{"code":"Conflict","message":"The resource already exists."}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		server := newTestRetryServer(respondWith(tc.statusCode, nil, tc.body))
+
+		resp := sendTestRequest(t, testRetryPolicy(5, time.Minute), server.URL, "")
+		server.Close()
+
+		if resp.StatusCode != tc.statusCode {
+			t.Fatalf("[%s] Expected %d but got %d", tc.name, tc.statusCode, resp.StatusCode)
+		}
+		if count := server.requestCount(); count != 1 {
+			t.Fatalf("[%s] Expected a single request but got %d", tc.name, count)
+		}
+
+		// the body must still be readable by the SDK after it's been inspected
+		body, _ := ioutil.ReadAll(resp.Body)
+		if string(body) != tc.body {
+			t.Fatalf("[%s] Expected the body %q but got %q", tc.name, tc.body, string(body))
+		}
+	}
+}
+
+func TestRetry_maxRetries(t *testing.T) {
+	server := newTestRetryServer(respondWith(http.StatusInternalServerError, nil, ""))
+	defer server.Close()
+
+	resp := sendTestRequest(t, testRetryPolicy(3, time.Minute), server.URL, "")
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("Expected the last response to be returned but got %d", resp.StatusCode)
+	}
+	if count := server.requestCount(); count != 4 {
+		t.Fatalf("Expected the initial request plus 3 retries but got %d requests", count)
+	}
+}
+
+func TestRetry_maxDuration(t *testing.T) {
+	// the Retry-After exceeds the time budget, so the request shouldn't be retried
+	server := newTestRetryServer(respondWith(http.StatusTooManyRequests, map[string]string{"Retry-After": "30"}, ""))
+	defer server.Close()
+
+	start := time.Now()
+	resp := sendTestRequest(t, testRetryPolicy(10, 5*time.Second), server.URL, "")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Expected the throttled response to be returned but got %d", resp.StatusCode)
+	}
+	if count := server.requestCount(); count != 1 {
+		t.Fatalf("Expected a single request but got %d", count)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Expected to give up immediately but took %s", elapsed)
+	}
+}
+
+func TestRetry_honoursRetryAfter(t *testing.T) {
+	server := newTestRetryServer(
+		respondWith(http.StatusTooManyRequests, map[string]string{"Retry-After": "1"}, ""),
+		respondWith(http.StatusOK, nil, ""))
+	defer server.Close()
+
+	sendTestRequest(t, testRetryPolicy(5, time.Minute), server.URL, "")
+	if count := server.requestCount(); count != 2 {
+		t.Fatalf("Expected 2 requests but got %d", count)
+	}
+	if delay := server.times[1].Sub(server.times[0]); delay < time.Second {
+		t.Fatalf("Expected the retry to wait for the Retry-After of 1s but it waited %s", delay)
+	}
+}
+
+func TestRetry_slowsDownNearRateLimit(t *testing.T) {
+	server := newTestRetryServer(respondWith(http.StatusOK, map[string]string{
+		"x-ms-ratelimit-remaining-subscription-writes": "0",
+		"x-ms-ratelimit-remaining-subscription-reads":  "11999",
+	}, ""))
+	defer server.Close()
+
+	policy := testRetryPolicy(5, time.Minute)
+	sendTestRequest(t, policy, server.URL, "")
+	sendTestRequest(t, policy, server.URL, "")
+
+	if delay := server.times[1].Sub(server.times[0]); delay < 150*time.Millisecond {
+		t.Fatalf("Expected the second request to be delayed as the rate limit was close but it was sent after %s", delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{value: "", ok: false},
+		{value: "abc", ok: false},
+		{value: "-1", ok: false},
+		{value: "0", expected: 0, ok: true},
+		{value: " 17 ", expected: 17 * time.Second, ok: true},
+		{value: "Wed, 21 Oct 2015 07:28:00 GMT", expected: 0, ok: true},
+	}
+
+	for _, tc := range testCases {
+		delay, ok := parseRetryAfter(tc.value)
+		if ok != tc.ok || delay != tc.expected {
+			t.Fatalf("Expected %q to parse as (%s, %t) but got (%s, %t)", tc.value, tc.expected, tc.ok, delay, ok)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package azurerm
+
+import (
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+const searchServicesAPIVersion = "2015-08-19"
+
+// searchServicesClient manages Search Services in the same way as the clients in the SDK,
+// which doesn't (yet) include one for Search.
+type searchServicesClient struct {
+	autorest.Client
+	BaseURI        string
+	SubscriptionID string
+}
+
+func newSearchServicesClientWithBaseURI(baseURI string, subscriptionID string) searchServicesClient {
+	return searchServicesClient{
+		Client:         autorest.NewClientWithUserAgent(""),
+		BaseURI:        baseURI,
+		SubscriptionID: subscriptionID,
+	}
+}
+
+type searchService struct {
+	autorest.Response `json:"-"`
+	ID                *string                  `json:"id,omitempty"`
+	Name              *string                  `json:"name,omitempty"`
+	Location          *string                  `json:"location,omitempty"`
+	Tags              *map[string]*string      `json:"tags,omitempty"`
+	Sku               *searchServiceSku        `json:"sku,omitempty"`
+	Properties        *searchServiceProperties `json:"properties,omitempty"`
+}
+
+type searchServiceSku struct {
+	Name string `json:"name"`
+}
+
+type searchServiceProperties struct {
+	ReplicaCount      *int32  `json:"replicaCount,omitempty"`
+	PartitionCount    *int32  `json:"partitionCount,omitempty"`
+	Status            *string `json:"status,omitempty"`
+	ProvisioningState *string `json:"provisioningState,omitempty"`
+}
+
+// CreateOrUpdate creates or updates the Search Service, waiting for it to be provisioned.
+// Polling can be cancelled using the cancel channel.
+func (client searchServicesClient) CreateOrUpdate(resourceGroupName string, searchServiceName string, parameters searchService, cancel <-chan struct{}) (result searchService, err error) {
+	req, err := client.preparer(resourceGroupName, searchServiceName, cancel,
+		autorest.AsJSON(),
+		autorest.AsPut(),
+		autorest.WithJSON(parameters))
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "azurerm.searchServicesClient", "CreateOrUpdate", nil, "Failure preparing request")
+	}
+
+	resp, err := autorest.SendWithSender(client, req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		return result, autorest.NewErrorWithError(err, "azurerm.searchServicesClient", "CreateOrUpdate", resp, "Failure sending request")
+	}
+
+	err = autorest.Respond(resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK, http.StatusCreated),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "azurerm.searchServicesClient", "CreateOrUpdate", resp, "Failure responding to request")
+	}
+	return result, nil
+}
+
+// Get retrieves the Search Service.
+func (client searchServicesClient) Get(resourceGroupName string, searchServiceName string) (result searchService, err error) {
+	req, err := client.preparer(resourceGroupName, searchServiceName, nil, autorest.AsGet())
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "azurerm.searchServicesClient", "Get", nil, "Failure preparing request")
+	}
+
+	resp, err := autorest.SendWithSender(client, req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		return result, autorest.NewErrorWithError(err, "azurerm.searchServicesClient", "Get", resp, "Failure sending request")
+	}
+
+	err = autorest.Respond(resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&result),
+		autorest.ByClosing())
+	result.Response = autorest.Response{Response: resp}
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "azurerm.searchServicesClient", "Get", resp, "Failure responding to request")
+	}
+	return result, nil
+}
+
+// Delete deletes the Search Service. Polling can be cancelled using the cancel channel.
+func (client searchServicesClient) Delete(resourceGroupName string, searchServiceName string, cancel <-chan struct{}) (result autorest.Response, err error) {
+	req, err := client.preparer(resourceGroupName, searchServiceName, cancel, autorest.AsDelete())
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "azurerm.searchServicesClient", "Delete", nil, "Failure preparing request")
+	}
+
+	resp, err := autorest.SendWithSender(client, req)
+	if err != nil {
+		result.Response = resp
+		return result, autorest.NewErrorWithError(err, "azurerm.searchServicesClient", "Delete", resp, "Failure sending request")
+	}
+
+	err = autorest.Respond(resp,
+		client.ByInspecting(),
+		azure.WithErrorUnlessStatusCode(http.StatusOK, http.StatusNoContent),
+		autorest.ByClosing())
+	result.Response = resp
+	if err != nil {
+		return result, autorest.NewErrorWithError(err, "azurerm.searchServicesClient", "Delete", resp, "Failure responding to request")
+	}
+	return result, nil
+}
+
+func (client searchServicesClient) preparer(resourceGroupName string, searchServiceName string, cancel <-chan struct{}, decorators ...autorest.PrepareDecorator) (*http.Request, error) {
+	pathParameters := map[string]interface{}{
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"searchServiceName": autorest.Encode("path", searchServiceName),
+		"subscriptionId":    autorest.Encode("path", client.SubscriptionID),
+	}
+
+	queryParameters := map[string]interface{}{
+		"api-version": searchServicesAPIVersion,
+	}
+
+	decorators = append(decorators,
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithPathParameters("/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Search/searchServices/{searchServiceName}", pathParameters),
+		autorest.WithQueryParameters(queryParameters))
+	return autorest.CreatePreparer(decorators...).Prepare(&http.Request{Cancel: cancel})
+}
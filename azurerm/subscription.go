@@ -0,0 +1,166 @@
+package azurerm
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// subscriptionIDKey is the argument (added to every resource and data source) which allows it
+// to be managed in a subscription other than the one the provider is configured with.
+const subscriptionIDKey = "subscription_id"
+
+func subscriptionIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ForceNew:         true,
+		ValidateFunc:     validateUUID,
+		DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+	}
+}
+
+func subscriptionIDForDataSourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Computed:         true,
+		ValidateFunc:     validateUUID,
+		DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
+	}
+}
+
+// subscriptionClients caches the ArmClient for each of the subscriptions resources are managed
+// in, which use the same credentials as the provider.
+type subscriptionClients struct {
+	lock    sync.Mutex
+	clients map[string]*ArmClient
+}
+
+func newSubscriptionClients() *subscriptionClients {
+	return &subscriptionClients{
+		clients: make(map[string]*ArmClient),
+	}
+}
+
+// forSubscription returns an ArmClient whose SDK clients manage resources in the subscription,
+// which is built the first time it's used.
+func (armClient *ArmClient) forSubscription(subscriptionID string) *ArmClient {
+	if subscriptionID == "" || strings.EqualFold(subscriptionID, armClient.subscriptionId) {
+		return armClient
+	}
+
+	cache := armClient.subscriptions
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	key := strings.ToLower(subscriptionID)
+	if client, ok := cache.clients[key]; ok {
+		return client
+	}
+
+	log.Printf("[DEBUG] Building the clients for the subscription %q", subscriptionID)
+	client := newSubscriptionArmClient(armClient, subscriptionID)
+	cache.clients[key] = client
+	return client
+}
+
+// newSubscriptionArmClient returns an ArmClient for the subscription, which shares the
+// credentials, HTTP client, retry policy, operation poller and read cache of the provider's
+// ArmClient - but has its own SDK clients, cache of locations and registration of Resource
+// Providers, since they're specific to the subscription.
+func newSubscriptionArmClient(armClient *ArmClient, subscriptionID string) *ArmClient {
+	client := &ArmClient{
+		clientId:       armClient.clientId,
+		tenantId:       armClient.tenantId,
+		subscriptionId: subscriptionID,
+		environment:    armClient.environment,
+		usingMsi:       armClient.usingMsi,
+		usingAzureCli:  armClient.usingAzureCli,
+		retryPolicy:    armClient.retryPolicy,
+		logRedactor:    armClient.logRedactor,
+		httpClient:     armClient.httpClient,
+		clients:        newClientRegistry(),
+		subscriptions:  armClient.subscriptions,
+		metrics:        armClient.metrics,
+		readCache:      armClient.readCache,
+		defaultTags:    armClient.defaultTags,
+
+		ignoreTagKeys:     armClient.ignoreTagKeys,
+		ignoreTagPrefixes: armClient.ignoreTagPrefixes,
+
+		operations:      armClient.operations,
+		armToken:        armClient.armToken,
+		authorizer:      armClient.authorizer,
+		graphAuthorizer: armClient.graphAuthorizer,
+		StopContext:     armClient.StopContext,
+	}
+
+	client.locations = newLocationCache(client.listLocations)
+	if armClient.resourceProviders != nil {
+		client.resourceProviders = armClient.resourceProviders.withClient(client.providers())
+	}
+
+	return client
+}
+
+// setStopContext replaces the StopContext of the ArmClient, along with those of the ArmClients
+// built for other subscriptions.
+func (armClient *ArmClient) setStopContext(ctx context.Context) {
+	armClient.StopContext = ctx
+
+	if cache := armClient.subscriptions; cache != nil {
+		cache.lock.Lock()
+		defer cache.lock.Unlock()
+		for _, client := range cache.clients {
+			client.StopContext = ctx
+		}
+	}
+}
+
+// forResource returns an ArmClient for the subscription the resource is in - which is taken
+// from its ID once it exists, otherwise its `subscription_id` (if one's specified).
+func (armClient *ArmClient) forResource(d *schema.ResourceData) *ArmClient {
+	if id, err := parseAzureResourceID(d.Id()); err == nil && id.SubscriptionID != "" {
+		return armClient.forSubscription(id.SubscriptionID)
+	}
+
+	subscriptionID, _ := d.Get(subscriptionIDKey).(string)
+	return armClient.forSubscription(subscriptionID)
+}
+
+// inResourceSubscription wraps a CRUD function of a resource (or data source), so that it's
+// given an ArmClient for the subscription the resource is in. The subscription is then saved
+// to the state.
+func inResourceSubscription(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, meta interface{}) error {
+		client := meta.(*ArmClient).forResource(d)
+		if err := f(d, client); err != nil {
+			return err
+		}
+
+		if d.Id() != "" {
+			d.Set(subscriptionIDKey, client.subscriptionId)
+		}
+		return nil
+	}
+}
+
+// existsInResourceSubscription is inResourceSubscription for the Exists function of a resource.
+func existsInResourceSubscription(f schema.ExistsFunc) schema.ExistsFunc {
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, meta interface{}) (bool, error) {
+		return f(d, meta.(*ArmClient).forResource(d))
+	}
+}
@@ -0,0 +1,100 @@
+package azurerm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceAzureRMVirtualNetwork_fakeArmSubscription(t *testing.T) {
+	otherSubscriptionID := "11111111-1111-1111-1111-111111111111"
+
+	cases := []struct {
+		Name           string
+		SubscriptionID string
+		Expected       string
+	}{
+		{"provider", "", fakeArmSubscriptionID},
+		{"same", strings.ToUpper(fakeArmSubscriptionID), fakeArmSubscriptionID},
+		{"other", otherSubscriptionID, otherSubscriptionID},
+	}
+
+	for _, tc := range cases {
+		server := newFakeArmServer()
+		defer server.Close()
+
+		meta := server.armClient(t)
+		r := Provider().(*schema.Provider).ResourcesMap["azurerm_virtual_network"]
+		raw := fakeArmVirtualNetworkConfig()
+		if tc.SubscriptionID != "" {
+			raw[subscriptionIDKey] = tc.SubscriptionID
+		}
+
+		state, err := createResource(t, r, raw, meta)
+		if err != nil {
+			t.Fatalf("%s: Error creating the Virtual Network: %s", tc.Name, err)
+		}
+
+		expectedID := fmt.Sprintf("/subscriptions/%s/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example", tc.Expected)
+		if state.ID != expectedID {
+			t.Fatalf("%s: Expected the Virtual Network to be created as %q but got %q", tc.Name, expectedID, state.ID)
+		}
+		if !strings.EqualFold(state.Attributes[subscriptionIDKey], tc.Expected) {
+			t.Fatalf("%s: Expected the subscription %q to be saved to the state but got %q", tc.Name, tc.Expected, state.Attributes[subscriptionIDKey])
+		}
+
+		// an imported resource has no subscription_id, so it's taken from the ID
+		delete(state.Attributes, subscriptionIDKey)
+		refreshed, err := r.Refresh(state, meta)
+		if err != nil {
+			t.Fatalf("%s: Error refreshing the Virtual Network: %s", tc.Name, err)
+		}
+		if refreshed == nil || refreshed.Attributes[subscriptionIDKey] != tc.Expected {
+			t.Fatalf("%s: Expected the Virtual Network to be read from the subscription %q but got %+v", tc.Name, tc.Expected, refreshed)
+		}
+
+		if err := destroyResource(r, refreshed, meta); err != nil {
+			t.Fatalf("%s: Error deleting the Virtual Network: %s", tc.Name, err)
+		}
+		if _, ok := server.get(expectedID); ok {
+			t.Fatalf("%s: Expected the Virtual Network to be deleted from the subscription %q", tc.Name, tc.Expected)
+		}
+	}
+}
+
+func TestArmClient_forSubscription(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	client := server.armClientRegisteringProviders(t)
+	if client.forSubscription("") != client || client.forSubscription(strings.ToUpper(fakeArmSubscriptionID)) != client {
+		t.Fatalf("Expected the provider's subscription to use the provider's client")
+	}
+
+	other := client.forSubscription("11111111-1111-1111-1111-111111111111")
+	if other == client || other.subscriptionId != "11111111-1111-1111-1111-111111111111" {
+		t.Fatalf("Expected a client for the other subscription but got %q", other.subscriptionId)
+	}
+	if other.vnetClient().SubscriptionID != other.subscriptionId || client.vnetClient().SubscriptionID != fakeArmSubscriptionID {
+		t.Fatalf("Expected the SDK clients to be bound to the subscription of the ArmClient")
+	}
+	if other.resourceProviders == client.resourceProviders {
+		t.Fatalf("Expected the Resource Providers to be registered with the other subscription separately")
+	}
+	if other.locations == client.locations {
+		t.Fatalf("Expected the locations of the other subscription to be cached separately")
+	}
+	if client.forSubscription("11111111-1111-1111-1111-111111111111") != other {
+		t.Fatalf("Expected the client for the other subscription to be cached")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.setStopContext(ctx)
+	if other.StopContext != ctx {
+		t.Fatalf("Expected the StopContext of the client for the other subscription to be replaced")
+	}
+}
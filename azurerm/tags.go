@@ -3,6 +3,7 @@ package azurerm
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -35,10 +36,38 @@ func tagValueToString(v interface{}) (string, error) {
 }
 
 func validateAzureRMTags(v interface{}, k string) (ws []string, es []error) {
-	tagsMap := v.(map[string]interface{})
+	return validateAzureRMTagsMerged(v.(map[string]interface{}), nil)
+}
+
+// validateAzureRMTagsWithDefaults returns a validation function for resource tags which also
+// counts the provider's default tags against the limit. The defaults aren't known until the
+// provider is configured (e.g. during `terraform validate`), in which case only the tags on
+// the resource are counted.
+func validateAzureRMTagsWithDefaults(p *schema.Provider) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, es []error) {
+		var defaultTags map[string]string
+		if client, ok := p.Meta().(*ArmClient); ok {
+			defaultTags = client.defaultTags
+		}
 
-	if len(tagsMap) > 15 {
-		es = append(es, errors.New("a maximum of 15 tags can be applied to each ARM resource"))
+		return validateAzureRMTagsMerged(v.(map[string]interface{}), defaultTags)
+	}
+}
+
+func validateAzureRMTagsMerged(tagsMap map[string]interface{}, defaultTags map[string]string) (ws []string, es []error) {
+	count := len(tagsMap)
+	for k := range defaultTags {
+		if _, ok := tagsMap[k]; !ok {
+			count++
+		}
+	}
+
+	if count > 15 {
+		if len(defaultTags) > 0 {
+			es = append(es, fmt.Errorf("a maximum of 15 tags can be applied to each ARM resource: %d tags would be applied including the provider's default tags", count))
+		} else {
+			es = append(es, errors.New("a maximum of 15 tags can be applied to each ARM resource"))
+		}
 	}
 
 	for k, v := range tagsMap {
@@ -57,9 +86,29 @@ func validateAzureRMTags(v interface{}, k string) (ws []string, es []error) {
 	return
 }
 
-func expandTags(tagsMap map[string]interface{}) *map[string]*string {
+// ignoredTagsKey is the attribute (added to every resource with tags) which holds the tags on
+// the resource which are ignored by the provider (see ArmClient.isTagIgnored), so that they're
+// sent back unchanged when the resource is updated rather than being removed.
+const ignoredTagsKey = "ignored_tags"
+
+func ignoredTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+	}
+}
+
+// expandTags merges the tags configured on the resource over the provider's default tags.
+func expandTags(tagsMap map[string]interface{}, meta interface{}) *map[string]*string {
 	output := make(map[string]*string, len(tagsMap))
 
+	if client, ok := meta.(*ArmClient); ok {
+		for k, v := range client.defaultTags {
+			value := v
+			output[k] = &value
+		}
+	}
+
 	for i, v := range tagsMap {
 		//Validate should have ignored this error already
 		value, _ := tagValueToString(v)
@@ -69,17 +118,83 @@ func expandTags(tagsMap map[string]interface{}) *map[string]*string {
 	return &output
 }
 
-func flattenAndSetTags(d *schema.ResourceData, tagsMap *map[string]*string) {
+// expandTagsForResource returns the tags to send for the resource: those configured on it
+// merged over the provider's default tags, along with the ignored tags already on the resource
+// so that they aren't removed by the update.
+func expandTagsForResource(d *schema.ResourceData, meta interface{}) *map[string]*string {
+	tagsMap := make(map[string]interface{})
+	if ignored, ok := d.Get(ignoredTagsKey).(map[string]interface{}); ok {
+		for k, v := range ignored {
+			tagsMap[k] = v
+		}
+	}
+	if configured, ok := d.Get("tags").(map[string]interface{}); ok {
+		for k, v := range configured {
+			tagsMap[k] = v
+		}
+	}
+
+	return expandTags(tagsMap, meta)
+}
+
+// flattenAndSetTags sets the tags on the resource, excluding any which were applied from the
+// provider's default tags (unless they're also configured on the resource) so that they don't
+// show as a diff. Tags which are ignored by the provider are set as the `ignored_tags` of the
+// resource instead, unless they're configured on it. Data Sources pass a nil meta since they
+// expose all of the tags.
+func flattenAndSetTags(d *schema.ResourceData, tagsMap *map[string]*string, meta interface{}) {
+	client, _ := meta.(*ArmClient)
 	if tagsMap == nil {
 		d.Set("tags", make(map[string]interface{}))
+		if client != nil {
+			d.Set(ignoredTagsKey, make(map[string]interface{}))
+		}
 		return
 	}
 
+	var defaultTags map[string]string
+	if client != nil {
+		defaultTags = client.defaultTags
+	}
+	configured, _ := d.Get("tags").(map[string]interface{})
+
 	output := make(map[string]interface{}, len(*tagsMap))
+	ignored := make(map[string]interface{})
 
 	for i, v := range *tagsMap {
+		if _, ok := configured[i]; !ok {
+			if client != nil && client.isTagIgnored(i) {
+				ignored[i] = *v
+				continue
+			}
+			if defaultValue, ok := defaultTags[i]; ok && defaultValue == *v {
+				continue
+			}
+		}
+
 		output[i] = *v
 	}
 
 	d.Set("tags", output)
+	if client != nil {
+		d.Set(ignoredTagsKey, ignored)
+	}
+}
+
+// isTagIgnored returns whether the tag is managed outside of Terraform. Tag keys are compared
+// case-insensitively, as they are by Resource Manager.
+func (c *ArmClient) isTagIgnored(key string) bool {
+	for _, ignored := range c.ignoreTagKeys {
+		if strings.EqualFold(key, ignored) {
+			return true
+		}
+	}
+
+	for _, prefix := range c.ignoreTagPrefixes {
+		if strings.HasPrefix(strings.ToLower(key), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+
+	return false
 }
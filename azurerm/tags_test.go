@@ -1,9 +1,18 @@
 package azurerm
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"strings"
+	"sync"
 	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 func TestValidateMaximumNumberOfARMTags(t *testing.T) {
@@ -74,7 +83,7 @@ func TestExpandARMTags(t *testing.T) {
 	testData["key2"] = 21
 	testData["key3"] = "value3"
 
-	tempExpanded := expandTags(testData)
+	tempExpanded := expandTags(testData, nil)
 	expanded := *tempExpanded
 
 	if len(expanded) != 3 {
@@ -95,3 +104,182 @@ func TestExpandARMTags(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandARMTagsWithDefaults(t *testing.T) {
+	client := &ArmClient{
+		defaultTags: map[string]string{
+			"cost-center": "1234",
+			"environment": "production",
+		},
+	}
+
+	expanded := *expandTags(map[string]interface{}{"environment": "staging", "role": "web"}, client)
+
+	expected := map[string]string{
+		"cost-center": "1234",
+		"environment": "staging",
+		"role":        "web",
+	}
+	if len(expanded) != len(expected) {
+		t.Fatalf("Expected %d tags but got %d: %+v", len(expected), len(expanded), expanded)
+	}
+	for k, v := range expected {
+		if expanded[k] == nil || *expanded[k] != v {
+			t.Fatalf("Expected the tag %q to be %q but got %v", k, v, expanded[k])
+		}
+	}
+}
+
+func TestFlattenAndSetTagsWithDefaults(t *testing.T) {
+	client := &ArmClient{
+		defaultTags: map[string]string{
+			"cost-center": "1234",
+			"environment": "production",
+			"owner":       "ops",
+		},
+	}
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tags": tagsSchema(),
+		},
+	}
+	d := resource.TestResourceData()
+	d.Set("tags", map[string]interface{}{"role": "web", "owner": "ops"})
+
+	cost, owner, environment, role := "1234", "ops", "staging", "web"
+	flattenAndSetTags(d, &map[string]*string{
+		"cost-center": &cost,
+		"owner":       &owner,
+		"environment": &environment,
+		"role":        &role,
+	}, client)
+
+	actual := d.Get("tags").(map[string]interface{})
+	expected := map[string]string{
+		// the default is also configured on the resource
+		"owner": "ops",
+		// the default has been changed outside of Terraform
+		"environment": "staging",
+		"role":        "web",
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d tags but got %d: %+v", len(expected), len(actual), actual)
+	}
+	for k, v := range expected {
+		if actual[k] != v {
+			t.Fatalf("Expected the tag %q to be %q but got %v", k, v, actual[k])
+		}
+	}
+}
+
+func TestValidateMaximumNumberOfARMTagsWithDefaults(t *testing.T) {
+	tagsMap := make(map[string]interface{})
+	for i := 0; i < 10; i++ {
+		tagsMap[fmt.Sprintf("key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+
+	defaultTags := make(map[string]string)
+	for i := 5; i < 15; i++ {
+		defaultTags[fmt.Sprintf("key%d", i)] = fmt.Sprintf("default%d", i)
+	}
+
+	if _, es := validateAzureRMTagsMerged(tagsMap, defaultTags); len(es) != 0 {
+		t.Fatalf("Expected the tags overriding the defaults to only be counted once but got %+v", es)
+	}
+
+	defaultTags["key15"] = "default15"
+	_, es := validateAzureRMTagsMerged(tagsMap, defaultTags)
+	if len(es) != 1 {
+		t.Fatalf("Expected one validation error for too many tags including the defaults but got %+v", es)
+	}
+	if !strings.Contains(es[0].Error(), "16 tags") {
+		t.Fatalf("Expected the number of tags in the validation error but got %q", es[0])
+	}
+}
+
+func TestResourceAzureRMResourceGroup_fakeArmIgnoredTags(t *testing.T) {
+	server := newFakeArmServer()
+	defer server.Close()
+
+	id := fmt.Sprintf("/subscriptions/%s/resourceGroups/example", fakeArmSubscriptionID)
+	server.put(id, map[string]interface{}{
+		"location": "westeurope",
+		"tags": map[string]interface{}{
+			"environment":      "Production",
+			"CreatedBy":        "policy",
+			"hidden-link:/app": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Web/sites/example",
+		},
+	})
+
+	var lock sync.Mutex
+	var sent []byte
+	for _, method := range []string{"PUT", "PATCH"} {
+		server.handle(method, "/resourceGroups/example", func(w http.ResponseWriter, req *http.Request) {
+			body, _ := ioutil.ReadAll(req.Body)
+			lock.Lock()
+			sent = body
+			lock.Unlock()
+
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			server.serveResource(w, req, strings.TrimSuffix(req.URL.Path, "/"))
+		})
+	}
+
+	meta := server.armClient(t)
+	meta.ignoreTagKeys = []string{"createdby"}
+	meta.ignoreTagPrefixes = []string{"hidden-link:"}
+	r := Provider().(*schema.Provider).ResourcesMap["azurerm_resource_group"]
+
+	// the ignored tags are read into `ignored_tags` rather than the tags
+	state, err := r.Refresh(&terraform.InstanceState{
+		ID: id,
+		Attributes: map[string]string{
+			"name":             "example",
+			"location":         "westeurope",
+			"tags.%":           "1",
+			"tags.environment": "Production",
+		},
+	}, meta)
+	if err != nil {
+		t.Fatalf("Error refreshing the Resource Group: %s", err)
+	}
+	if state.Attributes["tags.%"] != "1" || state.Attributes["ignored_tags.%"] != "2" || state.Attributes["ignored_tags.CreatedBy"] != "policy" {
+		t.Fatalf("Expected the ignored tags to be kept apart from the tags but got %+v", state.Attributes)
+	}
+
+	raw := map[string]interface{}{
+		"name":     "example",
+		"location": "westeurope",
+		"tags": map[string]interface{}{
+			"environment": "Production",
+		},
+	}
+	c, err := config.NewRawConfig(raw)
+	if err != nil {
+		t.Fatalf("Error building the config: %s", err)
+	}
+	diff, err := r.Diff(state, terraform.NewResourceConfig(c))
+	if err != nil {
+		t.Fatalf("Error diffing the Resource Group: %s", err)
+	}
+	if diff != nil && len(diff.Attributes) > 0 {
+		t.Fatalf("Expected no changes to the Resource Group but got %+v", diff.Attributes)
+	}
+
+	// the ignored tags are sent back along with the tags which changed
+	raw["tags"] = map[string]interface{}{"environment": "Staging"}
+	if _, err := updateResource(t, r, state, raw, meta); err != nil {
+		t.Fatalf("Error updating the Resource Group: %s", err)
+	}
+
+	var tags struct {
+		Tags map[string]string `json:"tags"`
+	}
+	lock.Lock()
+	json.Unmarshal(sent, &tags)
+	lock.Unlock()
+	if len(tags.Tags) != 3 || tags.Tags["environment"] != "Staging" || tags.Tags["CreatedBy"] != "policy" || tags.Tags["hidden-link:/app"] == "" {
+		t.Fatalf("Expected the ignored tags to be sent with the updated tags but got %+v", tags.Tags)
+	}
+}
@@ -0,0 +1,149 @@
+package azurerm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+)
+
+// auxiliaryAuthorizationHeader carries the tokens for the auxiliary tenants, which Resource
+// Manager requires for operations referencing resources in another tenant (such as peering
+// with a Virtual Network in another tenant).
+const auxiliaryAuthorizationHeader = "x-ms-authorization-auxiliary"
+
+// maxAuxiliaryTenants is the number of auxiliary tokens Resource Manager accepts in a request.
+const maxAuxiliaryTenants = 3
+
+// tokenClaims represents the subset of claims within an Azure Active Directory access
+// token which describe the identity the token was issued to.
+type tokenClaims struct {
+	AppID    string `json:"appid"`
+	ObjectID string `json:"oid"`
+	TenantID string `json:"tid"`
+	UPN      string `json:"upn"`
+}
+
+// parseTokenClaims decodes the claims from the payload of a JWT access token. The
+// signature isn't validated since the token was obtained directly from Azure AD.
+func parseTokenClaims(token string) (*tokenClaims, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return nil, fmt.Errorf("Expected the token to have 3 segments but got %d", len(segments))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segments[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding the token payload: %s", err)
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("Error unmarshalling the token claims: %s", err)
+	}
+
+	return &claims, nil
+}
+
+// getArmTokenClaims returns the claims from the token used to authorize requests to the
+// Resource Manager API, refreshing it first if necessary.
+func (armClient *ArmClient) getArmTokenClaims() (*tokenClaims, error) {
+	if err := armClient.armToken.EnsureFresh(); err != nil {
+		return nil, fmt.Errorf("Error obtaining a token for the Resource Manager API: %s", err)
+	}
+
+	claims, err := parseTokenClaims(armClient.armToken.OAuthToken())
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the claims from the Resource Manager token: %s", err)
+	}
+
+	return claims, nil
+}
+
+// synchronizedToken serializes the use of a token which is shared by all of the SDK clients
+// (and so by resources running in parallel), since the refresh of an adal
+// ServicePrincipalToken isn't safe for concurrent use.
+type synchronizedToken struct {
+	lock  sync.Mutex
+	token *adal.ServicePrincipalToken
+}
+
+func newSynchronizedToken(token *adal.ServicePrincipalToken) *synchronizedToken {
+	return &synchronizedToken{
+		token: token,
+	}
+}
+
+func (t *synchronizedToken) OAuthToken() string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.token.OAuthToken()
+}
+
+func (t *synchronizedToken) EnsureFresh() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.token.EnsureFresh()
+}
+
+func (t *synchronizedToken) Refresh() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.token.Refresh()
+}
+
+func (t *synchronizedToken) RefreshExchange(resource string) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.token.RefreshExchange(resource)
+}
+
+// auxiliaryTenantAuthorizer authorizes requests to Resource Manager using the token for the
+// primary tenant, along with a token for each of the auxiliary tenants.
+type auxiliaryTenantAuthorizer struct {
+	primary   autorest.Authorizer
+	auxiliary []*synchronizedToken
+}
+
+func newAuxiliaryTenantAuthorizer(primary autorest.Authorizer, auxiliary []*synchronizedToken) autorest.Authorizer {
+	if len(auxiliary) == 0 {
+		return primary
+	}
+
+	return &auxiliaryTenantAuthorizer{
+		primary:   primary,
+		auxiliary: auxiliary,
+	}
+}
+
+// WithAuthorization returns a PrepareDecorator which adds the Authorization header and the
+// auxiliary tokens, refreshing the tokens first if necessary.
+func (a *auxiliaryTenantAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := a.primary.WithAuthorization()(p).Prepare(r)
+			if err != nil {
+				return r, err
+			}
+
+			tokens := make([]string, 0, len(a.auxiliary))
+			for _, token := range a.auxiliary {
+				if err := token.EnsureFresh(); err != nil {
+					return r, fmt.Errorf("Error obtaining a token for an auxiliary tenant: %s", err)
+				}
+				tokens = append(tokens, fmt.Sprintf("Bearer %s", token.OAuthToken()))
+			}
+
+			return autorest.Prepare(r, autorest.WithHeader(auxiliaryAuthorizationHeader, strings.Join(tokens, ", ")))
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package azurerm
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestParseTokenClaims(t *testing.T) {
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+	header := encode(`{"typ":"JWT","alg":"RS256"}`)
+
+	testCases := []struct {
+		token       string
+		expected    tokenClaims
+		expectError bool
+	}{
+		{
+			token:       "",
+			expectError: true,
+		},
+		{
+			token:       "abc.def",
+			expectError: true,
+		},
+		{
+			token:       header + ".!!!.signature",
+			expectError: true,
+		},
+		{
+			token:       header + "." + encode("not-json") + ".signature",
+			expectError: true,
+		},
+		{
+			token: header + "." + encode(`{"appid":"11111111-1111-1111-1111-111111111111","oid":"22222222-2222-2222-2222-222222222222","tid":"33333333-3333-3333-3333-333333333333"}`) + ".signature",
+			expected: tokenClaims{
+				AppID:    "11111111-1111-1111-1111-111111111111",
+				ObjectID: "22222222-2222-2222-2222-222222222222",
+				TenantID: "33333333-3333-3333-3333-333333333333",
+			},
+		},
+		{
+			token: header + "." + base64.URLEncoding.EncodeToString([]byte(`{"oid":"22222222-2222-2222-2222-222222222222","upn":"user@example.com"}`)) + ".signature",
+			expected: tokenClaims{
+				ObjectID: "22222222-2222-2222-2222-222222222222",
+				UPN:      "user@example.com",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		claims, err := parseTokenClaims(tc.token)
+		if tc.expectError {
+			if err == nil {
+				t.Fatalf("Expected an error parsing %q but didn't get one", tc.token)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %s", tc.token, err)
+		}
+
+		if *claims != tc.expected {
+			t.Fatalf("Expected %+v but got %+v", tc.expected, *claims)
+		}
+	}
+}
@@ -2,7 +2,9 @@ package azurerm
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/satori/uuid"
 )
 
@@ -12,3 +14,55 @@ func validateUUID(v interface{}, k string) (ws []string, errors []error) {
 	}
 	return
 }
+
+func validateRFC3339Date(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q is an invalid RFC3339 date: %s", k, err))
+	}
+	return
+}
+
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q is an invalid duration: %s", k, err))
+	}
+	return
+}
+
+func validateResourceID(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parseAzureResourceID(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q is an invalid Resource ID: %s", k, err))
+	}
+	return
+}
+
+// validateResourceIDOrEmpty is validateResourceID for optional arguments which are commonly
+// interpolated from a variable with an empty default.
+func validateResourceIDOrEmpty(v interface{}, k string) (ws []string, errors []error) {
+	if v.(string) == "" {
+		return
+	}
+	return validateResourceID(v, k)
+}
+
+// validateResourceIDOfType returns a SchemaValidateFunc which checks the value is the ID of
+// a resource of the specified type, such as `Microsoft.Network/virtualNetworks/subnets`.
+func validateResourceIDOfType(resourceType string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		if _, _, err := parseAzureResourceIDOfType(v.(string), resourceType); err != nil {
+			errors = append(errors, fmt.Errorf("%q is an invalid Resource ID: %s", k, err))
+		}
+		return
+	}
+}
+
+// validateResourceIDOfTypeOrEmpty is validateResourceIDOfType for optional arguments.
+func validateResourceIDOfTypeOrEmpty(resourceType string) schema.SchemaValidateFunc {
+	validate := validateResourceIDOfType(resourceType)
+	return func(v interface{}, k string) (ws []string, errors []error) {
+		if v.(string) == "" {
+			return
+		}
+		return validate(v, k)
+	}
+}
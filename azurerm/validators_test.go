@@ -0,0 +1,66 @@
+package azurerm
+
+import "testing"
+
+func TestValidateResourceIDOfType(t *testing.T) {
+	cases := []struct {
+		ID     string
+		Errors int
+	}{
+		{
+			ID:     "",
+			Errors: 1,
+		},
+		{
+			ID:     "example-subnet",
+			Errors: 1,
+		},
+		{
+			ID:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example",
+			Errors: 1,
+		},
+		{
+			ID:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example/subnets/example",
+			Errors: 0,
+		},
+		{
+			ID:     "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example/providers/microsoft.network/virtualnetworks/example/subnets/example",
+			Errors: 0,
+		},
+	}
+
+	validate := validateResourceIDOfType("Microsoft.Network/virtualNetworks/subnets")
+	for _, tc := range cases {
+		_, errors := validate(tc.ID, "subnet_id")
+
+		if len(errors) != tc.Errors {
+			t.Fatalf("Expected %d errors validating %q but got %d: %+v", tc.Errors, tc.ID, len(errors), errors)
+		}
+	}
+}
+
+func TestValidateResourceIDOfTypeOrEmpty(t *testing.T) {
+	validate := validateResourceIDOfTypeOrEmpty("Microsoft.Network/networkSecurityGroups")
+
+	if _, errors := validate("", "network_security_group_id"); len(errors) != 0 {
+		t.Fatalf("Expected no errors for an empty value but got %+v", errors)
+	}
+
+	if _, errors := validate("example-nsg", "network_security_group_id"); len(errors) != 1 {
+		t.Fatalf("Expected an error for a name in place of an ID but got %+v", errors)
+	}
+}
+
+func TestValidateResourceID(t *testing.T) {
+	if _, errors := validateResourceID("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Compute/snapshots/example", "source_resource_id"); len(errors) != 0 {
+		t.Fatalf("Expected no errors for a valid ID but got %+v", errors)
+	}
+
+	if _, errors := validateResourceID("example", "source_resource_id"); len(errors) != 1 {
+		t.Fatalf("Expected an error for an invalid ID but got %+v", errors)
+	}
+
+	if _, errors := validateResourceIDOrEmpty("", "source_resource_id"); len(errors) != 0 {
+		t.Fatalf("Expected no errors for an empty value but got %+v", errors)
+	}
+}